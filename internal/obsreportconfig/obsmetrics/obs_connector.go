@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsmetrics // import "go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+)
+
+const (
+	// ConnectorKey used to identify connectors in metrics and traces.
+	ConnectorKey = "connector"
+
+	// FromPipelineKey used to identify the pipeline a connector failed to route data to.
+	FromPipelineKey = "from_pipeline"
+	// ToPipelineKey used to identify the pipeline a connector rerouted data to.
+	ToPipelineKey = "to_pipeline"
+
+	// ReroutedSpansKey used to track the spans rerouted by connectors to a fallback pipeline.
+	ReroutedSpansKey = "rerouted_spans"
+	// ReroutedMetricPointsKey used to track the metric points rerouted by connectors to a fallback pipeline.
+	ReroutedMetricPointsKey = "rerouted_metric_points"
+	// ReroutedLogRecordsKey used to track the log records rerouted by connectors to a fallback pipeline.
+	ReroutedLogRecordsKey = "rerouted_log_records"
+)
+
+var (
+	TagKeyConnector, _    = tag.NewKey(ConnectorKey)
+	TagKeyFromPipeline, _ = tag.NewKey(FromPipelineKey)
+	TagKeyToPipeline, _   = tag.NewKey(ToPipelineKey)
+
+	ConnectorPrefix = ConnectorKey + NameSep
+
+	// Connector metrics. A connector consumes data as an exporter of the pipelines
+	// it is part of and emits it as a receiver of other pipelines, so it reports
+	// both the receiver and the exporter measures under its own prefix.
+	ConnectorAcceptedSpans = stats.Int64(
+		ConnectorPrefix+AcceptedSpansKey,
+		"Number of spans successfully pushed into the pipeline.",
+		UnitSpans)
+	ConnectorRefusedSpans = stats.Int64(
+		ConnectorPrefix+RefusedSpansKey,
+		"Number of spans that could not be pushed into the pipeline.",
+		UnitSpans)
+	ConnectorAcceptedMetricPoints = stats.Int64(
+		ConnectorPrefix+AcceptedMetricPointsKey,
+		"Number of metric points successfully pushed into the pipeline.",
+		UnitMetricPoints)
+	ConnectorRefusedMetricPoints = stats.Int64(
+		ConnectorPrefix+RefusedMetricPointsKey,
+		"Number of metric points that could not be pushed into the pipeline.",
+		UnitMetricPoints)
+	ConnectorAcceptedLogRecords = stats.Int64(
+		ConnectorPrefix+AcceptedLogRecordsKey,
+		"Number of log records successfully pushed into the pipeline.",
+		UnitLogRecords)
+	ConnectorRefusedLogRecords = stats.Int64(
+		ConnectorPrefix+RefusedLogRecordsKey,
+		"Number of log records that could not be pushed into the pipeline.",
+		UnitLogRecords)
+	ConnectorAcceptedSpanEvents = stats.Int64(
+		ConnectorPrefix+AcceptedSpanEventsKey,
+		"Number of span events successfully pushed into the pipeline.",
+		UnitSpanEvents)
+	ConnectorRefusedSpanEvents = stats.Int64(
+		ConnectorPrefix+RefusedSpanEventsKey,
+		"Number of span events that could not be pushed into the pipeline.",
+		UnitSpanEvents)
+	ConnectorAcceptedSpanLinks = stats.Int64(
+		ConnectorPrefix+AcceptedSpanLinksKey,
+		"Number of span links successfully pushed into the pipeline.",
+		UnitSpanLinks)
+	ConnectorRefusedSpanLinks = stats.Int64(
+		ConnectorPrefix+RefusedSpanLinksKey,
+		"Number of span links that could not be pushed into the pipeline.",
+		UnitSpanLinks)
+	ConnectorAcceptedSpansByClockSkew = stats.Int64(
+		ConnectorPrefix+AcceptedSpansByClockSkewKey,
+		"Number of spans successfully pushed into the pipeline by clock skew range of their timestamps.",
+		UnitSpans)
+	ConnectorSentSpans = stats.Int64(
+		ConnectorPrefix+SentSpansKey,
+		"Number of spans successfully sent to destination.",
+		UnitSpans)
+	ConnectorFailedToSendSpans = stats.Int64(
+		ConnectorPrefix+FailedToSendSpansKey,
+		"Number of spans in failed attempts to send to destination.",
+		UnitSpans)
+	ConnectorSentMetricPoints = stats.Int64(
+		ConnectorPrefix+SentMetricPointsKey,
+		"Number of metric points successfully sent to destination.",
+		UnitMetricPoints)
+	ConnectorFailedToSendMetricPoints = stats.Int64(
+		ConnectorPrefix+FailedToSendMetricPointsKey,
+		"Number of metric points in failed attempts to send to destination.",
+		UnitMetricPoints)
+	ConnectorSentLogRecords = stats.Int64(
+		ConnectorPrefix+SentLogRecordsKey,
+		"Number of log record successfully sent to destination.",
+		UnitLogRecords)
+	ConnectorFailedToSendLogRecords = stats.Int64(
+		ConnectorPrefix+FailedToSendLogRecordsKey,
+		"Number of log records in failed attempts to send to destination.",
+		UnitLogRecords)
+	ConnectorReroutedSpans = stats.Int64(
+		ConnectorPrefix+ReroutedSpansKey,
+		"Number of spans rerouted to a fallback pipeline after failing to be routed to another.",
+		UnitSpans)
+	ConnectorReroutedMetricPoints = stats.Int64(
+		ConnectorPrefix+ReroutedMetricPointsKey,
+		"Number of metric points rerouted to a fallback pipeline after failing to be routed to another.",
+		UnitMetricPoints)
+	ConnectorReroutedLogRecords = stats.Int64(
+		ConnectorPrefix+ReroutedLogRecordsKey,
+		"Number of log records rerouted to a fallback pipeline after failing to be routed to another.",
+		UnitLogRecords)
+)
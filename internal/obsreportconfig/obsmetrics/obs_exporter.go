@@ -37,10 +37,94 @@ const (
 	SentLogRecordsKey = "sent_log_records"
 	// FailedToSendLogRecordsKey used to track logs that failed to be sent by exporters.
 	FailedToSendLogRecordsKey = "send_failed_log_records"
+
+	// FailedToSendSpansByCodeKey used to track spans that failed to be sent by exporters
+	// broken down by the status code returned by the destination.
+	FailedToSendSpansByCodeKey = "send_failed_spans_by_code"
+	// GRPCStatusCodeKey used to identify the gRPC status code returned by the destination.
+	GRPCStatusCodeKey = "rpc.grpc.status_code"
+	// HTTPStatusCodeKey used to identify the HTTP status code returned by the destination.
+	HTTPStatusCodeKey = "http.status_code"
+
+	// SentSpansByDestinationKey used to track spans sent by exporters broken down by
+	// the destination that handled them.
+	SentSpansByDestinationKey = "sent_spans_by_destination"
+	// FailedToSendSpansByDestinationKey used to track spans that failed to be sent by
+	// exporters broken down by the destination that handled them.
+	FailedToSendSpansByDestinationKey = "send_failed_spans_by_destination"
+	// DestinationKey used to identify the destination of exporters with failover.
+	DestinationKey = "destination"
+
+	// ConnectionStateKey used to track the state of the connection of exporters to the destination.
+	ConnectionStateKey = "connection_state"
+	// ConnectionStateTransitionsKey used to track the state changes of the connection of exporters.
+	ConnectionStateTransitionsKey = "connection_state_transitions"
+	// StateKey used to identify the state of the connection of exporters.
+	StateKey = "state"
+
+	// BackpressureKey used to track whether exporters are under backpressure from the destination.
+	BackpressureKey = "backpressure"
+	// BackpressureDurationKey used to track the time spent by exporters under backpressure.
+	BackpressureDurationKey = "backpressure_duration"
+
+	// SentBatchSizeKey used to track the number of items in the batches sent by exporters.
+	SentBatchSizeKey = "sent_batch_size"
+	// SignalKey used to identify the signal, ie.: the data type, of the batches sent by exporters.
+	SignalKey = "signal"
+
+	// SendDurationKey used to track the duration of the export operations.
+	SendDurationKey = "send_duration"
+	// AckLatencyKey used to track the time until the destination acknowledged the data sent by exporters.
+	AckLatencyKey = "ack_latency"
+	// QueueWaitKey used to track the time the data waited in the queue of exporters before being sent.
+	QueueWaitKey = "queue_wait"
+	// OutcomeKey used to identify whether an export operation succeeded or failed.
+	OutcomeKey = "outcome"
+
+	// PersistentQueueItemsKey used to track the number of items held in the persistent queue of exporters.
+	PersistentQueueItemsKey = "persistent_queue_items"
+	// PersistentQueueBytesKey used to track the size of the persistent queue of exporters on disk.
+	PersistentQueueBytesKey = "persistent_queue_bytes"
+	// OldestQueuedAgeKey used to track the age of the oldest item queued by exporters.
+	OldestQueuedAgeKey = "oldest_queued_age"
+
+	// SendsKey used to track the export operations of exporters, by compression codec.
+	SendsKey = "sends"
+	// RequestsKey used to track the export requests of exporters, by outcome, regardless of the number of items.
+	RequestsKey = "requests"
+	// CompressionKey used to identify the compression codec of the data sent by exporters.
+	CompressionKey = "compression"
+
+	// RetriesExhaustedSpansKey used to track spans dropped by exporters after exhausting the retries.
+	RetriesExhaustedSpansKey = "retries_exhausted_spans"
+	// RetriesExhaustedMetricPointsKey used to track metric points dropped by exporters after exhausting the retries.
+	RetriesExhaustedMetricPointsKey = "retries_exhausted_metric_points"
+	// RetriesExhaustedLogRecordsKey used to track log records dropped by exporters after exhausting the retries.
+	RetriesExhaustedLogRecordsKey = "retries_exhausted_log_records"
+
+	// PartialWarningsTracesKey used to track the traces export responses of exporters with a partial success warning.
+	PartialWarningsTracesKey = "partial_warnings_traces"
+	// PartialWarningsMetricsKey used to track the metrics export responses of exporters with a partial success warning.
+	PartialWarningsMetricsKey = "partial_warnings_metrics"
+	// PartialWarningsLogsKey used to track the logs export responses of exporters with a partial success warning.
+	PartialWarningsLogsKey = "partial_warnings_logs"
+
+	// TLSErrorsKey used to track the TLS errors of exporters, by kind.
+	TLSErrorsKey = "tls_errors"
+	// TLSErrorKindKey used to identify the kind of the TLS errors of exporters.
+	TLSErrorKindKey = "kind"
 )
 
 var (
-	TagKeyExporter, _ = tag.NewKey(ExporterKey)
+	TagKeyExporter, _       = tag.NewKey(ExporterKey)
+	TagKeyGRPCStatusCode, _ = tag.NewKey(GRPCStatusCodeKey)
+	TagKeyHTTPStatusCode, _ = tag.NewKey(HTTPStatusCodeKey)
+	TagKeyState, _          = tag.NewKey(StateKey)
+	TagKeyDestination, _    = tag.NewKey(DestinationKey)
+	TagKeySignal, _         = tag.NewKey(SignalKey)
+	TagKeyOutcome, _        = tag.NewKey(OutcomeKey)
+	TagKeyCompression, _    = tag.NewKey(CompressionKey)
+	TagKeyTLSErrorKind, _   = tag.NewKey(TLSErrorKindKey)
 
 	ExporterPrefix                 = ExporterKey + NameSep
 	ExportTraceDataOperationSuffix = NameSep + "traces"
@@ -55,25 +139,117 @@ var (
 	ExporterSentSpans = stats.Int64(
 		ExporterPrefix+SentSpansKey,
 		"Number of spans successfully sent to destination.",
-		stats.UnitDimensionless)
+		UnitSpans)
 	ExporterFailedToSendSpans = stats.Int64(
 		ExporterPrefix+FailedToSendSpansKey,
 		"Number of spans in failed attempts to send to destination.",
-		stats.UnitDimensionless)
+		UnitSpans)
 	ExporterSentMetricPoints = stats.Int64(
 		ExporterPrefix+SentMetricPointsKey,
 		"Number of metric points successfully sent to destination.",
-		stats.UnitDimensionless)
+		UnitMetricPoints)
 	ExporterFailedToSendMetricPoints = stats.Int64(
 		ExporterPrefix+FailedToSendMetricPointsKey,
 		"Number of metric points in failed attempts to send to destination.",
-		stats.UnitDimensionless)
+		UnitMetricPoints)
 	ExporterSentLogRecords = stats.Int64(
 		ExporterPrefix+SentLogRecordsKey,
 		"Number of log record successfully sent to destination.",
-		stats.UnitDimensionless)
+		UnitLogRecords)
 	ExporterFailedToSendLogRecords = stats.Int64(
 		ExporterPrefix+FailedToSendLogRecordsKey,
 		"Number of log records in failed attempts to send to destination.",
-		stats.UnitDimensionless)
+		UnitLogRecords)
+	ExporterFailedToSendSpansByCode = stats.Int64(
+		ExporterPrefix+FailedToSendSpansByCodeKey,
+		"Number of spans in failed attempts to send to destination by status code.",
+		UnitSpans)
+	ExporterSentSpansByDestination = stats.Int64(
+		ExporterPrefix+SentSpansByDestinationKey,
+		"Number of spans successfully sent by destination.",
+		UnitSpans)
+	ExporterFailedToSendSpansByDestination = stats.Int64(
+		ExporterPrefix+FailedToSendSpansByDestinationKey,
+		"Number of spans in failed attempts to send by destination.",
+		UnitSpans)
+	ExporterConnectionState = stats.Int64(
+		ExporterPrefix+ConnectionStateKey,
+		"Whether the connection to the destination is in the given state (1) or not (0).",
+		UnitConnections)
+	ExporterConnectionStateTransitions = stats.Int64(
+		ExporterPrefix+ConnectionStateTransitionsKey,
+		"Number of times the connection to the destination changed to the given state.",
+		UnitTransitions)
+	ExporterBackpressure = stats.Int64(
+		ExporterPrefix+BackpressureKey,
+		"Whether the destination applies backpressure to the exporter (1) or not (0).",
+		UnitExporters)
+	ExporterBackpressureDuration = stats.Float64(
+		ExporterPrefix+BackpressureDurationKey,
+		"Time spent by the exporter under backpressure from the destination.",
+		stats.UnitMilliseconds)
+	ExporterSentBatchSize = stats.Int64(
+		ExporterPrefix+SentBatchSizeKey,
+		"Number of items in the batches the exporter attempted to send.",
+		UnitItems)
+	ExporterSendDuration = stats.Float64(
+		ExporterPrefix+SendDurationKey,
+		"Duration of the export operations by outcome.",
+		stats.UnitMilliseconds)
+	ExporterAckLatency = stats.Float64(
+		ExporterPrefix+AckLatencyKey,
+		"Time from sending the data until the destination acknowledged it.",
+		stats.UnitMilliseconds)
+	ExporterQueueWait = stats.Float64(
+		ExporterPrefix+QueueWaitKey,
+		"Time the data waited in the queue of the exporter before the export operation started.",
+		stats.UnitMilliseconds)
+	ExporterPersistentQueueItems = stats.Int64(
+		ExporterPrefix+PersistentQueueItemsKey,
+		"Number of items held in the persistent queue of the exporter.",
+		UnitItems)
+	ExporterPersistentQueueBytes = stats.Int64(
+		ExporterPrefix+PersistentQueueBytesKey,
+		"Size in bytes of the persistent queue of the exporter on disk.",
+		stats.UnitBytes)
+	ExporterOldestQueuedAge = stats.Float64(
+		ExporterPrefix+OldestQueuedAgeKey,
+		"Age of the oldest item in the queue of the exporter.",
+		stats.UnitMilliseconds)
+	ExporterSends = stats.Int64(
+		ExporterPrefix+SendsKey,
+		"Number of export operations by the compression codec of the data sent.",
+		UnitSends)
+	ExporterRequests = stats.Int64(
+		ExporterPrefix+RequestsKey,
+		"Number of export requests by outcome, regardless of the number of items they hold.",
+		UnitRequests)
+	ExporterRetriesExhaustedSpans = stats.Int64(
+		ExporterPrefix+RetriesExhaustedSpansKey,
+		"Number of spans dropped after exhausting the retries to send them to destination.",
+		UnitSpans)
+	ExporterRetriesExhaustedMetricPoints = stats.Int64(
+		ExporterPrefix+RetriesExhaustedMetricPointsKey,
+		"Number of metric points dropped after exhausting the retries to send them to destination.",
+		UnitMetricPoints)
+	ExporterRetriesExhaustedLogRecords = stats.Int64(
+		ExporterPrefix+RetriesExhaustedLogRecordsKey,
+		"Number of log records dropped after exhausting the retries to send them to destination.",
+		UnitLogRecords)
+	ExporterPartialWarningsTraces = stats.Int64(
+		ExporterPrefix+PartialWarningsTracesKey,
+		"Number of traces export responses with a partial success warning, whether or not items were rejected.",
+		UnitRequests)
+	ExporterPartialWarningsMetrics = stats.Int64(
+		ExporterPrefix+PartialWarningsMetricsKey,
+		"Number of metrics export responses with a partial success warning, whether or not items were rejected.",
+		UnitRequests)
+	ExporterPartialWarningsLogs = stats.Int64(
+		ExporterPrefix+PartialWarningsLogsKey,
+		"Number of logs export responses with a partial success warning, whether or not items were rejected.",
+		UnitRequests)
+	ExporterTLSErrors = stats.Int64(
+		ExporterPrefix+TLSErrorsKey,
+		"Number of TLS errors of the exporter by kind, e.g. handshake failures or expired certificates.",
+		UnitFailures)
 )
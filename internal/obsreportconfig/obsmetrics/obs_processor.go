@@ -31,10 +31,164 @@ const (
 
 	// DroppedLogRecordsKey is the key used to identify log records dropped by the Collector.
 	DroppedLogRecordsKey = "dropped_log_records"
+
+	// DeduplicatedSpansKey is the key used to identify duplicated spans dropped by the Collector.
+	DeduplicatedSpansKey = "deduplicated_spans"
+
+	// DeduplicatedMetricPointsKey is the key used to identify duplicated metric points dropped by the Collector.
+	DeduplicatedMetricPointsKey = "deduplicated_metric_points"
+
+	// DeduplicatedLogRecordsKey is the key used to identify duplicated log records dropped by the Collector.
+	DeduplicatedLogRecordsKey = "deduplicated_log_records"
+
+	// PassthroughSpansKey is the key used to identify spans passed through unchanged by the Collector.
+	PassthroughSpansKey = "passthrough_spans"
+
+	// PassthroughMetricPointsKey is the key used to identify metric points passed through unchanged by the Collector.
+	PassthroughMetricPointsKey = "passthrough_metric_points"
+
+	// PassthroughLogRecordsKey is the key used to identify log records passed through unchanged by the Collector.
+	PassthroughLogRecordsKey = "passthrough_log_records"
+
+	// MemoryLimitedSpansKey is the key used to identify spans refused by the Collector under memory pressure.
+	MemoryLimitedSpansKey = "memory_limited_spans"
+
+	// MemoryLimitedMetricPointsKey is the key used to identify metric points refused by the Collector under memory pressure.
+	MemoryLimitedMetricPointsKey = "memory_limited_metric_points"
+
+	// MemoryLimitedLogRecordsKey is the key used to identify log records refused by the Collector under memory pressure.
+	MemoryLimitedLogRecordsKey = "memory_limited_log_records"
+
+	// DroppedSpanBytesKey is the key used to identify the size of the spans dropped by the Collector.
+	DroppedSpanBytesKey = "dropped_bytes_spans"
+
+	// DroppedMetricPointBytesKey is the key used to identify the size of the metric points dropped by the Collector.
+	DroppedMetricPointBytesKey = "dropped_bytes_metric_points"
+
+	// DroppedLogRecordBytesKey is the key used to identify the size of the log records dropped by the Collector.
+	DroppedLogRecordBytesKey = "dropped_bytes_log_records"
+
+	// ProcessingErrorsSpansKey is the key used to identify spans a processor failed to fully process but passed on.
+	ProcessingErrorsSpansKey = "processing_errors_spans"
+
+	// ProcessingErrorsMetricPointsKey is the key used to identify metric points a processor failed to fully process but passed on.
+	ProcessingErrorsMetricPointsKey = "processing_errors_metric_points"
+
+	// ProcessingErrorsLogRecordsKey is the key used to identify log records a processor failed to fully process but passed on.
+	ProcessingErrorsLogRecordsKey = "processing_errors_log_records"
+
+	// OutOfOrderSpansKey is the key used to identify spans a processor received out of order.
+	OutOfOrderSpansKey = "out_of_order_spans"
+
+	// OutOfOrderMetricPointsKey is the key used to identify metric points a processor received out of order.
+	OutOfOrderMetricPointsKey = "out_of_order_metric_points"
+
+	// OutOfOrderLogRecordsKey is the key used to identify log records a processor received out of order.
+	OutOfOrderLogRecordsKey = "out_of_order_log_records"
+
+	// TransformDroppedSpansKey is the key used to identify spans a processor dropped because it could not transform them.
+	TransformDroppedSpansKey = "transform_dropped_spans"
+
+	// TransformDroppedMetricPointsKey is the key used to identify metric points a processor dropped because it could not transform them.
+	TransformDroppedMetricPointsKey = "transform_dropped_metric_points"
+
+	// TransformDroppedLogRecordsKey is the key used to identify log records a processor dropped because it could not transform them.
+	TransformDroppedLogRecordsKey = "transform_dropped_log_records"
+
+	// TransformDropReasonKey is the key used to identify why a processor could not transform the data it dropped.
+	TransformDropReasonKey = "reason"
+
+	// FlushReasonKey is the key used to identify the reason a processor flushed its data.
+	FlushReasonKey = "reason"
+
+	// FlushByReasonKey is the key used to identify the flushes of a processor broken down by reason.
+	FlushByReasonKey = "flush_by_reason"
+
+	// TimeoutFlushRatioKey is the key used to identify the ratio of the flushes of a processor
+	// triggered by its timeout rather than by the size of its data, over a sliding window.
+	TimeoutFlushRatioKey = "timeout_flush_ratio"
+
+	// AllocatedBytesKey is the key used to identify the bytes allocated while a processor handled an operation.
+	AllocatedBytesKey = "allocated_bytes"
+
+	// QueueLatencyKey is the key used to identify the time data spent queued in a processor.
+	QueueLatencyKey = "queue_latency"
+
+	// ProcessingDurationKey is the key used to identify the time a synchronous processor spent
+	// processing data, by signal.
+	ProcessingDurationKey = "processing_duration"
+
+	// FanoutDegreeKey is the key used to identify the number of consumers a processor wrote
+	// each batch to, by signal.
+	FanoutDegreeKey = "fanout_degree"
+
+	// BatchSplitFactorKey is the key used to identify the number of batches a processor
+	// split each oversized batch into.
+	BatchSplitFactorKey = "batch_split_factor"
+
+	// SourceReceiverKey is the key used to identify the receiver the data handled by a processor came from.
+	SourceReceiverKey = "source_receiver"
+
+	// AcceptedSpansBySourceKey is the key used to identify spans accepted by a processor
+	// broken down by the receiver they came from.
+	AcceptedSpansBySourceKey = "accepted_spans_by_source"
+
+	// SamplingDecisionKey is the key used to identify the sampling decision taken by a processor.
+	SamplingDecisionKey = "decision"
+
+	// SampledSpansKey is the key used to identify the spans a processor took a sampling decision on.
+	SampledSpansKey = "sampled_spans"
+
+	// EffectiveSampleRatioKey is the key used to identify the ratio of the data a processor kept
+	// when sampling, by signal.
+	EffectiveSampleRatioKey = "effective_sample_ratio"
+
+	// ThresholdKey is the key used to identify the threshold breached by the data a processor checked.
+	ThresholdKey = "threshold"
+
+	// ThresholdBreachesKey is the key used to identify the items that breached a threshold
+	// checked by a processor, broken down by threshold.
+	ThresholdBreachesKey = "threshold_breaches"
+
+	// EnrichedItemsKey is the key used to identify the items a processor looked up in an external
+	// source to enrich them, e.g. a GeoIP database, broken down by signal and outcome.
+	EnrichedItemsKey = "enriched_items"
+
+	// RuleIDKey is the key used to identify the rule a processor dropped data by.
+	RuleIDKey = "rule_id"
+
+	// DroppedSpansByRuleKey is the key used to identify spans dropped by a processor
+	// broken down by the rule they were dropped by.
+	DroppedSpansByRuleKey = "dropped_spans_by_rule"
+
+	// DroppedSpansByPipelineKey is the key used to identify spans dropped by a processor
+	// broken down by the pipeline they were dropped in.
+	DroppedSpansByPipelineKey = "dropped_spans_by_pipeline"
+	// DroppedMetricPointsByPipelineKey is the key used to identify metric points dropped by
+	// a processor broken down by the pipeline they were dropped in.
+	DroppedMetricPointsByPipelineKey = "dropped_metric_points_by_pipeline"
+	// DroppedLogRecordsByPipelineKey is the key used to identify log records dropped by a
+	// processor broken down by the pipeline they were dropped in.
+	DroppedLogRecordsByPipelineKey = "dropped_log_records_by_pipeline"
+
+	// ResourceKey is the key used to identify the resource, e.g. by its service name, a
+	// processor dropped data for.
+	ResourceKey = "resource"
+
+	// DroppedSpansByResourceKey is the key used to identify spans dropped by a processor
+	// broken down by the resource they belong to.
+	DroppedSpansByResourceKey = "dropped_spans_by_resource"
 )
 
 var (
-	TagKeyProcessor, _ = tag.NewKey(ProcessorKey)
+	TagKeyProcessor, _       = tag.NewKey(ProcessorKey)
+	TagKeySourceReceiver, _  = tag.NewKey(SourceReceiverKey)
+	TagKeyFlushReason, _     = tag.NewKey(FlushReasonKey)
+	TagKeyDecision, _        = tag.NewKey(SamplingDecisionKey)
+	TagKeyRuleID, _          = tag.NewKey(RuleIDKey)
+	TagKeyThreshold, _       = tag.NewKey(ThresholdKey)
+	TagKeyTransformReason, _ = tag.NewKey(TransformDropReasonKey)
+	TagKeyResource, _        = tag.NewKey(ResourceKey)
 
 	ProcessorPrefix = ProcessorKey + NameSep
 
@@ -43,37 +197,189 @@ var (
 	ProcessorAcceptedSpans = stats.Int64(
 		ProcessorPrefix+AcceptedSpansKey,
 		"Number of spans successfully pushed into the next component in the pipeline.",
-		stats.UnitDimensionless)
+		UnitSpans)
 	ProcessorRefusedSpans = stats.Int64(
 		ProcessorPrefix+RefusedSpansKey,
 		"Number of spans that were rejected by the next component in the pipeline.",
-		stats.UnitDimensionless)
+		UnitSpans)
 	ProcessorDroppedSpans = stats.Int64(
 		ProcessorPrefix+DroppedSpansKey,
 		"Number of spans that were dropped.",
-		stats.UnitDimensionless)
+		UnitSpans)
 	ProcessorAcceptedMetricPoints = stats.Int64(
 		ProcessorPrefix+AcceptedMetricPointsKey,
 		"Number of metric points successfully pushed into the next component in the pipeline.",
-		stats.UnitDimensionless)
+		UnitMetricPoints)
 	ProcessorRefusedMetricPoints = stats.Int64(
 		ProcessorPrefix+RefusedMetricPointsKey,
 		"Number of metric points that were rejected by the next component in the pipeline.",
-		stats.UnitDimensionless)
+		UnitMetricPoints)
 	ProcessorDroppedMetricPoints = stats.Int64(
 		ProcessorPrefix+DroppedMetricPointsKey,
 		"Number of metric points that were dropped.",
-		stats.UnitDimensionless)
+		UnitMetricPoints)
 	ProcessorAcceptedLogRecords = stats.Int64(
 		ProcessorPrefix+AcceptedLogRecordsKey,
 		"Number of log records successfully pushed into the next component in the pipeline.",
-		stats.UnitDimensionless)
+		UnitLogRecords)
 	ProcessorRefusedLogRecords = stats.Int64(
 		ProcessorPrefix+RefusedLogRecordsKey,
 		"Number of log records that were rejected by the next component in the pipeline.",
-		stats.UnitDimensionless)
+		UnitLogRecords)
 	ProcessorDroppedLogRecords = stats.Int64(
 		ProcessorPrefix+DroppedLogRecordsKey,
 		"Number of log records that were dropped.",
-		stats.UnitDimensionless)
+		UnitLogRecords)
+	ProcessorDeduplicatedSpans = stats.Int64(
+		ProcessorPrefix+DeduplicatedSpansKey,
+		"Number of spans that were dropped as duplicates.",
+		UnitSpans)
+	ProcessorDeduplicatedMetricPoints = stats.Int64(
+		ProcessorPrefix+DeduplicatedMetricPointsKey,
+		"Number of metric points that were dropped as duplicates.",
+		UnitMetricPoints)
+	ProcessorDeduplicatedLogRecords = stats.Int64(
+		ProcessorPrefix+DeduplicatedLogRecordsKey,
+		"Number of log records that were dropped as duplicates.",
+		UnitLogRecords)
+	ProcessorPassthroughSpans = stats.Int64(
+		ProcessorPrefix+PassthroughSpansKey,
+		"Number of spans that were passed through unchanged to the next component in the pipeline.",
+		UnitSpans)
+	ProcessorPassthroughMetricPoints = stats.Int64(
+		ProcessorPrefix+PassthroughMetricPointsKey,
+		"Number of metric points that were passed through unchanged to the next component in the pipeline.",
+		UnitMetricPoints)
+	ProcessorPassthroughLogRecords = stats.Int64(
+		ProcessorPrefix+PassthroughLogRecordsKey,
+		"Number of log records that were passed through unchanged to the next component in the pipeline.",
+		UnitLogRecords)
+	ProcessorMemoryLimitedSpans = stats.Int64(
+		ProcessorPrefix+MemoryLimitedSpansKey,
+		"Number of spans that were refused because the memory usage was above the limit.",
+		UnitSpans)
+	ProcessorMemoryLimitedMetricPoints = stats.Int64(
+		ProcessorPrefix+MemoryLimitedMetricPointsKey,
+		"Number of metric points that were refused because the memory usage was above the limit.",
+		UnitMetricPoints)
+	ProcessorMemoryLimitedLogRecords = stats.Int64(
+		ProcessorPrefix+MemoryLimitedLogRecordsKey,
+		"Number of log records that were refused because the memory usage was above the limit.",
+		UnitLogRecords)
+	ProcessorDroppedSpanBytes = stats.Int64(
+		ProcessorPrefix+DroppedSpanBytesKey,
+		"Size in bytes of the spans that were dropped.",
+		stats.UnitBytes)
+	ProcessorDroppedMetricPointBytes = stats.Int64(
+		ProcessorPrefix+DroppedMetricPointBytesKey,
+		"Size in bytes of the metric points that were dropped.",
+		stats.UnitBytes)
+	ProcessorDroppedLogRecordBytes = stats.Int64(
+		ProcessorPrefix+DroppedLogRecordBytesKey,
+		"Size in bytes of the log records that were dropped.",
+		stats.UnitBytes)
+	ProcessorProcessingErrorsSpans = stats.Int64(
+		ProcessorPrefix+ProcessingErrorsSpansKey,
+		"Number of spans the processor failed to fully process but still passed on.",
+		UnitSpans)
+	ProcessorProcessingErrorsMetricPoints = stats.Int64(
+		ProcessorPrefix+ProcessingErrorsMetricPointsKey,
+		"Number of metric points the processor failed to fully process but still passed on.",
+		UnitMetricPoints)
+	ProcessorProcessingErrorsLogRecords = stats.Int64(
+		ProcessorPrefix+ProcessingErrorsLogRecordsKey,
+		"Number of log records the processor failed to fully process but still passed on.",
+		UnitLogRecords)
+	ProcessorOutOfOrderSpans = stats.Int64(
+		ProcessorPrefix+OutOfOrderSpansKey,
+		"Number of spans the processor received out of order.",
+		UnitSpans)
+	ProcessorOutOfOrderMetricPoints = stats.Int64(
+		ProcessorPrefix+OutOfOrderMetricPointsKey,
+		"Number of metric points the processor received out of order.",
+		UnitMetricPoints)
+	ProcessorOutOfOrderLogRecords = stats.Int64(
+		ProcessorPrefix+OutOfOrderLogRecordsKey,
+		"Number of log records the processor received out of order.",
+		UnitLogRecords)
+	ProcessorTransformDroppedSpans = stats.Int64(
+		ProcessorPrefix+TransformDroppedSpansKey,
+		"Number of spans dropped because the processor could not transform them, by reason.",
+		UnitSpans)
+	ProcessorTransformDroppedMetricPoints = stats.Int64(
+		ProcessorPrefix+TransformDroppedMetricPointsKey,
+		"Number of metric points dropped because the processor could not transform them, by reason.",
+		UnitMetricPoints)
+	ProcessorTransformDroppedLogRecords = stats.Int64(
+		ProcessorPrefix+TransformDroppedLogRecordsKey,
+		"Number of log records dropped because the processor could not transform them, by reason.",
+		UnitLogRecords)
+	ProcessorFlushByReason = stats.Int64(
+		ProcessorPrefix+FlushByReasonKey,
+		"Number of times the processor flushed its data by reason.",
+		UnitFlushes)
+	ProcessorTimeoutFlushRatio = stats.Float64(
+		ProcessorPrefix+TimeoutFlushRatioKey,
+		"Ratio of the flushes of the processor triggered by the timeout rather than by the size, over the last minute.",
+		UnitRatio)
+	ProcessorAllocatedBytes = stats.Int64(
+		ProcessorPrefix+AllocatedBytesKey,
+		"Number of bytes allocated while the processor handled an operation.",
+		stats.UnitBytes)
+	ProcessorQueueLatency = stats.Float64(
+		ProcessorPrefix+QueueLatencyKey,
+		"Time the data spent queued in the processor before being processed.",
+		stats.UnitMilliseconds)
+	ProcessorProcessingDuration = stats.Float64(
+		ProcessorPrefix+ProcessingDurationKey,
+		"Time the processor spent processing the data, by signal.",
+		stats.UnitMilliseconds)
+	ProcessorFanoutDegree = stats.Int64(
+		ProcessorPrefix+FanoutDegreeKey,
+		"Number of consumers the processor wrote each batch to, by signal.",
+		UnitConsumers)
+	ProcessorBatchSplitFactor = stats.Float64(
+		ProcessorPrefix+BatchSplitFactorKey,
+		"Number of batches resulting from splitting each oversized batch.",
+		UnitRatio)
+	ProcessorAcceptedSpansBySource = stats.Int64(
+		ProcessorPrefix+AcceptedSpansBySourceKey,
+		"Number of spans successfully pushed into the next component in the pipeline by source receiver.",
+		UnitSpans)
+	ProcessorSampledSpans = stats.Int64(
+		ProcessorPrefix+SampledSpansKey,
+		"Number of spans the processor took a sampling decision on, by decision.",
+		UnitSpans)
+	ProcessorEffectiveSampleRatio = stats.Float64(
+		ProcessorPrefix+EffectiveSampleRatioKey,
+		"Ratio of the data kept by the processor when sampling, by signal.",
+		UnitRatio)
+	ProcessorThresholdBreaches = stats.Int64(
+		ProcessorPrefix+ThresholdBreachesKey,
+		"Number of items that breached a threshold checked by the processor, by signal and threshold.",
+		UnitItems)
+	ProcessorEnrichedItems = stats.Int64(
+		ProcessorPrefix+EnrichedItemsKey,
+		"Number of items the processor looked up in an external source to enrich them, by signal and outcome.",
+		UnitItems)
+	ProcessorDroppedSpansByRule = stats.Int64(
+		ProcessorPrefix+DroppedSpansByRuleKey,
+		"Number of spans that were dropped by rule.",
+		UnitSpans)
+	ProcessorDroppedSpansByPipeline = stats.Int64(
+		ProcessorPrefix+DroppedSpansByPipelineKey,
+		"Number of spans that were dropped by pipeline.",
+		UnitSpans)
+	ProcessorDroppedMetricPointsByPipeline = stats.Int64(
+		ProcessorPrefix+DroppedMetricPointsByPipelineKey,
+		"Number of metric points that were dropped by pipeline.",
+		UnitMetricPoints)
+	ProcessorDroppedLogRecordsByPipeline = stats.Int64(
+		ProcessorPrefix+DroppedLogRecordsByPipelineKey,
+		"Number of log records that were dropped by pipeline.",
+		UnitLogRecords)
+	ProcessorDroppedSpansByResource = stats.Int64(
+		ProcessorPrefix+DroppedSpansByResourceKey,
+		"Number of spans that were dropped by resource.",
+		UnitSpans)
 )
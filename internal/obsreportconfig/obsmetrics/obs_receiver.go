@@ -26,6 +26,12 @@ const (
 	TransportKey = "transport"
 	// FormatKey used to identify the format of the data received.
 	FormatKey = "format"
+	// FromFormatKey used to identify the format of the data received before it was converted.
+	FromFormatKey = "from_format"
+	// ToFormatKey used to identify the format the data received was converted to.
+	ToFormatKey = "to_format"
+	// TenantKey used to identify the tenant the data received belongs to.
+	TenantKey = "tenant"
 
 	// AcceptedSpansKey used to identify spans accepted by the Collector.
 	AcceptedSpansKey = "accepted_spans"
@@ -43,11 +49,146 @@ const (
 	// RefusedLogRecordsKey used to identify log records refused (ie.: not ingested) by the
 	// Collector.
 	RefusedLogRecordsKey = "refused_log_records"
+
+	// AcceptedSpanEventsKey used to identify span events accepted by the Collector.
+	AcceptedSpanEventsKey = "accepted_span_events"
+	// RefusedSpanEventsKey used to identify span events refused (ie.: not ingested) by the
+	// Collector.
+	RefusedSpanEventsKey = "refused_span_events"
+
+	// AcceptedSpanLinksKey used to identify span links accepted by the Collector.
+	AcceptedSpanLinksKey = "accepted_span_links"
+	// RefusedSpanLinksKey used to identify span links refused (ie.: not ingested) by the
+	// Collector.
+	RefusedSpanLinksKey = "refused_span_links"
+
+	// AcceptedResourcesKey used to identify resource groupings accepted by the Collector.
+	AcceptedResourcesKey = "accepted_resources"
+	// AcceptedScopesKey used to identify instrumentation scope groupings accepted by the Collector.
+	AcceptedScopesKey = "accepted_scopes"
+
+	// AcceptedLogRecordBytesKey used to identify the size of the log records accepted by the Collector.
+	AcceptedLogRecordBytesKey = "accepted_log_record_bytes"
+
+	// SchemaMismatchesKey used to identify the data received with an unexpected or missing schema URL.
+	SchemaMismatchesKey = "schema_mismatches"
+
+	// AuthFailuresKey used to identify the requests rejected by the receiver because they
+	// failed authentication.
+	AuthFailuresKey = "auth_failures"
+
+	// ReadErrorsKey used to identify the requests whose body the receiver failed to read,
+	// e.g. because the client disconnected during the upload.
+	ReadErrorsKey = "read_errors"
+
+	// ValidationFieldKey used to identify the field of the data that failed the validation of the receiver.
+	ValidationFieldKey = "field"
+	// ValidationErrorsKey used to identify the data rejected by the receiver because it failed
+	// validation, broken down by field.
+	ValidationErrorsKey = "validation_errors"
+
+	// KeepalivesKey used to identify the keepalive pings exchanged by the receiver with the
+	// clients over long-lived streams.
+	KeepalivesKey = "keepalives"
+	// StreamClosesKey used to identify the client streams closed by the receiver, broken down by reason.
+	StreamClosesKey = "stream_closes"
+	// StreamCloseReasonKey used to identify the reason the receiver closed a client stream.
+	StreamCloseReasonKey = "reason"
+
+	// ConnectionsKey used to identify the connections accepted by the receiver, broken down
+	// by whether they are new or reused.
+	ConnectionsKey = "connections"
+	// ConnectionKey used to identify whether a connection is new or reused.
+	ConnectionKey = "connection"
+	// ActiveConnectionsKey used to identify the number of connections currently open on the receiver.
+	ActiveConnectionsKey = "active_connections"
+
+	// DistinctResourcesEstimateKey used to identify the estimated number of distinct resources
+	// received in the current window.
+	DistinctResourcesEstimateKey = "distinct_resources_estimate"
+	// DistinctTracesEstimateKey used to identify the estimated number of distinct trace IDs
+	// received in the current window.
+	DistinctTracesEstimateKey = "distinct_traces_estimate"
+
+	// ItemsPerCoreKey used to identify the items accepted by the receiver divided by the
+	// number of cores available to the collector.
+	ItemsPerCoreKey = "items_per_core"
+
+	// EmptyBatchesKey used to identify the receive operations that successfully accepted no items.
+	EmptyBatchesKey = "empty_batches"
+
+	// FirstByteLatencyKey used to identify the time from the start of a receive operation
+	// until the first data was received.
+	FirstByteLatencyKey = "first_byte_latency"
+
+	// DeadlineRemainingKey used to identify the time remaining until the deadline of the
+	// context of a receive operation when it started.
+	DeadlineRemainingKey = "deadline_remaining"
+
+	// ParseDurationKey used to identify the time spent by receivers decoding the data received.
+	ParseDurationKey = "parse_duration"
+
+	// AttributesPerSpanKey used to identify the average number of attributes of the spans
+	// accepted in a receive operation.
+	AttributesPerSpanKey = "attributes_per_span"
+
+	// ClockSkewKey used to identify the clock skew range of the data received.
+	ClockSkewKey = "clock_skew"
+	// AcceptedSpansByClockSkewKey used to identify spans accepted by the Collector
+	// broken down by clock skew range.
+	AcceptedSpansByClockSkewKey = "accepted_spans_by_clock_skew"
+
+	// SampledKey used to identify whether the spans received were sampled, from their trace flags.
+	SampledKey = "sampled"
+	// AcceptedSpansBySampledKey used to identify spans accepted by the Collector
+	// broken down by whether they were sampled.
+	AcceptedSpansBySampledKey = "accepted_spans_by_sampled"
+
+	// ConvertedSpansKey used to identify spans accepted by the Collector that were converted
+	// from the format they were received in.
+	ConvertedSpansKey = "converted_spans"
+
+	// AcceptedSpansByTenantKey used to identify spans accepted by the Collector broken down by tenant.
+	AcceptedSpansByTenantKey = "accepted_spans_by_tenant"
+	// RefusedSpansByTenantKey used to identify spans refused by the Collector broken down by tenant.
+	RefusedSpansByTenantKey = "refused_spans_by_tenant"
+	// RefusedSpansByStatusCodeKey used to identify spans refused by the Collector broken down
+	// by the HTTP status code returned to the client.
+	RefusedSpansByStatusCodeKey = "refused_spans_by_status_code"
+	// ProtoVersionKey used to identify the version of the protocol schema the data was received in.
+	ProtoVersionKey = "proto_version"
+	// AcceptedSpansByProtoVersionKey used to identify spans accepted by the Collector broken
+	// down by the version of the protocol schema they were received in.
+	AcceptedSpansByProtoVersionKey = "accepted_spans_by_proto_version"
+
+	// VolumeKey used to identify the value of the resource attribute configured to break down
+	// the volume of data accepted by the Collector.
+	VolumeKey = "volume"
+	// AcceptedSpansByVolumeKey used to identify spans accepted by the Collector broken down
+	// by the value of the configured resource attribute.
+	AcceptedSpansByVolumeKey = "accepted_spans_by_volume"
+	// AcceptedMetricPointsByVolumeKey used to identify metric points accepted by the Collector
+	// broken down by the value of the configured resource attribute.
+	AcceptedMetricPointsByVolumeKey = "accepted_metric_points_by_volume"
+	// AcceptedLogRecordsByVolumeKey used to identify log records accepted by the Collector
+	// broken down by the value of the configured resource attribute.
+	AcceptedLogRecordsByVolumeKey = "accepted_log_records_by_volume"
 )
 
 var (
-	TagKeyReceiver, _  = tag.NewKey(ReceiverKey)
-	TagKeyTransport, _ = tag.NewKey(TransportKey)
+	TagKeyReceiver, _        = tag.NewKey(ReceiverKey)
+	TagKeyTransport, _       = tag.NewKey(TransportKey)
+	TagKeyClockSkew, _       = tag.NewKey(ClockSkewKey)
+	TagKeyFormat, _          = tag.NewKey(FormatKey)
+	TagKeyFromFormat, _      = tag.NewKey(FromFormatKey)
+	TagKeyToFormat, _        = tag.NewKey(ToFormatKey)
+	TagKeyTenant, _          = tag.NewKey(TenantKey)
+	TagKeyConnection, _      = tag.NewKey(ConnectionKey)
+	TagKeySampled, _         = tag.NewKey(SampledKey)
+	TagKeyProtoVersion, _    = tag.NewKey(ProtoVersionKey)
+	TagKeyValidationField, _ = tag.NewKey(ValidationFieldKey)
+	TagKeyStreamClose, _     = tag.NewKey(StreamCloseReasonKey)
+	TagKeyVolume, _          = tag.NewKey(VolumeKey)
 
 	ReceiverPrefix                  = ReceiverKey + NameSep
 	ReceiveTraceDataOperationSuffix = NameSep + "TraceDataReceived"
@@ -62,25 +203,157 @@ var (
 	ReceiverAcceptedSpans = stats.Int64(
 		ReceiverPrefix+AcceptedSpansKey,
 		"Number of spans successfully pushed into the pipeline.",
-		stats.UnitDimensionless)
+		UnitSpans)
 	ReceiverRefusedSpans = stats.Int64(
 		ReceiverPrefix+RefusedSpansKey,
 		"Number of spans that could not be pushed into the pipeline.",
-		stats.UnitDimensionless)
+		UnitSpans)
 	ReceiverAcceptedMetricPoints = stats.Int64(
 		ReceiverPrefix+AcceptedMetricPointsKey,
 		"Number of metric points successfully pushed into the pipeline.",
-		stats.UnitDimensionless)
+		UnitMetricPoints)
 	ReceiverRefusedMetricPoints = stats.Int64(
 		ReceiverPrefix+RefusedMetricPointsKey,
 		"Number of metric points that could not be pushed into the pipeline.",
-		stats.UnitDimensionless)
+		UnitMetricPoints)
 	ReceiverAcceptedLogRecords = stats.Int64(
 		ReceiverPrefix+AcceptedLogRecordsKey,
 		"Number of log records successfully pushed into the pipeline.",
-		stats.UnitDimensionless)
+		UnitLogRecords)
 	ReceiverRefusedLogRecords = stats.Int64(
 		ReceiverPrefix+RefusedLogRecordsKey,
 		"Number of log records that could not be pushed into the pipeline.",
-		stats.UnitDimensionless)
+		UnitLogRecords)
+	ReceiverAcceptedSpanEvents = stats.Int64(
+		ReceiverPrefix+AcceptedSpanEventsKey,
+		"Number of span events successfully pushed into the pipeline.",
+		UnitSpanEvents)
+	ReceiverRefusedSpanEvents = stats.Int64(
+		ReceiverPrefix+RefusedSpanEventsKey,
+		"Number of span events that could not be pushed into the pipeline.",
+		UnitSpanEvents)
+	ReceiverAcceptedSpanLinks = stats.Int64(
+		ReceiverPrefix+AcceptedSpanLinksKey,
+		"Number of span links successfully pushed into the pipeline.",
+		UnitSpanLinks)
+	ReceiverRefusedSpanLinks = stats.Int64(
+		ReceiverPrefix+RefusedSpanLinksKey,
+		"Number of span links that could not be pushed into the pipeline.",
+		UnitSpanLinks)
+	ReceiverAcceptedResources = stats.Int64(
+		ReceiverPrefix+AcceptedResourcesKey,
+		"Number of resource groupings successfully pushed into the pipeline.",
+		UnitResources)
+	ReceiverAcceptedScopes = stats.Int64(
+		ReceiverPrefix+AcceptedScopesKey,
+		"Number of instrumentation scope groupings successfully pushed into the pipeline.",
+		UnitScopes)
+	ReceiverAcceptedLogRecordBytes = stats.Int64(
+		ReceiverPrefix+AcceptedLogRecordBytesKey,
+		"Size in bytes of the log records successfully pushed into the pipeline.",
+		stats.UnitBytes)
+	ReceiverSchemaMismatches = stats.Int64(
+		ReceiverPrefix+SchemaMismatchesKey,
+		"Number of times data was received with an unexpected or missing schema URL.",
+		UnitSchemaMismatches)
+	ReceiverAuthFailures = stats.Int64(
+		ReceiverPrefix+AuthFailuresKey,
+		"Number of requests rejected because they failed authentication.",
+		UnitFailures)
+	ReceiverReadErrors = stats.Int64(
+		ReceiverPrefix+ReadErrorsKey,
+		"Number of requests whose body could not be read.",
+		UnitFailures)
+	ReceiverValidationErrors = stats.Int64(
+		ReceiverPrefix+ValidationErrorsKey,
+		"Number of times data was rejected because a field failed validation, by field.",
+		UnitFailures)
+	ReceiverStreamCloses = stats.Int64(
+		ReceiverPrefix+StreamClosesKey,
+		"Number of client streams closed by the receiver, by reason.",
+		UnitStreams)
+	ReceiverKeepalives = stats.Int64(
+		ReceiverPrefix+KeepalivesKey,
+		"Number of keepalive pings exchanged with the clients over long-lived streams.",
+		UnitKeepalives)
+	ReceiverConnections = stats.Int64(
+		ReceiverPrefix+ConnectionsKey,
+		"Number of connections accepted by the receiver, by whether they are new or reused.",
+		UnitConnections)
+	ReceiverActiveConnections = stats.Int64(
+		ReceiverPrefix+ActiveConnectionsKey,
+		"Number of connections currently open on the receiver.",
+		UnitConnections)
+	ReceiverDistinctResourcesEstimate = stats.Int64(
+		ReceiverPrefix+DistinctResourcesEstimateKey,
+		"Estimated number of distinct resource attribute sets received in the last minute.",
+		UnitResources)
+	ReceiverDistinctTracesEstimate = stats.Int64(
+		ReceiverPrefix+DistinctTracesEstimateKey,
+		"Estimated number of distinct trace IDs received in the last minute.",
+		UnitTraces)
+	ReceiverEmptyBatches = stats.Int64(
+		ReceiverPrefix+EmptyBatchesKey,
+		"Number of receive operations that successfully pushed no items into the pipeline.",
+		UnitBatches)
+	ReceiverFirstByteLatency = stats.Float64(
+		ReceiverPrefix+FirstByteLatencyKey,
+		"Time from the start of the receive operation until the first data was received.",
+		stats.UnitMilliseconds)
+	ReceiverDeadlineRemaining = stats.Float64(
+		ReceiverPrefix+DeadlineRemainingKey,
+		"Time remaining until the deadline of the request when the receive operation started.",
+		stats.UnitMilliseconds)
+	ReceiverParseDuration = stats.Float64(
+		ReceiverPrefix+ParseDurationKey,
+		"Time spent decoding the data received, by format.",
+		stats.UnitMilliseconds)
+	ReceiverAttributesPerSpan = stats.Float64(
+		ReceiverPrefix+AttributesPerSpanKey,
+		"Average number of attributes of the spans successfully pushed into the pipeline, per receive operation.",
+		UnitAttributes)
+	ReceiverItemsPerCore = stats.Float64(
+		ReceiverPrefix+ItemsPerCoreKey,
+		"Number of items successfully pushed into the pipeline divided by the number of cores available to the collector, by signal.",
+		UnitItems)
+	ReceiverAcceptedSpansByClockSkew = stats.Int64(
+		ReceiverPrefix+AcceptedSpansByClockSkewKey,
+		"Number of spans successfully pushed into the pipeline by clock skew range of their timestamps.",
+		UnitSpans)
+	ReceiverAcceptedSpansBySampled = stats.Int64(
+		ReceiverPrefix+AcceptedSpansBySampledKey,
+		"Number of spans successfully pushed into the pipeline by whether they were sampled.",
+		UnitSpans)
+	ReceiverConvertedSpans = stats.Int64(
+		ReceiverPrefix+ConvertedSpansKey,
+		"Number of spans successfully pushed into the pipeline after being converted from the format they were received in.",
+		UnitSpans)
+	ReceiverAcceptedSpansByTenant = stats.Int64(
+		ReceiverPrefix+AcceptedSpansByTenantKey,
+		"Number of spans successfully pushed into the pipeline by tenant.",
+		UnitSpans)
+	ReceiverRefusedSpansByTenant = stats.Int64(
+		ReceiverPrefix+RefusedSpansByTenantKey,
+		"Number of spans that could not be pushed into the pipeline by tenant.",
+		UnitSpans)
+	ReceiverAcceptedSpansByVolume = stats.Int64(
+		ReceiverPrefix+AcceptedSpansByVolumeKey,
+		"Number of spans successfully pushed into the pipeline by the value of the configured resource attribute.",
+		UnitSpans)
+	ReceiverAcceptedMetricPointsByVolume = stats.Int64(
+		ReceiverPrefix+AcceptedMetricPointsByVolumeKey,
+		"Number of metric points successfully pushed into the pipeline by the value of the configured resource attribute.",
+		UnitMetricPoints)
+	ReceiverAcceptedLogRecordsByVolume = stats.Int64(
+		ReceiverPrefix+AcceptedLogRecordsByVolumeKey,
+		"Number of log records successfully pushed into the pipeline by the value of the configured resource attribute.",
+		UnitLogRecords)
+	ReceiverRefusedSpansByStatusCode = stats.Int64(
+		ReceiverPrefix+RefusedSpansByStatusCodeKey,
+		"Number of spans that could not be pushed into the pipeline by the HTTP status code returned to the client.",
+		UnitSpans)
+	ReceiverAcceptedSpansByProtoVersion = stats.Int64(
+		ReceiverPrefix+AcceptedSpansByProtoVersionKey,
+		"Number of spans successfully pushed into the pipeline by the version of the protocol schema they were received in.",
+		UnitSpans)
 )
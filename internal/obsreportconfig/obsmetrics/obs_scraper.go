@@ -29,6 +29,18 @@ const (
 	// ErroredMetricPointsKey used to identify metric points errored (i.e.
 	// unable to be scraped) by the Collector.
 	ErroredMetricPointsKey = "errored_metric_points"
+
+	// CacheHitsKey used to identify the lookups of scrapers that were served from their cache.
+	CacheHitsKey = "cache_hits"
+	// CacheMissesKey used to identify the lookups of scrapers that were not found in their cache.
+	CacheMissesKey = "cache_misses"
+
+	// MetricNameKey is the key used to identify the name of the metric a scrape is for.
+	MetricNameKey = "metric_name"
+	// MetricScrapesKey used to identify the successful scrapes of the individual metrics.
+	MetricScrapesKey = "metric_scrapes"
+	// MetricScrapeErrorsKey used to identify the failed scrapes of the individual metrics.
+	MetricScrapeErrorsKey = "metric_scrape_errors"
 )
 
 const (
@@ -37,14 +49,31 @@ const (
 )
 
 var (
-	TagKeyScraper, _ = tag.NewKey(ScraperKey)
+	TagKeyScraper, _    = tag.NewKey(ScraperKey)
+	TagKeyMetricName, _ = tag.NewKey(MetricNameKey)
 
 	ScraperScrapedMetricPoints = stats.Int64(
 		ScraperPrefix+ScrapedMetricPointsKey,
 		"Number of metric points successfully scraped.",
-		stats.UnitDimensionless)
+		UnitMetricPoints)
 	ScraperErroredMetricPoints = stats.Int64(
 		ScraperPrefix+ErroredMetricPointsKey,
 		"Number of metric points that were unable to be scraped.",
-		stats.UnitDimensionless)
+		UnitMetricPoints)
+	ScraperCacheHits = stats.Int64(
+		ScraperPrefix+CacheHitsKey,
+		"Number of lookups served from the cache of the scraper.",
+		UnitLookups)
+	ScraperCacheMisses = stats.Int64(
+		ScraperPrefix+CacheMissesKey,
+		"Number of lookups not found in the cache of the scraper.",
+		UnitLookups)
+	ScraperMetricScrapes = stats.Int64(
+		ScraperPrefix+MetricScrapesKey,
+		"Number of successful scrapes of the metrics, by metric name.",
+		UnitScrapes)
+	ScraperMetricScrapeErrors = stats.Int64(
+		ScraperPrefix+MetricScrapeErrorsKey,
+		"Number of failed scrapes of the metrics, by metric name.",
+		UnitScrapes)
 )
@@ -17,6 +17,158 @@
 // in the future
 package obsmetrics // import "go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+)
+
 const (
 	NameSep = "/"
+
+	// CollectorInstanceIDKey used to identify the collector instance that emitted the metrics.
+	CollectorInstanceIDKey = "collector.instance.id"
+
+	// ObsreportKey used to identify the metrics about obsreport itself.
+	ObsreportKey = "obsreport"
+	// ComponentKindKey used to identify the kind of component in the metrics about obsreport itself.
+	ComponentKindKey = "kind"
+	// OverheadKey used to identify the time spent by obsreport instrumenting the
+	// operations of the components.
+	OverheadKey = "overhead_ns"
+	// PendingInternalTelemetryKey used to identify the data points of the internal metrics
+	// waiting to be exported by the exporters of the collector's own telemetry.
+	PendingInternalTelemetryKey = "pending_internal_telemetry"
+	// DroppedInternalTelemetryKey used to identify the data points of the internal metrics
+	// dropped before being exported by the exporters of the collector's own telemetry.
+	DroppedInternalTelemetryKey = "dropped_internal_telemetry"
+
+	// SaturationKey used to track how saturated a component is, from 0 when idle to 1
+	// when it reached its limits.
+	SaturationKey = "saturation"
+	// ConcurrencyKey used to track the number of workers a component is configured to run concurrently.
+	ConcurrencyKey = "concurrency"
+
+	// CollectorKey used to identify the metrics about the collector as a whole.
+	CollectorKey = "collector"
+	// InfoKey used to identify the metric holding the build information of the collector.
+	InfoKey = "info"
+	// VersionKey used to identify the version of the collector.
+	VersionKey = "version"
+	// CommitKey used to identify the commit the collector was built from.
+	CommitKey = "commit"
+
+	// PipelineKey used to identify the metrics about the pipelines as a whole, and the
+	// pipeline the data was dropped in by the processors shared by multiple pipelines.
+	PipelineKey = "pipeline"
+	// LatencyKey used to identify the time from the start of the receive operation of the
+	// data to the end of its export operation.
+	LatencyKey = "latency"
+)
+
+const (
+	ObsreportPrefix = ObsreportKey + NameSep
+	CollectorPrefix = CollectorKey + NameSep
+	PipelinePrefix  = PipelineKey + NameSep
+)
+
+// Prefixes lists the prefixes of the names of all the obsreport metrics.
+var Prefixes = []string{
+	ReceiverPrefix,
+	ScraperPrefix,
+	ProcessorPrefix,
+	ExporterPrefix,
+	ConnectorPrefix,
+	ObsreportPrefix,
+	CollectorPrefix,
+	PipelinePrefix,
+}
+
+var (
+	TagKeyCollectorInstanceID, _ = tag.NewKey(CollectorInstanceIDKey)
+	TagKeyComponentKind, _       = tag.NewKey(ComponentKindKey)
+	TagKeyVersion, _             = tag.NewKey(VersionKey)
+	TagKeyCommit, _              = tag.NewKey(CommitKey)
+	TagKeyPipeline, _            = tag.NewKey(PipelineKey)
+
+	ObsreportOverhead = stats.Int64(
+		ObsreportPrefix+OverheadKey,
+		"Time spent by obsreport itself in the functions instrumenting the operations of the components.",
+		"ns")
+	CollectorInfo = stats.Int64(
+		CollectorPrefix+InfoKey,
+		"Build information of the collector, always 1.",
+		UnitCollectors)
+	PipelineLatency = stats.Float64(
+		PipelinePrefix+LatencyKey,
+		"Time from the start of the receive operation of the data to the end of its export operation.",
+		stats.UnitMilliseconds)
+
+	// The saturation measures are recorded by the components of each kind.
+	ReceiverSaturation = stats.Float64(
+		ReceiverPrefix+SaturationKey,
+		"Saturation of the receiver, from 0 when idle to 1 when it reached its limits.",
+		UnitRatio)
+	ProcessorSaturation = stats.Float64(
+		ProcessorPrefix+SaturationKey,
+		"Saturation of the processor, from 0 when idle to 1 when it reached its limits.",
+		UnitRatio)
+	ExporterSaturation = stats.Float64(
+		ExporterPrefix+SaturationKey,
+		"Saturation of the exporter, from 0 when idle to 1 when it reached its limits.",
+		UnitRatio)
+	ConnectorSaturation = stats.Float64(
+		ConnectorPrefix+SaturationKey,
+		"Saturation of the connector, from 0 when idle to 1 when it reached its limits.",
+		UnitRatio)
+
+	// The concurrency measures are recorded by the components of each kind.
+	ReceiverConcurrency = stats.Int64(
+		ReceiverPrefix+ConcurrencyKey,
+		"Number of workers the receiver is configured to run concurrently.",
+		UnitWorkers)
+	ProcessorConcurrency = stats.Int64(
+		ProcessorPrefix+ConcurrencyKey,
+		"Number of workers the processor is configured to run concurrently.",
+		UnitWorkers)
+	ExporterConcurrency = stats.Int64(
+		ExporterPrefix+ConcurrencyKey,
+		"Number of workers the exporter is configured to run concurrently.",
+		UnitWorkers)
+	ConnectorConcurrency = stats.Int64(
+		ConnectorPrefix+ConcurrencyKey,
+		"Number of workers the connector is configured to run concurrently.",
+		UnitWorkers)
+)
+
+// Units of the obsreport metrics counting data items and events, following the
+// UCUM annotation syntax so that backends can display them. The metrics measuring
+// sizes and durations use "By" and "ms" respectively.
+const (
+	UnitSpans            = "{spans}"
+	UnitMetricPoints     = "{datapoints}"
+	UnitLogRecords       = "{records}"
+	UnitSpanEvents       = "{events}"
+	UnitSpanLinks        = "{links}"
+	UnitResources        = "{resources}"
+	UnitScopes           = "{scopes}"
+	UnitFlushes          = "{flushes}"
+	UnitSchemaMismatches = "{mismatches}"
+	UnitConnections      = "{connections}"
+	UnitTransitions      = "{transitions}"
+	UnitAttributes       = "{attributes}"
+	UnitBatches          = "{batches}"
+	UnitExporters        = "{exporters}"
+	UnitLookups          = "{lookups}"
+	UnitFailures         = "{failures}"
+	UnitItems            = "{items}"
+	UnitCollectors       = "{collectors}"
+	UnitRatio            = "{ratio}"
+	UnitSends            = "{sends}"
+	UnitScrapes          = "{scrapes}"
+	UnitTraces           = "{traces}"
+	UnitRequests         = "{requests}"
+	UnitKeepalives       = "{keepalives}"
+	UnitConsumers        = "{consumers}"
+	UnitStreams          = "{streams}"
+	UnitWorkers          = "{workers}"
 )
@@ -39,6 +39,24 @@ var DisableHighCardinalityMetricsfeatureGate = featuregate.GlobalRegistry().Must
 	featuregate.WithRegisterDescription("controls whether the collector should enable potentially high"+
 		"cardinality metrics. The gate will be removed when the collector allows for view configuration."))
 
+// LatencyBuckets are the histogram bucket boundaries, in milliseconds, used by the obsreport latency metrics.
+var LatencyBuckets = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+// AllocatedBytesBuckets are the histogram bucket boundaries, in bytes, used by the obsreport allocation metrics.
+var AllocatedBytesBuckets = []float64{0, 1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20, 64 << 20}
+
+// AttributeCountBuckets are the histogram bucket boundaries used by the obsreport attribute count metrics.
+var AttributeCountBuckets = []float64{0, 1, 2, 4, 8, 16, 32, 64, 128, 256}
+
+// BatchSizeBuckets are the histogram bucket boundaries used by the obsreport batch size metrics.
+var BatchSizeBuckets = []float64{0, 1, 10, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 50000, 100000}
+
+// FanoutDegreeBuckets are the histogram bucket boundaries used by the obsreport fan-out metrics.
+var FanoutDegreeBuckets = []float64{1, 2, 3, 4, 5, 8, 16, 32}
+
+// SplitFactorBuckets are the histogram bucket boundaries used by the obsreport batch split metrics.
+var SplitFactorBuckets = []float64{1, 1.5, 2, 3, 4, 8, 16, 32, 64}
+
 // AllViews returns all the OpenCensus views requires by obsreport package.
 func AllViews(level configtelemetry.Level) []*view.View {
 	if level == configtelemetry.LevelNone {
@@ -63,6 +81,12 @@ func AllViews(level configtelemetry.Level) []*view.View {
 		obsmetrics.ExporterFailedToSendMetricPoints,
 		obsmetrics.ExporterSentLogRecords,
 		obsmetrics.ExporterFailedToSendLogRecords,
+		obsmetrics.ExporterRetriesExhaustedSpans,
+		obsmetrics.ExporterRetriesExhaustedMetricPoints,
+		obsmetrics.ExporterRetriesExhaustedLogRecords,
+		obsmetrics.ExporterPartialWarningsTraces,
+		obsmetrics.ExporterPartialWarningsMetrics,
+		obsmetrics.ExporterPartialWarningsLogs,
 	}
 	tagKeys = []tag.Key{obsmetrics.TagKeyExporter}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
@@ -75,6 +99,83 @@ func AllViews(level configtelemetry.Level) []*view.View {
 	}
 	views = append(views, errorNumberView)
 
+	tagKeys = []tag.Key{obsmetrics.TagKeyExporter, obsmetrics.TagKeyGRPCStatusCode, obsmetrics.TagKeyHTTPStatusCode}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ExporterFailedToSendSpansByCode}, tagKeys, view.Sum())...)
+
+	tagKeys = []tag.Key{obsmetrics.TagKeyExporter, obsmetrics.TagKeyDestination}
+	measures = []*stats.Int64Measure{
+		obsmetrics.ExporterSentSpansByDestination,
+		obsmetrics.ExporterFailedToSendSpansByDestination,
+	}
+	views = append(views, genViews(measures, tagKeys, view.Sum())...)
+
+	tagKeys = []tag.Key{obsmetrics.TagKeyExporter, obsmetrics.TagKeyCompression}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ExporterSends}, tagKeys, view.Sum())...)
+
+	tagKeys = []tag.Key{obsmetrics.TagKeyExporter, obsmetrics.TagKeyOutcome}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ExporterRequests}, tagKeys, view.Sum())...)
+
+	tagKeys = []tag.Key{obsmetrics.TagKeyExporter, obsmetrics.TagKeyTLSErrorKind}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ExporterTLSErrors}, tagKeys, view.Sum())...)
+
+	tagKeys = []tag.Key{obsmetrics.TagKeyExporter, obsmetrics.TagKeyState}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ExporterConnectionState}, tagKeys, view.LastValue())...)
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ExporterConnectionStateTransitions}, tagKeys, view.Sum())...)
+
+	tagKeys = []tag.Key{obsmetrics.TagKeyExporter}
+	measures = []*stats.Int64Measure{
+		obsmetrics.ExporterBackpressure,
+		obsmetrics.ExporterPersistentQueueItems,
+		obsmetrics.ExporterPersistentQueueBytes,
+	}
+	views = append(views, genViews(measures, tagKeys, view.LastValue())...)
+	views = append(views, &view.View{
+		Name:        obsmetrics.ExporterBackpressureDuration.Name(),
+		Description: obsmetrics.ExporterBackpressureDuration.Description(),
+		TagKeys:     tagKeys,
+		Measure:     obsmetrics.ExporterBackpressureDuration,
+		Aggregation: view.Sum(),
+	}, &view.View{
+		Name:        obsmetrics.ExporterSentBatchSize.Name(),
+		Description: obsmetrics.ExporterSentBatchSize.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyExporter, obsmetrics.TagKeySignal},
+		Measure:     obsmetrics.ExporterSentBatchSize,
+		Aggregation: view.Distribution(BatchSizeBuckets...),
+	}, &view.View{
+		Name:        obsmetrics.ExporterSendDuration.Name(),
+		Description: obsmetrics.ExporterSendDuration.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyExporter, obsmetrics.TagKeyOutcome},
+		Measure:     obsmetrics.ExporterSendDuration,
+		Aggregation: view.Distribution(LatencyBuckets...),
+	}, &view.View{
+		Name:        obsmetrics.PipelineLatency.Name(),
+		Description: obsmetrics.PipelineLatency.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyExporter},
+		Measure:     obsmetrics.PipelineLatency,
+		Aggregation: view.Distribution(LatencyBuckets...),
+	}, &view.View{
+		Name:        obsmetrics.ExporterAckLatency.Name(),
+		Description: obsmetrics.ExporterAckLatency.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyExporter},
+		Measure:     obsmetrics.ExporterAckLatency,
+		Aggregation: view.Distribution(LatencyBuckets...),
+	}, &view.View{
+		Name:        obsmetrics.ExporterQueueWait.Name(),
+		Description: obsmetrics.ExporterQueueWait.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyExporter},
+		Measure:     obsmetrics.ExporterQueueWait,
+		Aggregation: view.Distribution(LatencyBuckets...),
+	}, &view.View{
+		Name:        obsmetrics.ExporterOldestQueuedAge.Name(),
+		Description: obsmetrics.ExporterOldestQueuedAge.Description(),
+		TagKeys:     tagKeys,
+		Measure:     obsmetrics.ExporterOldestQueuedAge,
+		Aggregation: view.LastValue(),
+	})
+
+	// Connector views.
+	views = append(views, connectorViews()...)
+
 	// Processor views.
 	measures = []*stats.Int64Measure{
 		obsmetrics.ProcessorAcceptedSpans,
@@ -86,13 +187,169 @@ func AllViews(level configtelemetry.Level) []*view.View {
 		obsmetrics.ProcessorAcceptedLogRecords,
 		obsmetrics.ProcessorRefusedLogRecords,
 		obsmetrics.ProcessorDroppedLogRecords,
+		obsmetrics.ProcessorDeduplicatedSpans,
+		obsmetrics.ProcessorDeduplicatedMetricPoints,
+		obsmetrics.ProcessorDeduplicatedLogRecords,
+		obsmetrics.ProcessorPassthroughSpans,
+		obsmetrics.ProcessorPassthroughMetricPoints,
+		obsmetrics.ProcessorPassthroughLogRecords,
+		obsmetrics.ProcessorMemoryLimitedSpans,
+		obsmetrics.ProcessorMemoryLimitedMetricPoints,
+		obsmetrics.ProcessorMemoryLimitedLogRecords,
+		obsmetrics.ProcessorDroppedSpanBytes,
+		obsmetrics.ProcessorDroppedMetricPointBytes,
+		obsmetrics.ProcessorDroppedLogRecordBytes,
+		obsmetrics.ProcessorProcessingErrorsSpans,
+		obsmetrics.ProcessorProcessingErrorsMetricPoints,
+		obsmetrics.ProcessorProcessingErrorsLogRecords,
+		obsmetrics.ProcessorOutOfOrderSpans,
+		obsmetrics.ProcessorOutOfOrderMetricPoints,
+		obsmetrics.ProcessorOutOfOrderLogRecords,
 	}
 	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
 
+	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor, obsmetrics.TagKeySourceReceiver}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ProcessorAcceptedSpansBySource}, tagKeys, view.Sum())...)
+
+	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor, obsmetrics.TagKeyFlushReason}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ProcessorFlushByReason}, tagKeys, view.Sum())...)
+	views = append(views, &view.View{
+		Name:        obsmetrics.ProcessorTimeoutFlushRatio.Name(),
+		Description: obsmetrics.ProcessorTimeoutFlushRatio.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyProcessor},
+		Measure:     obsmetrics.ProcessorTimeoutFlushRatio,
+		Aggregation: view.LastValue(),
+	})
+
+	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor, obsmetrics.TagKeyDecision}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ProcessorSampledSpans}, tagKeys, view.Sum())...)
+
+	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor, obsmetrics.TagKeyRuleID}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ProcessorDroppedSpansByRule}, tagKeys, view.Sum())...)
+
+	measures = []*stats.Int64Measure{
+		obsmetrics.ProcessorDroppedSpansByPipeline,
+		obsmetrics.ProcessorDroppedMetricPointsByPipeline,
+		obsmetrics.ProcessorDroppedLogRecordsByPipeline,
+	}
+	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor, obsmetrics.TagKeyPipeline}
+	views = append(views, genViews(measures, tagKeys, view.Sum())...)
+
+	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor, obsmetrics.TagKeyResource}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ProcessorDroppedSpansByResource}, tagKeys, view.Sum())...)
+
+	measures = []*stats.Int64Measure{
+		obsmetrics.ProcessorTransformDroppedSpans,
+		obsmetrics.ProcessorTransformDroppedMetricPoints,
+		obsmetrics.ProcessorTransformDroppedLogRecords,
+	}
+	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor, obsmetrics.TagKeyTransformReason}
+	views = append(views, genViews(measures, tagKeys, view.Sum())...)
+
+	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor, obsmetrics.TagKeySignal, obsmetrics.TagKeyThreshold}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ProcessorThresholdBreaches}, tagKeys, view.Sum())...)
+
+	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor, obsmetrics.TagKeySignal, obsmetrics.TagKeyOutcome}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ProcessorEnrichedItems}, tagKeys, view.Sum())...)
+
+	views = append(views, &view.View{
+		Name:        obsmetrics.ProcessorEffectiveSampleRatio.Name(),
+		Description: obsmetrics.ProcessorEffectiveSampleRatio.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyProcessor, obsmetrics.TagKeySignal},
+		Measure:     obsmetrics.ProcessorEffectiveSampleRatio,
+		Aggregation: view.LastValue(),
+	})
+
+	views = append(views, &view.View{
+		Name:        obsmetrics.ProcessorAllocatedBytes.Name(),
+		Description: obsmetrics.ProcessorAllocatedBytes.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyProcessor},
+		Measure:     obsmetrics.ProcessorAllocatedBytes,
+		Aggregation: view.Distribution(AllocatedBytesBuckets...),
+	})
+
+	views = append(views, &view.View{
+		Name:        obsmetrics.ProcessorQueueLatency.Name(),
+		Description: obsmetrics.ProcessorQueueLatency.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyProcessor},
+		Measure:     obsmetrics.ProcessorQueueLatency,
+		Aggregation: view.Distribution(LatencyBuckets...),
+	})
+
+	views = append(views, &view.View{
+		Name:        obsmetrics.ProcessorProcessingDuration.Name(),
+		Description: obsmetrics.ProcessorProcessingDuration.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyProcessor, obsmetrics.TagKeySignal},
+		Measure:     obsmetrics.ProcessorProcessingDuration,
+		Aggregation: view.Distribution(LatencyBuckets...),
+	})
+
+	views = append(views, &view.View{
+		Name:        obsmetrics.ProcessorFanoutDegree.Name(),
+		Description: obsmetrics.ProcessorFanoutDegree.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyProcessor, obsmetrics.TagKeySignal},
+		Measure:     obsmetrics.ProcessorFanoutDegree,
+		Aggregation: view.Distribution(FanoutDegreeBuckets...),
+	})
+
+	views = append(views, &view.View{
+		Name:        obsmetrics.ProcessorBatchSplitFactor.Name(),
+		Description: obsmetrics.ProcessorBatchSplitFactor.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyProcessor},
+		Measure:     obsmetrics.ProcessorBatchSplitFactor,
+		Aggregation: view.Distribution(SplitFactorBuckets...),
+	})
+
+	// Saturation and concurrency views.
+	views = append(views,
+		componentGaugeView(obsmetrics.ReceiverSaturation, obsmetrics.TagKeyReceiver),
+		componentGaugeView(obsmetrics.ProcessorSaturation, obsmetrics.TagKeyProcessor),
+		componentGaugeView(obsmetrics.ExporterSaturation, obsmetrics.TagKeyExporter),
+		componentGaugeView(obsmetrics.ConnectorSaturation, obsmetrics.TagKeyConnector),
+		componentGaugeView(obsmetrics.ReceiverConcurrency, obsmetrics.TagKeyReceiver),
+		componentGaugeView(obsmetrics.ProcessorConcurrency, obsmetrics.TagKeyProcessor),
+		componentGaugeView(obsmetrics.ExporterConcurrency, obsmetrics.TagKeyExporter),
+		componentGaugeView(obsmetrics.ConnectorConcurrency, obsmetrics.TagKeyConnector))
+
+	// Obsreport views.
+	tagKeys = []tag.Key{obsmetrics.TagKeyComponentKind}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ObsreportOverhead}, tagKeys, view.Sum())...)
+
+	// Collector views.
+	tagKeys = []tag.Key{obsmetrics.TagKeyVersion, obsmetrics.TagKeyCommit, obsmetrics.TagKeyCollectorInstanceID}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.CollectorInfo}, tagKeys, view.LastValue())...)
+
 	return views
 }
 
+// WithInstanceIDTag returns copies of the given views also tagged with the collector
+// instance, to register instead of them when the components set the instance ID in their
+// obsreport settings. Without it, the other series would be exported with an empty
+// collector instance label, so the tag set is decided once, before the views are registered.
+func WithInstanceIDTag(views []*view.View) []*view.View {
+	tagged := make([]*view.View, 0, len(views))
+	for _, v := range views {
+		if hasTagKey(v, obsmetrics.TagKeyCollectorInstanceID) {
+			tagged = append(tagged, v)
+			continue
+		}
+		t := *v
+		t.TagKeys = append(append([]tag.Key{}, v.TagKeys...), obsmetrics.TagKeyCollectorInstanceID)
+		tagged = append(tagged, &t)
+	}
+	return tagged
+}
+
+func hasTagKey(v *view.View, key tag.Key) bool {
+	for _, k := range v.TagKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
 func receiverViews() []*view.View {
 	if UseOtelForInternalMetricsfeatureGate.IsEnabled() {
 		return nil
@@ -105,12 +362,157 @@ func receiverViews() []*view.View {
 		obsmetrics.ReceiverRefusedMetricPoints,
 		obsmetrics.ReceiverAcceptedLogRecords,
 		obsmetrics.ReceiverRefusedLogRecords,
+		obsmetrics.ReceiverAcceptedSpanEvents,
+		obsmetrics.ReceiverRefusedSpanEvents,
+		obsmetrics.ReceiverAcceptedSpanLinks,
+		obsmetrics.ReceiverRefusedSpanLinks,
+		obsmetrics.ReceiverAcceptedResources,
+		obsmetrics.ReceiverAcceptedScopes,
+		obsmetrics.ReceiverAcceptedLogRecordBytes,
+		obsmetrics.ReceiverSchemaMismatches,
+		obsmetrics.ReceiverAuthFailures,
+		obsmetrics.ReceiverReadErrors,
+		obsmetrics.ReceiverKeepalives,
+		obsmetrics.ReceiverEmptyBatches,
 	}
 	tagKeys := []tag.Key{
 		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport,
 	}
+	views := genViews(measures, tagKeys, view.Sum())
+
+	skewTagKeys := []tag.Key{
+		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport, obsmetrics.TagKeyClockSkew,
+	}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ReceiverAcceptedSpansByClockSkew}, skewTagKeys, view.Sum())...)
+
+	sampledTagKeys := []tag.Key{
+		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport, obsmetrics.TagKeySampled,
+	}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ReceiverAcceptedSpansBySampled}, sampledTagKeys, view.Sum())...)
+
+	conversionTagKeys := []tag.Key{
+		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport, obsmetrics.TagKeyFromFormat, obsmetrics.TagKeyToFormat,
+	}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ReceiverConvertedSpans}, conversionTagKeys, view.Sum())...)
+
+	validationTagKeys := []tag.Key{
+		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport, obsmetrics.TagKeyValidationField,
+	}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ReceiverValidationErrors}, validationTagKeys, view.Sum())...)
+
+	streamCloseTagKeys := []tag.Key{
+		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport, obsmetrics.TagKeyStreamClose,
+	}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ReceiverStreamCloses}, streamCloseTagKeys, view.Sum())...)
+
+	protoVersionTagKeys := []tag.Key{
+		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport, obsmetrics.TagKeyProtoVersion,
+	}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ReceiverAcceptedSpansByProtoVersion}, protoVersionTagKeys, view.Sum())...)
+
+	tenantMeasures := []*stats.Int64Measure{
+		obsmetrics.ReceiverAcceptedSpansByTenant,
+		obsmetrics.ReceiverRefusedSpansByTenant,
+	}
+	tenantTagKeys := []tag.Key{
+		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport, obsmetrics.TagKeyTenant,
+	}
+	views = append(views, genViews(tenantMeasures, tenantTagKeys, view.Sum())...)
+	volumeMeasures := []*stats.Int64Measure{
+		obsmetrics.ReceiverAcceptedSpansByVolume,
+		obsmetrics.ReceiverAcceptedMetricPointsByVolume,
+		obsmetrics.ReceiverAcceptedLogRecordsByVolume,
+	}
+	volumeTagKeys := []tag.Key{
+		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport, obsmetrics.TagKeyVolume,
+	}
+	views = append(views, genViews(volumeMeasures, volumeTagKeys, view.Sum())...)
+	statusCodeTagKeys := []tag.Key{
+		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport, obsmetrics.TagKeyHTTPStatusCode,
+	}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ReceiverRefusedSpansByStatusCode}, statusCodeTagKeys, view.Sum())...)
+	views = append(views, genViews([]*stats.Int64Measure{
+		obsmetrics.ReceiverDistinctResourcesEstimate,
+		obsmetrics.ReceiverDistinctTracesEstimate,
+		obsmetrics.ReceiverActiveConnections,
+	}, tagKeys, view.LastValue())...)
+
+	connectionTagKeys := []tag.Key{
+		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport, obsmetrics.TagKeyConnection,
+	}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ReceiverConnections}, connectionTagKeys, view.Sum())...)
 
-	return genViews(measures, tagKeys, view.Sum())
+	parseTagKeys := []tag.Key{
+		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport, obsmetrics.TagKeyFormat,
+	}
+	return append(views, &view.View{
+		Name:        obsmetrics.ReceiverFirstByteLatency.Name(),
+		Description: obsmetrics.ReceiverFirstByteLatency.Description(),
+		TagKeys:     tagKeys,
+		Measure:     obsmetrics.ReceiverFirstByteLatency,
+		Aggregation: view.Distribution(LatencyBuckets...),
+	}, &view.View{
+		Name:        obsmetrics.ReceiverDeadlineRemaining.Name(),
+		Description: obsmetrics.ReceiverDeadlineRemaining.Description(),
+		TagKeys:     tagKeys,
+		Measure:     obsmetrics.ReceiverDeadlineRemaining,
+		Aggregation: view.Distribution(LatencyBuckets...),
+	}, &view.View{
+		Name:        obsmetrics.ReceiverParseDuration.Name(),
+		Description: obsmetrics.ReceiverParseDuration.Description(),
+		TagKeys:     parseTagKeys,
+		Measure:     obsmetrics.ReceiverParseDuration,
+		Aggregation: view.Distribution(LatencyBuckets...),
+	}, &view.View{
+		Name:        obsmetrics.ReceiverAttributesPerSpan.Name(),
+		Description: obsmetrics.ReceiverAttributesPerSpan.Description(),
+		TagKeys:     tagKeys,
+		Measure:     obsmetrics.ReceiverAttributesPerSpan,
+		Aggregation: view.Distribution(AttributeCountBuckets...),
+	}, &view.View{
+		Name:        obsmetrics.ReceiverItemsPerCore.Name(),
+		Description: obsmetrics.ReceiverItemsPerCore.Description(),
+		TagKeys:     []tag.Key{obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport, obsmetrics.TagKeySignal},
+		Measure:     obsmetrics.ReceiverItemsPerCore,
+		Aggregation: view.LastValue(),
+	})
+}
+
+func connectorViews() []*view.View {
+	receiveMeasures := []*stats.Int64Measure{
+		obsmetrics.ConnectorAcceptedSpans,
+		obsmetrics.ConnectorRefusedSpans,
+		obsmetrics.ConnectorAcceptedMetricPoints,
+		obsmetrics.ConnectorRefusedMetricPoints,
+		obsmetrics.ConnectorAcceptedLogRecords,
+		obsmetrics.ConnectorRefusedLogRecords,
+		obsmetrics.ConnectorAcceptedSpanEvents,
+		obsmetrics.ConnectorRefusedSpanEvents,
+		obsmetrics.ConnectorAcceptedSpanLinks,
+		obsmetrics.ConnectorRefusedSpanLinks,
+	}
+	views := genViews(receiveMeasures, []tag.Key{obsmetrics.TagKeyConnector}, view.Sum())
+
+	skewTagKeys := []tag.Key{obsmetrics.TagKeyConnector, obsmetrics.TagKeyClockSkew}
+	views = append(views, genViews([]*stats.Int64Measure{obsmetrics.ConnectorAcceptedSpansByClockSkew}, skewTagKeys, view.Sum())...)
+
+	exportMeasures := []*stats.Int64Measure{
+		obsmetrics.ConnectorSentSpans,
+		obsmetrics.ConnectorFailedToSendSpans,
+		obsmetrics.ConnectorSentMetricPoints,
+		obsmetrics.ConnectorFailedToSendMetricPoints,
+		obsmetrics.ConnectorSentLogRecords,
+		obsmetrics.ConnectorFailedToSendLogRecords,
+	}
+	views = append(views, genViews(exportMeasures, []tag.Key{obsmetrics.TagKeyConnector}, view.Sum())...)
+
+	rerouteMeasures := []*stats.Int64Measure{
+		obsmetrics.ConnectorReroutedSpans,
+		obsmetrics.ConnectorReroutedMetricPoints,
+		obsmetrics.ConnectorReroutedLogRecords,
+	}
+	rerouteTagKeys := []tag.Key{obsmetrics.TagKeyConnector, obsmetrics.TagKeyFromPipeline, obsmetrics.TagKeyToPipeline}
+	return append(views, genViews(rerouteMeasures, rerouteTagKeys, view.Sum())...)
 }
 
 func scraperViews() []*view.View {
@@ -121,10 +523,29 @@ func scraperViews() []*view.View {
 	measures := []*stats.Int64Measure{
 		obsmetrics.ScraperScrapedMetricPoints,
 		obsmetrics.ScraperErroredMetricPoints,
+		obsmetrics.ScraperCacheHits,
+		obsmetrics.ScraperCacheMisses,
 	}
 	tagKeys := []tag.Key{obsmetrics.TagKeyReceiver, obsmetrics.TagKeyScraper}
+	views := genViews(measures, tagKeys, view.Sum())
+
+	measures = []*stats.Int64Measure{
+		obsmetrics.ScraperMetricScrapes,
+		obsmetrics.ScraperMetricScrapeErrors,
+	}
+	tagKeys = []tag.Key{obsmetrics.TagKeyReceiver, obsmetrics.TagKeyScraper, obsmetrics.TagKeyMetricName}
 
-	return genViews(measures, tagKeys, view.Sum())
+	return append(views, genViews(measures, tagKeys, view.Sum())...)
+}
+
+func componentGaugeView(measure stats.Measure, tagKey tag.Key) *view.View {
+	return &view.View{
+		Name:        measure.Name(),
+		Description: measure.Description(),
+		TagKeys:     []tag.Key{tagKey},
+		Measure:     measure,
+		Aggregation: view.LastValue(),
+	}
 }
 
 func genViews(
@@ -18,8 +18,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
 func TestConfigure(t *testing.T) {
@@ -35,17 +38,17 @@ func TestConfigure(t *testing.T) {
 		{
 			name:         "basic",
 			level:        configtelemetry.LevelBasic,
-			wantViewsLen: 24,
+			wantViewsLen: 153,
 		},
 		{
 			name:         "normal",
 			level:        configtelemetry.LevelNormal,
-			wantViewsLen: 24,
+			wantViewsLen: 153,
 		},
 		{
 			name:         "detailed",
 			level:        configtelemetry.LevelDetailed,
-			wantViewsLen: 24,
+			wantViewsLen: 153,
 		},
 	}
 	for _, tt := range tests {
@@ -54,3 +57,25 @@ func TestConfigure(t *testing.T) {
 		})
 	}
 }
+
+func TestAllViewsUnits(t *testing.T) {
+	for _, v := range AllViews(configtelemetry.LevelDetailed) {
+		unit := v.Measure.Unit()
+		assert.NotEmpty(t, unit, v.Name)
+		assert.NotEqual(t, stats.UnitDimensionless, unit, v.Name)
+	}
+}
+
+func TestWithInstanceIDTag(t *testing.T) {
+	views := AllViews(configtelemetry.LevelDetailed)
+	tagged := WithInstanceIDTag(views)
+	require.Len(t, tagged, len(views))
+	for i, v := range views {
+		assert.Equal(t, v.Name, tagged[i].Name)
+		assert.Contains(t, tagged[i].TagKeys, obsmetrics.TagKeyCollectorInstanceID, v.Name)
+		// The views are only tagged when asked to, except collector/info which always is.
+		if v.Name != obsmetrics.CollectorInfo.Name() {
+			assert.NotContains(t, v.TagKeys, obsmetrics.TagKeyCollectorInstanceID, v.Name)
+		}
+	}
+}
@@ -85,6 +85,12 @@
 //   - Data "filtered out" should have its own metrics and not be confused
 //     with dropped data.
 //
+// * The counters of the items of an operation, eg.: the accepted and refused
+// spans of a receive operation or the accepted, refused and dropped spans of
+// a processor, are updated together: with OpenCensus they are recorded in a
+// single call, with OpenTelemetry they are added under a lock of the helper,
+// so the concurrent operations of a helper do not interleave their updates.
+//
 // # Naming Convention for New Metrics
 //
 // Common Metrics:
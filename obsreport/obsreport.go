@@ -15,8 +15,24 @@
 package obsreport // import "go.opentelemetry.io/collector/obsreport"
 
 import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
 	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
 const (
@@ -25,8 +41,662 @@ const (
 	nameSep = "/"
 )
 
-func recordError(span trace.Span, err error) {
+// StatusMapper maps the error returned by an operation to the status of its span.
+// It is only called with non-nil errors. Returning codes.Unset leaves the status
+// of the span unchanged, which allows expected errors (e.g. the context being
+// canceled during shutdown) to not mark the span as failed.
+type StatusMapper func(err error) (codes.Code, string)
+
+// defaultStatusMapper sets the status of the span to error for any error.
+func defaultStatusMapper(err error) (codes.Code, string) {
+	return codes.Error, err.Error()
+}
+
+func recordError(span trace.Span, err error, statusMapper StatusMapper) {
+	if err == nil {
+		return
+	}
+	if statusMapper == nil {
+		statusMapper = defaultStatusMapper
+	}
+	if code, description := statusMapper(err); code != codes.Unset {
+		span.SetStatus(code, description)
+	}
+}
+
+// startSpan starts the span of an operation. When minDuration is positive the span is
+// deferred: it is buffered until it ends and only then started, with its original start
+// time, if the operation took at least minDuration or its status was set to codes.Error.
+// Since the span does not exist during the operation, the spans started from the returned
+// context are children of the parent span of the operation instead.
+func startSpan(ctx context.Context, tracer trace.Tracer, minDuration time.Duration, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if minDuration <= 0 {
+		return tracer.Start(ctx, name, opts...)
+	}
+	span := &deferredSpan{
+		parentCtx:   ctx,
+		tracer:      tracer,
+		minDuration: minDuration,
+		name:        name,
+		startOpts:   opts,
+		start:       time.Now(),
+	}
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+// deferredSpanEvent is an event added to a deferredSpan.
+type deferredSpanEvent struct {
+	name string
+	err  error
+	opts []trace.EventOption
+}
+
+// deferredSpan is a span that is only started when it ends, see startSpan.
+// Like the spans of the operations, it is not safe for concurrent use.
+type deferredSpan struct {
+	parentCtx   context.Context
+	tracer      trace.Tracer
+	minDuration time.Duration
+	name        string
+	startOpts   []trace.SpanStartOption
+	start       time.Time
+
+	attrs       []attribute.KeyValue
+	events      []deferredSpanEvent
+	code        codes.Code
+	description string
+	ended       bool
+}
+
+var _ trace.Span = (*deferredSpan)(nil)
+
+// End starts and ends the buffered span if the operation was slow or failed, otherwise it is dropped.
+func (s *deferredSpan) End(options ...trace.SpanEndOption) {
+	if s.ended {
+		return
+	}
+	s.ended = true
+	end := time.Now()
+	if end.Sub(s.start) < s.minDuration && s.code != codes.Error {
+		return
+	}
+
+	_, span := s.tracer.Start(s.parentCtx, s.name, append(s.startOpts, trace.WithTimestamp(s.start))...)
+	span.SetAttributes(s.attrs...)
+	for _, event := range s.events {
+		if event.err != nil {
+			span.RecordError(event.err, event.opts...)
+		} else {
+			span.AddEvent(event.name, event.opts...)
+		}
+	}
+	if s.code != codes.Unset {
+		span.SetStatus(s.code, s.description)
+	}
+	span.End(append(options, trace.WithTimestamp(end))...)
+}
+
+// AddEvent buffers the event until the span ends.
+func (s *deferredSpan) AddEvent(name string, options ...trace.EventOption) {
+	if !s.ended {
+		s.events = append(s.events, deferredSpanEvent{name: name, opts: append(options, trace.WithTimestamp(time.Now()))})
+	}
+}
+
+// IsRecording returns true until the span ends, since it may still be recorded.
+func (s *deferredSpan) IsRecording() bool {
+	return !s.ended
+}
+
+// RecordError buffers the error until the span ends.
+func (s *deferredSpan) RecordError(err error, options ...trace.EventOption) {
+	if !s.ended && err != nil {
+		s.events = append(s.events, deferredSpanEvent{err: err, opts: append(options, trace.WithTimestamp(time.Now()))})
+	}
+}
+
+// SpanContext returns the span context of the parent span, since the span has not started yet.
+func (s *deferredSpan) SpanContext() trace.SpanContext {
+	return trace.SpanContextFromContext(s.parentCtx)
+}
+
+// SetStatus buffers the status until the span ends, following the same precedence as the spans.
+func (s *deferredSpan) SetStatus(code codes.Code, description string) {
+	if s.ended || s.code == codes.Ok || code < s.code {
+		return
+	}
+	s.code = code
+	s.description = ""
+	if code == codes.Error {
+		s.description = description
+	}
+}
+
+// SetName sets the name the span is started with.
+func (s *deferredSpan) SetName(name string) {
+	if !s.ended {
+		s.name = name
+	}
+}
+
+// SetAttributes buffers the attributes until the span ends.
+func (s *deferredSpan) SetAttributes(kv ...attribute.KeyValue) {
+	if !s.ended {
+		s.attrs = append(s.attrs, kv...)
+	}
+}
+
+// TracerProvider returns the provider of the parent span.
+func (s *deferredSpan) TracerProvider() trace.TracerProvider {
+	return trace.SpanFromContext(s.parentCtx).TracerProvider()
+}
+
+// OpHandle identifies an operation started with one of the Start*OpWithHandle functions
+// of a Receiver or an Exporter. It captures the start time, span and tags of the operation
+// held by the returned context but none of its deadline or cancellation, so it can be
+// passed to the End*Op functions, in place of the context, from another goroutine and
+// after the request that started the operation is done, e.g. for asynchronous exports:
+//
+//	ctx, handle := exp.StartTracesOpWithHandle(ctx)
+//	go func() { exp.EndTracesOp(handle, numSpans, send(td)) }()
+//
+// It implements context.Context, but it should not be used to start other operations.
+type OpHandle struct {
+	opCtx context.Context
+}
+
+var _ context.Context = OpHandle{}
+
+func newOpHandle(opCtx context.Context) (context.Context, OpHandle) {
+	return opCtx, OpHandle{opCtx: opCtx}
+}
+
+// Deadline implements context.Context, an OpHandle never has a deadline.
+func (OpHandle) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+// Done implements context.Context, an OpHandle is never canceled.
+func (OpHandle) Done() <-chan struct{} {
+	return nil
+}
+
+// Err implements context.Context, an OpHandle is never canceled.
+func (OpHandle) Err() error {
+	return nil
+}
+
+// Value returns the values of the context of the operation.
+func (h OpHandle) Value(key any) any {
+	if h.opCtx == nil {
+		return nil
+	}
+	return h.opCtx.Value(key)
+}
+
+// PartialError is an error returned by an operation that failed partway through,
+// e.g. when the next consumer failed after part of a batch was already consumed.
+// When passed to the End*Op functions of a Receiver or an Exporter, the Accepted
+// items are recorded as accepted (or sent) and the Refused ones as refused (or
+// failed to send) instead of the whole batch, so they should add up to the number
+// of items of the operation; they are clamped to it otherwise. The status of the span
+// is set from Err, or to an error if Err is nil but some items were refused.
+type PartialError struct {
+	Accepted int
+	Refused  int
+	Err      error
+}
+
+// Error implements the error interface.
+func (e PartialError) Error() string {
+	if e.Err == nil {
+		return "partial error"
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e PartialError) Unwrap() error {
+	return e.Err
+}
+
+// toAcceptedRefused splits the items of an operation into accepted and refused
+// according to err, and returns the error to set the status of the span from.
+func toAcceptedRefused(numItems int, err error) (int, int, error) {
+	var partialErr PartialError
+	if errors.As(err, &partialErr) {
+		refused := clampItems(partialErr.Refused, numItems)
+		accepted := clampItems(partialErr.Accepted, numItems-refused)
+		if partialErr.Err == nil && refused > 0 {
+			return accepted, refused, partialErr
+		}
+		return accepted, refused, partialErr.Err
+	}
 	if err != nil {
-		span.SetStatus(codes.Error, err.Error())
+		return 0, numItems, err
+	}
+	return numItems, 0, nil
+}
+
+// clampItems clamps the number of items n between 0 and limit.
+func clampItems(n, limit int) int {
+	if n < 0 {
+		return 0
+	}
+	if n > limit {
+		return limit
+	}
+	return n
+}
+
+// allAccepted reports whether all the items of an operation ending with err were
+// accepted, i.e. err is nil or a PartialError refusing none of them.
+func allAccepted(numItems int, err error) bool {
+	if err == nil {
+		return true
+	}
+	var partialErr PartialError
+	if !errors.As(err, &partialErr) {
+		return false
+	}
+	_, refused, _ := toAcceptedRefused(numItems, err)
+	return refused == 0
+}
+
+// splitAcceptedRefused splits n, a quantity related to the items of an operation such
+// as their size, in proportion to the accepted and refused items of the operation,
+// since the ones refused by a PartialError cannot be told apart.
+func splitAcceptedRefused(n, numItems, numAccepted int) (int, int) {
+	if numItems <= 0 {
+		return 0, n
+	}
+	if numAccepted >= numItems {
+		return n, 0
+	}
+	accepted := int(int64(n) * int64(numAccepted) / int64(numItems))
+	return accepted, n - accepted
+}
+
+// withAttrs returns a new slice with the given extra attributes appended to attrs,
+// so the attributes shared by all the measurements of a component are never modified.
+// The OpenTelemetry SDK sorts the attributes it is given in place, so the attributes
+// shared by the recordings of a component must always be copied with it before being
+// passed to an instrument, otherwise concurrent recordings race on the shared slice.
+func withAttrs(attrs []attribute.KeyValue, extra ...attribute.KeyValue) []attribute.KeyValue {
+	res := make([]attribute.KeyValue, 0, len(attrs)+len(extra))
+	res = append(res, attrs...)
+	return append(res, extra...)
+}
+
+// maxInternedAttrs caps the number of tag values cached by an attrsInterner, so a
+// tag with an unexpectedly large set of values does not grow the cache indefinitely.
+const maxInternedAttrs = 256
+
+// internedAttrs are the attributes and tag mutators of a component extended with one
+// or two extra tags. They are shared by all the recordings and must never be modified.
+type internedAttrs struct {
+	kvs      []attribute.KeyValue
+	mutators []tag.Mutator
+}
+
+// attrs returns a copy of the interned attributes to pass to an instrument, see withAttrs.
+func (ia internedAttrs) attrs() []attribute.KeyValue {
+	return withAttrs(ia.kvs)
+}
+
+// internKey identifies the extra tags of a set of internedAttrs, the second one is empty
+// for the sets with a single extra tag.
+type internKey [2]attribute.KeyValue
+
+// attrsInterner caches the attributes and tag mutators of a component extended with
+// one or two extra tags, e.g. the status code of a failed export, so the recordings do not
+// build them on every call: the mutators are shared, while the attributes are copied with a
+// single allocation before each OpenTelemetry recording, see withAttrs. It also caches the tag
+// map of the component, to avoid building it for each operation when the caller context
+// has no tags.
+type attrsInterner struct {
+	attrs    []attribute.KeyValue
+	mutators []tag.Mutator
+	tagMap   *tag.Map
+
+	mu    sync.RWMutex
+	cache map[internKey]internedAttrs
+}
+
+// newAttrsInterner creates an attrsInterner for the component recording with the given
+// attributes and tag mutators. The mutators of the interned sets are only the extra tags
+// when mutators is nil, for components that record on a context that is already tagged.
+func newAttrsInterner(attrs []attribute.KeyValue, mutators []tag.Mutator) *attrsInterner {
+	ai := &attrsInterner{
+		attrs:    attrs,
+		mutators: mutators,
+		cache:    make(map[internKey]internedAttrs),
+	}
+	if len(mutators) > 0 {
+		if ctx, err := tag.New(context.Background(), mutators...); err == nil {
+			ai.tagMap = tag.FromContext(ctx)
+		}
+	}
+	return ai
+}
+
+// base returns the attributes and tag mutators of the component without any extra tag.
+func (ai *attrsInterner) base() internedAttrs {
+	return internedAttrs{kvs: ai.attrs, mutators: ai.mutators}
+}
+
+// with returns the attributes and tag mutators of the component extended with the tag
+// key and value, in the order the recordings used before interning them.
+func (ai *attrsInterner) with(key tag.Key, value string) internedAttrs {
+	kv := attribute.String(key.Name(), value)
+	if ia, ok := ai.load(internKey{kv}); ok {
+		return ia
+	}
+	return ai.store(internKey{kv}, internedAttrs{
+		kvs:      withAttrs(ai.attrs, kv),
+		mutators: append([]tag.Mutator{tag.Upsert(key, value, tag.WithTTL(tag.TTLNoPropagation))}, ai.mutators...),
+	})
+}
+
+// with2 is like with, extending the attributes and tag mutators of the component with two tags.
+func (ai *attrsInterner) with2(key1 tag.Key, value1 string, key2 tag.Key, value2 string) internedAttrs {
+	kv1, kv2 := attribute.String(key1.Name(), value1), attribute.String(key2.Name(), value2)
+	if ia, ok := ai.load(internKey{kv1, kv2}); ok {
+		return ia
+	}
+	return ai.store(internKey{kv1, kv2}, internedAttrs{
+		kvs: withAttrs(ai.attrs, kv1, kv2),
+		mutators: append([]tag.Mutator{
+			tag.Upsert(key1, value1, tag.WithTTL(tag.TTLNoPropagation)),
+			tag.Upsert(key2, value2, tag.WithTTL(tag.TTLNoPropagation)),
+		}, ai.mutators...),
+	})
+}
+
+func (ai *attrsInterner) load(key internKey) (internedAttrs, bool) {
+	ai.mu.RLock()
+	defer ai.mu.RUnlock()
+	ia, ok := ai.cache[key]
+	return ia, ok
+}
+
+// store caches ia unless the cache is full, returning the set cached by a concurrent
+// call for the same key if any.
+func (ai *attrsInterner) store(key internKey, ia internedAttrs) internedAttrs {
+	ai.mu.Lock()
+	defer ai.mu.Unlock()
+	if cached, ok := ai.cache[key]; ok {
+		return cached
+	}
+	if len(ai.cache) < maxInternedAttrs {
+		ai.cache[key] = ia
+	}
+	return ia
+}
+
+// newContext returns ctx tagged with the tag mutators of the component. The cached tag
+// map is reused when ctx has no tags, since applying the mutators gives the same map.
+func (ai *attrsInterner) newContext(ctx context.Context) context.Context {
+	if ai.tagMap != nil && tag.FromContext(ctx) == nil {
+		return tag.NewContext(ctx, ai.tagMap)
+	}
+	ctx, _ = tag.New(ctx, ai.mutators...)
+	return ctx
+}
+
+// MetricNaming is the strategy used to build the names of the metrics recorded with
+// OpenTelemetry. It does not apply to the metrics recorded with OpenCensus, whose
+// views are registered once for all the components, so their names always use "/".
+// The custom metrics of the processors follow it when their names are built with
+// MetricNaming.BuildProcessorCustomMetricName.
+type MetricNaming int
+
+const (
+	// MetricNamingDefault separates the parts of the metric names with "/",
+	// e.g. "receiver/accepted_spans".
+	MetricNamingDefault MetricNaming = iota
+	// MetricNamingOpenMetrics separates the parts of the metric names with "_",
+	// e.g. "receiver_accepted_spans", for backends that only accept OpenMetrics
+	// compatible names.
+	MetricNamingOpenMetrics
+)
+
+// metricPrefix returns the prefix of the names of the metrics of the given kind of component.
+func (mn MetricNaming) metricPrefix(key string) string {
+	return key + mn.separator()
+}
+
+// separator returns the separator of the parts of the metric names.
+func (mn MetricNaming) separator() string {
+	if mn == MetricNamingOpenMetrics {
+		return "_"
+	}
+	return nameSep
+}
+
+// BuildProcessorCustomMetricName is like the package level BuildProcessorCustomMetricName,
+// but separates the parts of the name according to the naming strategy, so that the custom
+// metrics of a processor are named consistently with the ones recorded by its Processor.
+func (mn MetricNaming) BuildProcessorCustomMetricName(configType, metric string) string {
+	componentPrefix := mn.metricPrefix(obsmetrics.ProcessorKey)
+	if configType == "" {
+		return componentPrefix
+	}
+	return componentPrefix + configType + mn.separator() + metric
+}
+
+// SignalLevels overrides the metrics level of a component for individual signals, e.g. to
+// record the metrics for the logs exported by a component but not for its traces.
+// A nil level means that the metrics level of the component TelemetrySettings is used.
+type SignalLevels struct {
+	Traces  *configtelemetry.Level
+	Metrics *configtelemetry.Level
+	Logs    *configtelemetry.Level
+}
+
+// levelFor returns the metrics level to use for the given signal.
+func (sl SignalLevels) levelFor(dataType component.DataType, defaultLevel configtelemetry.Level) configtelemetry.Level {
+	var level *configtelemetry.Level
+	switch dataType {
+	case component.DataTypeTraces:
+		level = sl.Traces
+	case component.DataTypeMetrics:
+		level = sl.Metrics
+	case component.DataTypeLogs:
+		level = sl.Logs
+	}
+	if level == nil {
+		return defaultLevel
+	}
+	return *level
+}
+
+// pipelineStartKey is the context key for the time the receive operation of the data started,
+// set by the receivers with RecordPipelineStart and read by the exporters with RecordPipelineLatency.
+type pipelineStartKey struct{}
+
+// pipelineKey is the context key for the name of the pipeline the data flows through, set with WithPipeline.
+type pipelineKey struct{}
+
+// WithPipeline returns a copy of the context holding the name of the pipeline the data
+// flows through, e.g. "traces/backend". The service sets it when passing the data to the
+// first consumer of each pipeline, so that the processors shared by multiple pipelines can
+// tell which pipeline the data they drop belongs to. It is lost by the components handling
+// the data asynchronously, e.g. the batch processor.
+func WithPipeline(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, pipelineKey{}, name)
+}
+
+// PipelineFromContext returns the name of the pipeline set with WithPipeline, if any. The
+// components handling the data asynchronously can use it to carry the pipeline over.
+func PipelineFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(pipelineKey{}).(string)
+	return name, ok && name != ""
+}
+
+// atomicLevel holds the metrics level of a component, which can be changed while the
+// component is running with the SetLevel method of its helper.
+type atomicLevel struct {
+	v atomic.Int32
+}
+
+func newAtomicLevel(level configtelemetry.Level) *atomicLevel {
+	al := &atomicLevel{}
+	al.Store(level)
+	return al
+}
+
+// Load returns the current metrics level.
+func (al *atomicLevel) Load() configtelemetry.Level {
+	return configtelemetry.Level(al.v.Load())
+}
+
+// Store sets the metrics level.
+func (al *atomicLevel) Store(level configtelemetry.Level) {
+	al.v.Store(int32(level))
+}
+
+// Flush exports the metrics recorded with OpenTelemetry so far with all the readers of the
+// given MeterProvider, e.g. so they are not lost when the collector shuts down. It is a no-op
+// for the MeterProviders that cannot be flushed, e.g. the no-op one. The metrics recorded with
+// OpenCensus have nothing to flush: they are aggregated into the views, which the exporters
+// read when they are scraped.
+func Flush(ctx context.Context, provider metric.MeterProvider) error {
+	if f, ok := provider.(interface{ ForceFlush(context.Context) error }); ok {
+		return f.ForceFlush(ctx)
+	}
+	return nil
+}
+
+// RecordBuildInfo records the collector/info gauge, always 1, tagged with the version of
+// the collector and the commit it was built from, for dashboards to join the other metrics
+// against, and with the collector instance if instanceID is not empty. It should be called
+// once at startup, with the telemetry settings of the service.
+func RecordBuildInfo(set component.TelemetrySettings, version, commit, instanceID string) error {
+	return recordBuildInfo(set, version, commit, instanceID, obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled())
+}
+
+func recordBuildInfo(set component.TelemetrySettings, version, commit, instanceID string, useOtel bool) error {
+	if set.MetricsLevel == configtelemetry.LevelNone {
+		return nil
+	}
+	mutators := []tag.Mutator{
+		tag.Upsert(obsmetrics.TagKeyVersion, version, tag.WithTTL(tag.TTLNoPropagation)),
+		tag.Upsert(obsmetrics.TagKeyCommit, commit, tag.WithTTL(tag.TTLNoPropagation)),
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String(obsmetrics.VersionKey, version),
+		attribute.String(obsmetrics.CommitKey, commit),
+	}
+	instanceMutators, instanceAttrs := instanceIDTags(instanceID)
+	mutators = append(mutators, instanceMutators...)
+	attrs = append(attrs, instanceAttrs...)
+
+	if !useOtel {
+		return stats.RecordWithTags(context.Background(), mutators, obsmetrics.CollectorInfo.M(1))
+	}
+	_, err := set.MeterProvider.Meter(scopeName).Int64ObservableGauge(
+		obsmetrics.CollectorPrefix+obsmetrics.InfoKey,
+		instrument.WithDescription("Build information of the collector, always 1."),
+		instrument.WithUnit(obsmetrics.UnitCollectors),
+		instrument.WithInt64Callback(func(_ context.Context, o instrument.Int64Observer) error {
+			o.Observe(1, withAttrs(attrs)...)
+			return nil
+		}))
+	return err
+}
+
+// instanceIDTags returns the tag mutator and the attribute identifying the collector
+// instance, if instanceID is not empty.
+func instanceIDTags(instanceID string) ([]tag.Mutator, []attribute.KeyValue) {
+	if instanceID == "" {
+		return nil, nil
+	}
+	return []tag.Mutator{tag.Upsert(obsmetrics.TagKeyCollectorInstanceID, instanceID, tag.WithTTL(tag.TTLNoPropagation))},
+		[]attribute.KeyValue{attribute.String(obsmetrics.CollectorInstanceIDKey, instanceID)}
+}
+
+// overheadRecorder records the time spent by obsreport itself in the functions
+// instrumenting the operations of a component, to assess the cost of the instrumentation.
+// It is a diagnostics feature, only enabled when the metrics level is detailed.
+type overheadRecorder struct {
+	created  bool
+	level    *atomicLevel
+	useOtel  bool
+	mutators []tag.Mutator
+	attrs    []attribute.KeyValue
+	counter  instrument.Int64Counter
+}
+
+// newOverheadRecorder creates an overheadRecorder for the given kind of component, the
+// instance tags are the ones returned by instanceIDTags for the component.
+func newOverheadRecorder(
+	kindKey string,
+	level *atomicLevel,
+	naming MetricNaming,
+	meter metric.Meter,
+	useOtel bool,
+	instanceMutators []tag.Mutator,
+	instanceAttrs []attribute.KeyValue,
+) (overheadRecorder, error) {
+	or := overheadRecorder{
+		created:  level.Load() == configtelemetry.LevelDetailed,
+		level:    level,
+		useOtel:  useOtel,
+		mutators: append([]tag.Mutator{tag.Upsert(obsmetrics.TagKeyComponentKind, kindKey, tag.WithTTL(tag.TTLNoPropagation))}, instanceMutators...),
+		attrs:    append([]attribute.KeyValue{attribute.String(obsmetrics.ComponentKindKey, kindKey)}, instanceAttrs...),
+	}
+	if !or.created || !useOtel {
+		return or, nil
+	}
+	var err error
+	or.counter, err = meter.Int64Counter(
+		naming.metricPrefix(obsmetrics.ObsreportKey)+obsmetrics.OverheadKey,
+		instrument.WithDescription("Time spent by obsreport itself in the functions instrumenting the operations of the components."),
+		instrument.WithUnit("ns"),
+	)
+	return or, err
+}
+
+// enabled returns whether the overhead is recorded. The instruments are only created if the
+// metrics level is detailed when the component is created, raising it later does not enable
+// the recorder, while lowering it disables the recorder until the level is detailed again.
+func (or overheadRecorder) enabled() bool {
+	return or.created && or.level.Load() == configtelemetry.LevelDetailed
+}
+
+// record records the time elapsed since start, it is meant to be deferred at the
+// beginning of the instrumenting functions when the recorder is enabled.
+func (or overheadRecorder) record(start time.Time) {
+	overhead := time.Since(start).Nanoseconds()
+	if or.useOtel {
+		or.counter.Add(context.Background(), overhead, withAttrs(or.attrs)...)
+	} else {
+		_ = stats.RecordWithTags(context.Background(), or.mutators, obsmetrics.ObsreportOverhead.M(overhead))
+	}
+}
+
+// counterGroups records with OpenTelemetry the related counters of a helper for each
+// data type, e.g. the accepted and refused items of a receiver. The counters of an
+// operation are added under a single lock, so the operations recorded concurrently by
+// a helper are applied one after the other instead of interleaving their updates.
+type counterGroups struct {
+	mu       sync.Mutex
+	attrs    []attribute.KeyValue
+	counters map[component.DataType][]instrument.Int64Counter
+}
+
+func newCounterGroups(attrs []attribute.KeyValue, counters map[component.DataType][]instrument.Int64Counter) *counterGroups {
+	return &counterGroups{attrs: attrs, counters: counters}
+}
+
+// add adds the values to the counters of the given data type, in the same order. The
+// context of the operation is not used, so its cancellation does not drop the values.
+func (cg *counterGroups) add(dataType component.DataType, values ...int64) {
+	attrs := withAttrs(cg.attrs)
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+	for i, counter := range cg.counters[dataType] {
+		counter.Add(context.Background(), values[i], attrs...)
 	}
 }
@@ -0,0 +1,224 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+// benchmarkOp runs the operation returned by newOp at the LevelNone, LevelBasic and
+// LevelDetailed metrics levels, with a no-op tracer and with a recording one.
+func benchmarkOp(b *testing.B, id component.ID, newOp func(b *testing.B, tt obsreporttest.TestTelemetry) func(ctx context.Context)) {
+	levels := []configtelemetry.Level{
+		configtelemetry.LevelNone,
+		configtelemetry.LevelBasic,
+		configtelemetry.LevelDetailed,
+	}
+	tracers := []struct {
+		name string
+		// newProvider returns the tracer provider and a function to shut it down.
+		newProvider func() (trace.TracerProvider, func(context.Context) error)
+	}{
+		{
+			name: "NoopTracer",
+			newProvider: func() (trace.TracerProvider, func(context.Context) error) {
+				return trace.NewNoopTracerProvider(), func(context.Context) error { return nil }
+			},
+		},
+		{
+			name: "RecordingTracer",
+			newProvider: func() (trace.TracerProvider, func(context.Context) error) {
+				// No span processor, so the spans are recorded but never stored or exported.
+				tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()))
+				return tp, tp.Shutdown
+			},
+		},
+	}
+
+	for _, level := range levels {
+		for _, tracer := range tracers {
+			b.Run(level.String()+"/"+tracer.name, func(b *testing.B) {
+				tt, err := obsreporttest.SetupTelemetry(id)
+				require.NoError(b, err)
+				b.Cleanup(func() { require.NoError(b, tt.Shutdown(context.Background())) })
+
+				tp, shutdown := tracer.newProvider()
+				b.Cleanup(func() { require.NoError(b, shutdown(context.Background())) })
+				tt.TracerProvider = tp
+				tt.MetricsLevel = level
+
+				op := newOp(b, tt)
+				ctx := context.Background()
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					op(ctx)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkReceiveTracesOp(b *testing.B) {
+	benchmarkOp(b, receiverID, func(b *testing.B, tt obsreporttest.TestTelemetry) func(ctx context.Context) {
+		rec, err := NewReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		})
+		require.NoError(b, err)
+		return func(ctx context.Context) {
+			ctx = rec.StartTracesOp(ctx)
+			rec.EndTracesOp(ctx, format, 10, nil)
+		}
+	})
+}
+
+func BenchmarkReceiveMetricsOp(b *testing.B) {
+	benchmarkOp(b, receiverID, func(b *testing.B, tt obsreporttest.TestTelemetry) func(ctx context.Context) {
+		rec, err := NewReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		})
+		require.NoError(b, err)
+		return func(ctx context.Context) {
+			ctx = rec.StartMetricsOp(ctx)
+			rec.EndMetricsOp(ctx, format, 10, nil)
+		}
+	})
+}
+
+func BenchmarkReceiveLogsOp(b *testing.B) {
+	benchmarkOp(b, receiverID, func(b *testing.B, tt obsreporttest.TestTelemetry) func(ctx context.Context) {
+		rec, err := NewReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		})
+		require.NoError(b, err)
+		return func(ctx context.Context) {
+			ctx = rec.StartLogsOp(ctx)
+			rec.EndLogsOp(ctx, format, 10, nil)
+		}
+	})
+}
+
+func BenchmarkScrapeMetricsOp(b *testing.B) {
+	benchmarkOp(b, receiverID, func(b *testing.B, tt obsreporttest.TestTelemetry) func(ctx context.Context) {
+		scrp, err := NewScraper(ScraperSettings{
+			ReceiverID:             receiverID,
+			Scraper:                scraperID,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		})
+		require.NoError(b, err)
+		return func(ctx context.Context) {
+			ctx = scrp.StartMetricsOp(ctx)
+			scrp.EndMetricsOp(ctx, 10, nil)
+		}
+	})
+}
+
+func BenchmarkExportTracesOp(b *testing.B) {
+	benchmarkOp(b, exporterID, func(b *testing.B, tt obsreporttest.TestTelemetry) func(ctx context.Context) {
+		exp, err := NewExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		})
+		require.NoError(b, err)
+		return func(ctx context.Context) {
+			ctx = exp.StartTracesOp(ctx)
+			exp.EndTracesOp(ctx, 10, nil)
+		}
+	})
+}
+
+func BenchmarkExportMetricsOp(b *testing.B) {
+	benchmarkOp(b, exporterID, func(b *testing.B, tt obsreporttest.TestTelemetry) func(ctx context.Context) {
+		exp, err := NewExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		})
+		require.NoError(b, err)
+		return func(ctx context.Context) {
+			ctx = exp.StartMetricsOp(ctx)
+			exp.EndMetricsOp(ctx, 10, nil)
+		}
+	})
+}
+
+func BenchmarkExportLogsOp(b *testing.B) {
+	benchmarkOp(b, exporterID, func(b *testing.B, tt obsreporttest.TestTelemetry) func(ctx context.Context) {
+		exp, err := NewExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		})
+		require.NoError(b, err)
+		return func(ctx context.Context) {
+			ctx = exp.StartLogsOp(ctx)
+			exp.EndLogsOp(ctx, 10, nil)
+		}
+	})
+}
+
+func BenchmarkExportTracesOpWithCode(b *testing.B) {
+	benchmarkOp(b, exporterID, func(b *testing.B, tt obsreporttest.TestTelemetry) func(ctx context.Context) {
+		exp, err := NewExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		})
+		require.NoError(b, err)
+		return func(ctx context.Context) {
+			ctx = exp.StartTracesOp(ctx)
+			exp.EndTracesOpWithCode(ctx, 10, "503", errFake)
+		}
+	})
+}
+
+// BenchmarkTaggedAttrs compares building the attributes and tag mutators of a recording
+// tagged with an extra value on every call with reusing the interned ones.
+func BenchmarkTaggedAttrs(b *testing.B) {
+	attrs := []attribute.KeyValue{attribute.String(obsmetrics.ExporterKey, exporterID.String())}
+	mutators := []tag.Mutator{tag.Upsert(obsmetrics.TagKeyExporter, exporterID.String(), tag.WithTTL(tag.TTLNoPropagation))}
+
+	b.Run("Built", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = withAttrs(attrs, attribute.String(obsmetrics.StateKey, ConnectionStateReady))
+			_ = append([]tag.Mutator{tag.Upsert(obsmetrics.TagKeyState, ConnectionStateReady, tag.WithTTL(tag.TTLNoPropagation))}, mutators...)
+		}
+	})
+
+	b.Run("Interned", func(b *testing.B) {
+		ai := newAttrsInterner(attrs, mutators)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = ai.with(obsmetrics.TagKeyState, ConnectionStateReady)
+		}
+	})
+}
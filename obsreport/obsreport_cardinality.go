@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreport // import "go.opentelemetry.io/collector/obsreport"
+
+import (
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// hllPrecision is the number of bits of the hashes used to select a register of the
+// HyperLogLog estimator. With 2^12 registers the standard error is about 1.6%.
+const hllPrecision = 12
+
+// hyperLogLog estimates the number of distinct values added to it in constant memory,
+// see "HyperLogLog: the analysis of a near-optimal cardinality estimation algorithm"
+// by Flajolet et al. It is not safe for concurrent use.
+type hyperLogLog struct {
+	registers [1 << hllPrecision]uint8
+}
+
+// add adds the value with the given 64 bits hash to the estimator and reports whether
+// the estimate changed.
+func (h *hyperLogLog) add(hash uint64) bool {
+	idx := hash >> (64 - hllPrecision)
+	// The rank is the position of the leftmost 1 bit in the remaining bits, the
+	// sentinel bit bounds it when all of them are 0.
+	rank := uint8(bits.LeadingZeros64(hash<<hllPrecision|1<<(hllPrecision-1)) + 1)
+	if rank <= h.registers[idx] {
+		return false
+	}
+	h.registers[idx] = rank
+	return true
+}
+
+// estimate returns the estimated number of distinct values added since the last reset.
+func (h *hyperLogLog) estimate() int64 {
+	const m = float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+	est := 0.7213 / (1 + 1.079/m) * m * m / sum
+	// Use linear counting for the small cardinalities, where the raw estimate is biased.
+	if est <= 2.5*m && zeros > 0 {
+		est = m * math.Log(m/float64(zeros))
+	}
+	return int64(math.Round(est))
+}
+
+// reset forgets all the values added to the estimator.
+func (h *hyperLogLog) reset() {
+	h.registers = [1 << hllPrecision]uint8{}
+}
+
+// windowedEstimator estimates the number of distinct values added to it over windows of
+// the given period, starting over at the beginning of each window. It is safe for concurrent use.
+type windowedEstimator struct {
+	window time.Duration
+
+	mu        sync.Mutex
+	hll       hyperLogLog
+	windowEnd time.Time
+	last      int64
+}
+
+func newWindowedEstimator(window time.Duration) *windowedEstimator {
+	return &windowedEstimator{window: window}
+}
+
+// add adds the value with the given hash at the given time. If the estimate changed, record
+// is called with the new estimate and its difference with the previous one. It is called
+// while holding the lock, so the estimates are recorded in order.
+func (we *windowedEstimator) add(now time.Time, hash uint64, record func(estimate, delta int64)) {
+	we.mu.Lock()
+	defer we.mu.Unlock()
+	if !now.Before(we.windowEnd) {
+		we.hll.reset()
+		we.windowEnd = now.Add(we.window)
+	}
+	if !we.hll.add(hash) {
+		return
+	}
+	estimate := we.hll.estimate()
+	if estimate == we.last {
+		return
+	}
+	record(estimate, estimate-we.last)
+	we.last = estimate
+}
+
+// hashTraceID returns a 64 bits hash of the trace ID.
+func hashTraceID(id pcommon.TraceID) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(id[:])
+	return mix64(h.Sum64())
+}
+
+// hashAttributes returns a 64 bits hash of the attributes which does not depend on
+// their order, so that the same set of attributes always has the same hash.
+func hashAttributes(attrs pcommon.Map) uint64 {
+	var sum uint64
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(k))
+		_, _ = h.Write([]byte{0, byte(v.Type())})
+		_, _ = h.Write([]byte(v.AsString()))
+		sum += mix64(h.Sum64())
+		return true
+	})
+	return mix64(sum)
+}
+
+// mix64 is the finalizer of SplitMix64, it spreads the entropy of the FNV hashes
+// over all the bits, which the estimator needs to select the registers.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,550 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreport // import "go.opentelemetry.io/collector/obsreport"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// Component is a helper to add observability to a component of any kind supported
+// by obsreport. It is built by NewComponent, which creates the typed helper of the
+// given kind, so that all of them are created with consistent settings.
+// Only the accessors appropriate to the kind return a non-nil helper.
+type Component struct {
+	kind      component.Kind
+	receiver  *Receiver
+	processor *Processor
+	exporter  *Exporter
+	connector *Connector
+
+	level        configtelemetry.Level
+	metricPrefix string
+	ocPrefix     string
+	tagKey       tag.Key
+	mutators     []tag.Mutator
+	meter        metric.Meter
+	logger       *zap.Logger
+
+	useOtelForMetrics bool
+	otelAttrs         []attribute.KeyValue
+
+	// counters holds the counters declared in the ComponentSettings by name, they are
+	// created with the Component and never modified after.
+	counters map[string]*namedCounter
+	// shutdownOnce guards the unregistration of the views of the counters.
+	shutdownOnce sync.Once
+
+	saturationMeasure *stats.Float64Measure
+	saturationMu      sync.Mutex
+	// saturation holds the last value reported by RecordSaturation, observed by
+	// saturationGauge, it is negative until the first one is reported.
+	saturation      float64
+	saturationGauge instrument.Float64ObservableGauge
+
+	concurrencyMeasure *stats.Int64Measure
+	concurrencyMu      sync.Mutex
+	// concurrency holds the last value reported by RecordConcurrency, observed by
+	// concurrencyGauge, it is negative until the first one is reported.
+	concurrency      int64
+	concurrencyGauge instrument.Int64ObservableGauge
+}
+
+// namedCounter is a counter recorded with Component.RecordCounter. Either its instrument,
+// or its measure and view, are set, depending on the backend used to record the metrics.
+type namedCounter struct {
+	otelCounter instrument.Int64Counter
+	ocMeasure   *stats.Int64Measure
+	ocView      *view.View
+	// tagKeys holds the OpenCensus tag keys of the attributes the counter is recorded
+	// with, the other attributes are ignored.
+	tagKeys map[attribute.Key]tag.Key
+}
+
+// CounterSettings declares a counter of a component, recorded with Component.RecordCounter.
+type CounterSettings struct {
+	// Name of the counter, it is reported as "<kind>/<name>", e.g. "receiver/dropped_connections".
+	Name string
+	// Description of the counter.
+	Description string
+	// Unit of the counter, following the UCUM conventions, e.g. "{connections}" or "By".
+	// It is required.
+	Unit string
+	// AttributeKeys are the keys of the attributes the counter is recorded with, in addition
+	// to the component tags. The other attributes passed to RecordCounter are ignored.
+	AttributeKeys []attribute.Key
+}
+
+var (
+	counterViewsMu sync.Mutex
+	// counterViews counts the components using each OpenCensus view registered for the
+	// counters declared in their ComponentSettings, by name, so that a view is only
+	// unregistered when the last of them is shut down.
+	counterViews = map[string]int{}
+)
+
+// ComponentSettings are settings for creating a Component.
+type ComponentSettings struct {
+	TelemetrySettings component.TelemetrySettings
+	BuildInfo         component.BuildInfo
+	// Transport is only used by receivers, see ReceiverSettings.
+	Transport string
+	// StatusMapper is used to set the status of the operation spans from the
+	// returned errors. If nil, any error sets the status to codes.Error.
+	// It is not used by processors.
+	StatusMapper StatusMapper
+	// SignalLevels overrides the metrics level of the TelemetrySettings for individual signals.
+	// It is not used by processors.
+	SignalLevels SignalLevels
+	// MetricNaming is the strategy used to build the metric names, defaults to MetricNamingDefault.
+	MetricNaming MetricNaming
+	// InstanceID is added as the collector.instance.id tag to all the metrics, see ReceiverSettings.
+	InstanceID string
+	// SpanMinDuration only keeps the spans of the slow or failed operations, see ReceiverSettings.
+	// It is not used by processors.
+	SpanMinDuration time.Duration
+	// Recorder records the item counters of the operations, see ReceiverSettings.
+	Recorder Recorder
+	// Counters declares the one-off counters of the component, recorded with RecordCounter.
+	Counters []CounterSettings
+}
+
+// NewComponent creates a new Component for the component of the given kind and ID.
+// The kind must be a receiver, processor, exporter or connector.
+func NewComponent(kind component.Kind, id component.ID, set ComponentSettings) (*Component, error) {
+	return newComponent(kind, id, set, obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled())
+}
+
+func newComponent(kind component.Kind, id component.ID, set ComponentSettings, useOtel bool) (*Component, error) {
+	c := &Component{
+		kind:     kind,
+		level:    set.TelemetrySettings.MetricsLevel,
+		logger:   set.TelemetrySettings.Logger,
+		counters: make(map[string]*namedCounter, len(set.Counters)),
+
+		saturation:  -1,
+		concurrency: -1,
+
+		useOtelForMetrics: useOtel,
+	}
+	var err error
+	switch kind {
+	case component.KindReceiver:
+		c.receiver, err = newReceiver(ReceiverSettings{
+			ReceiverID: id,
+			Transport:  set.Transport,
+			ReceiverCreateSettings: receiver.CreateSettings{
+				ID:                id,
+				TelemetrySettings: set.TelemetrySettings,
+				BuildInfo:         set.BuildInfo,
+			},
+			StatusMapper:    set.StatusMapper,
+			SignalLevels:    set.SignalLevels,
+			MetricNaming:    set.MetricNaming,
+			InstanceID:      set.InstanceID,
+			SpanMinDuration: set.SpanMinDuration,
+			Recorder:        set.Recorder,
+		}, useOtel)
+	case component.KindProcessor:
+		c.processor, err = newProcessor(ProcessorSettings{
+			ProcessorID: id,
+			ProcessorCreateSettings: processor.CreateSettings{
+				ID:                id,
+				TelemetrySettings: set.TelemetrySettings,
+				BuildInfo:         set.BuildInfo,
+			},
+			MetricNaming: set.MetricNaming,
+			InstanceID:   set.InstanceID,
+			Recorder:     set.Recorder,
+		}, useOtel)
+	case component.KindExporter:
+		c.exporter, err = newExporter(ExporterSettings{
+			ExporterID: id,
+			ExporterCreateSettings: exporter.CreateSettings{
+				ID:                id,
+				TelemetrySettings: set.TelemetrySettings,
+				BuildInfo:         set.BuildInfo,
+			},
+			StatusMapper:    set.StatusMapper,
+			SignalLevels:    set.SignalLevels,
+			MetricNaming:    set.MetricNaming,
+			InstanceID:      set.InstanceID,
+			SpanMinDuration: set.SpanMinDuration,
+			Recorder:        set.Recorder,
+		}, useOtel)
+	case component.KindConnector:
+		c.connector, err = newConnector(ConnectorSettings{
+			ConnectorID: id,
+			ConnectorCreateSettings: connector.CreateSettings{
+				ID:                id,
+				TelemetrySettings: set.TelemetrySettings,
+				BuildInfo:         set.BuildInfo,
+			},
+			StatusMapper:    set.StatusMapper,
+			SignalLevels:    set.SignalLevels,
+			MetricNaming:    set.MetricNaming,
+			InstanceID:      set.InstanceID,
+			SpanMinDuration: set.SpanMinDuration,
+			Recorder:        set.Recorder,
+		}, useOtel)
+	default:
+		return nil, fmt.Errorf("obsreport does not support components of kind %d", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key, tagKey, scope := componentKindTags(kind)
+	c.saturationMeasure = componentSaturationMeasure(kind)
+	c.concurrencyMeasure = componentConcurrencyMeasure(kind)
+	c.tagKey = tagKey
+	c.metricPrefix = set.MetricNaming.metricPrefix(key)
+	c.ocPrefix = key + nameSep
+	c.meter = set.TelemetrySettings.MeterProvider.Meter(scope)
+	c.mutators = []tag.Mutator{tag.Upsert(c.tagKey, id.String(), tag.WithTTL(tag.TTLNoPropagation))}
+	c.otelAttrs = []attribute.KeyValue{attribute.String(key, id.String())}
+	instanceMutators, instanceAttrs := instanceIDTags(set.InstanceID)
+	c.mutators = append(c.mutators, instanceMutators...)
+	c.otelAttrs = append(c.otelAttrs, instanceAttrs...)
+	if err = c.createOtelMetrics(); err != nil {
+		return nil, err
+	}
+	if err = c.createCounters(set.Counters, len(instanceMutators) > 0); err != nil {
+		return nil, multierr.Append(err, c.Shutdown(context.Background()))
+	}
+	return c, nil
+}
+
+// createCounters creates the instruments, or registers the OpenCensus views, of the
+// given counters, the views are tagged with the collector instance if includeInstanceID.
+func (c *Component) createCounters(counters []CounterSettings, includeInstanceID bool) error {
+	for _, cs := range counters {
+		if cs.Name == "" {
+			return errors.New("the counters of a component must have a name")
+		}
+		if cs.Unit == "" {
+			return fmt.Errorf("counter %q must have a unit", cs.Name)
+		}
+		if _, ok := c.counters[cs.Name]; ok {
+			return fmt.Errorf("counter %q is declared more than once", cs.Name)
+		}
+		description := cs.Description
+		if description == "" {
+			description = fmt.Sprintf("Counter %q recorded by the component.", cs.Name)
+		}
+
+		counter := &namedCounter{tagKeys: make(map[attribute.Key]tag.Key, len(cs.AttributeKeys))}
+		viewTagKeys := []tag.Key{c.tagKey}
+		if includeInstanceID {
+			viewTagKeys = append(viewTagKeys, obsmetrics.TagKeyCollectorInstanceID)
+		}
+		for _, key := range cs.AttributeKeys {
+			tagKey, err := tag.NewKey(string(key))
+			if err != nil {
+				return fmt.Errorf("invalid attribute key %q of counter %q: %w", key, cs.Name, err)
+			}
+			counter.tagKeys[key] = tagKey
+			viewTagKeys = append(viewTagKeys, tagKey)
+		}
+
+		if c.useOtelForMetrics {
+			otelCounter, err := c.meter.Int64Counter(
+				c.metricPrefix+cs.Name,
+				instrument.WithDescription(description),
+				instrument.WithUnit(cs.Unit))
+			if err != nil {
+				return err
+			}
+			counter.otelCounter = otelCounter
+			c.counters[cs.Name] = counter
+			continue
+		}
+
+		measure := stats.Int64(c.ocPrefix+cs.Name, description, cs.Unit)
+		v := &view.View{
+			Name:        measure.Name(),
+			Description: measure.Description(),
+			TagKeys:     viewTagKeys,
+			Measure:     measure,
+			Aggregation: view.Sum(),
+		}
+		if err := registerCounterView(v); err != nil {
+			return fmt.Errorf("failed to register the view of counter %q: %w", cs.Name, err)
+		}
+		counter.ocMeasure = measure
+		counter.ocView = v
+		c.counters[cs.Name] = counter
+	}
+	return nil
+}
+
+// registerCounterView registers the view of a counter, unless another component already
+// registered the same one. It fails if the view registered for the name is different.
+func registerCounterView(v *view.View) error {
+	counterViewsMu.Lock()
+	defer counterViewsMu.Unlock()
+	if err := view.Register(v); err != nil {
+		return err
+	}
+	counterViews[v.Name]++
+	return nil
+}
+
+// unregisterCounterView unregisters the view of a counter once no component uses it.
+func unregisterCounterView(v *view.View) {
+	counterViewsMu.Lock()
+	defer counterViewsMu.Unlock()
+	counterViews[v.Name]--
+	if counterViews[v.Name] > 0 {
+		return
+	}
+	delete(counterViews, v.Name)
+	view.Unregister(v)
+}
+
+func (c *Component) createOtelMetrics() error {
+	if !c.useOtelForMetrics {
+		return nil
+	}
+	var errors, err error
+	c.saturationGauge, err = c.meter.Float64ObservableGauge(
+		c.metricPrefix+obsmetrics.SaturationKey,
+		instrument.WithDescription(c.saturationMeasure.Description()),
+		instrument.WithUnit(obsmetrics.UnitRatio),
+		instrument.WithFloat64Callback(func(_ context.Context, o instrument.Float64Observer) error {
+			c.saturationMu.Lock()
+			defer c.saturationMu.Unlock()
+			if c.saturation >= 0 {
+				o.Observe(c.saturation, withAttrs(c.otelAttrs)...)
+			}
+			return nil
+		}))
+	errors = multierr.Append(errors, err)
+
+	c.concurrencyGauge, err = c.meter.Int64ObservableGauge(
+		c.metricPrefix+obsmetrics.ConcurrencyKey,
+		instrument.WithDescription(c.concurrencyMeasure.Description()),
+		instrument.WithUnit(obsmetrics.UnitWorkers),
+		instrument.WithInt64Callback(func(_ context.Context, o instrument.Int64Observer) error {
+			c.concurrencyMu.Lock()
+			defer c.concurrencyMu.Unlock()
+			if c.concurrency >= 0 {
+				o.Observe(c.concurrency, withAttrs(c.otelAttrs)...)
+			}
+			return nil
+		}))
+	errors = multierr.Append(errors, err)
+	return errors
+}
+
+// componentKindTags returns the metric key, tag key and meter scope of the given
+// kind of component, which must be supported by NewComponent.
+func componentKindTags(kind component.Kind) (string, tag.Key, string) {
+	switch kind {
+	case component.KindReceiver:
+		return obsmetrics.ReceiverKey, obsmetrics.TagKeyReceiver, receiverScope
+	case component.KindProcessor:
+		return obsmetrics.ProcessorKey, obsmetrics.TagKeyProcessor, processorScope
+	case component.KindExporter:
+		return obsmetrics.ExporterKey, obsmetrics.TagKeyExporter, exporterScope
+	default:
+		return obsmetrics.ConnectorKey, obsmetrics.TagKeyConnector, connectorScope
+	}
+}
+
+// componentSaturationMeasure returns the saturation measure of the given kind of
+// component, which must be supported by NewComponent.
+func componentSaturationMeasure(kind component.Kind) *stats.Float64Measure {
+	switch kind {
+	case component.KindReceiver:
+		return obsmetrics.ReceiverSaturation
+	case component.KindProcessor:
+		return obsmetrics.ProcessorSaturation
+	case component.KindExporter:
+		return obsmetrics.ExporterSaturation
+	default:
+		return obsmetrics.ConnectorSaturation
+	}
+}
+
+// componentConcurrencyMeasure returns the concurrency measure of the given kind of
+// component, which must be supported by NewComponent.
+func componentConcurrencyMeasure(kind component.Kind) *stats.Int64Measure {
+	switch kind {
+	case component.KindReceiver:
+		return obsmetrics.ReceiverConcurrency
+	case component.KindProcessor:
+		return obsmetrics.ProcessorConcurrency
+	case component.KindExporter:
+		return obsmetrics.ExporterConcurrency
+	default:
+		return obsmetrics.ConnectorConcurrency
+	}
+}
+
+// Kind returns the kind of the component.
+func (c *Component) Kind() component.Kind {
+	return c.kind
+}
+
+// Receiver returns the helper used to report the data received by a receiver, or
+// emitted by a connector into its output pipelines. It is nil for other kinds.
+func (c *Component) Receiver() *Receiver {
+	if c.connector != nil {
+		return c.connector.Receiver()
+	}
+	return c.receiver
+}
+
+// Processor returns the helper used by a processor, it is nil for other kinds.
+func (c *Component) Processor() *Processor {
+	return c.processor
+}
+
+// Exporter returns the helper used to report the data sent by an exporter, or
+// consumed by a connector from its input pipelines. It is nil for other kinds.
+func (c *Component) Exporter() *Exporter {
+	if c.connector != nil {
+		return c.connector.Exporter()
+	}
+	return c.exporter
+}
+
+// Connector returns the helper used by a connector, it is nil for other kinds.
+func (c *Component) Connector() *Connector {
+	return c.connector
+}
+
+// RecordCounter adds the value to the counter with the given name, for the one-off
+// counters of a component which are not covered by the other obsreport functions.
+// The counter must be declared in the Counters of the ComponentSettings, it is reported
+// with the same component tags as the other metrics and the given attributes whose keys
+// were declared with it. The counters which were not declared are ignored.
+func (c *Component) RecordCounter(ctx context.Context, name string, value int64, attrs ...attribute.KeyValue) {
+	if c.level == configtelemetry.LevelNone {
+		return
+	}
+	counter, ok := c.counters[name]
+	if !ok {
+		c.logger.Debug("Ignoring undeclared counter", zap.String("name", name))
+		return
+	}
+	if c.useOtelForMetrics {
+		otelAttrs := withAttrs(c.otelAttrs)
+		for _, attr := range attrs {
+			if _, ok := counter.tagKeys[attr.Key]; ok {
+				otelAttrs = append(otelAttrs, attr)
+			}
+		}
+		counter.otelCounter.Add(ctx, value, otelAttrs...)
+		return
+	}
+	mutators := c.mutators[:len(c.mutators):len(c.mutators)]
+	for _, attr := range attrs {
+		if key, ok := counter.tagKeys[attr.Key]; ok {
+			mutators = append(mutators, tag.Upsert(key, attr.Value.Emit(), tag.WithTTL(tag.TTLNoPropagation)))
+		}
+	}
+	_ = stats.RecordWithTags(ctx, mutators, counter.ocMeasure.M(value))
+}
+
+// Shutdown unregisters the OpenCensus views of the counters declared in the ComponentSettings,
+// unless other components still use them. It should be called when the component shuts down,
+// the counters are not reported anymore after. It can be called more than once.
+func (c *Component) Shutdown(context.Context) error {
+	c.shutdownOnce.Do(func() {
+		for _, counter := range c.counters {
+			if counter.ocView != nil {
+				unregisterCounterView(counter.ocView)
+			}
+		}
+	})
+	return nil
+}
+
+// RecordSaturation reports how saturated the component is, from 0 when it is idle to 1
+// when it reached its limits, which is set as the "<kind>/saturation" gauge, e.g.
+// "exporter/saturation". The component should combine its own indicators, e.g. the
+// number of requests in flight and the depth of its queue relative to their limits,
+// into this single value, so that dashboards can compare the health of any component.
+// It should be called periodically or whenever the value changes. Values outside of
+// [0, 1] are clamped to the nearest bound, and NaN is ignored.
+func (c *Component) RecordSaturation(ctx context.Context, value float64) {
+	if c.level == configtelemetry.LevelNone {
+		return
+	}
+	switch {
+	case math.IsNaN(value):
+		c.logger.Debug("Ignoring invalid saturation", zap.Float64(obsmetrics.SaturationKey, value))
+		return
+	case value < 0:
+		value = 0
+	case value > 1:
+		value = 1
+	}
+
+	if c.useOtelForMetrics {
+		c.saturationMu.Lock()
+		c.saturation = value
+		c.saturationMu.Unlock()
+		return
+	}
+	_ = stats.RecordWithTags(ctx, c.mutators, c.saturationMeasure.M(value))
+}
+
+// RecordConcurrency reports the number of workers the component is configured to run
+// concurrently, e.g. the consumers of the sending queue of an exporter, which is set as the
+// "<kind>/concurrency" gauge, e.g. "exporter/concurrency". It lets operators correlate the
+// saturation of the component with its configured parallelism. It should be called when
+// the component starts and whenever its configuration changes. Negative values are ignored.
+func (c *Component) RecordConcurrency(ctx context.Context, n int) {
+	if c.level == configtelemetry.LevelNone {
+		return
+	}
+	if n < 0 {
+		c.logger.Debug("Ignoring invalid concurrency", zap.Int(obsmetrics.ConcurrencyKey, n))
+		return
+	}
+
+	if c.useOtelForMetrics {
+		c.concurrencyMu.Lock()
+		c.concurrency = int64(n)
+		c.concurrencyMu.Unlock()
+		return
+	}
+	_ = stats.RecordWithTags(ctx, c.mutators, c.concurrencyMeasure.M(int64(n)))
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreport // import "go.opentelemetry.io/collector/obsreport"
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+const (
+	connectorName = "connector"
+
+	connectorScope = scopeName + nameSep + connectorName
+)
+
+// Connector is a helper to add observability to a connector.Connector.
+// A connector consumes data as an exporter of its input pipelines and emits
+// data as a receiver of its output pipelines, both sides report their metrics
+// and spans under the "connector" prefix instead of the receiver or exporter ones.
+type Connector struct {
+	receiver *Receiver
+	exporter *Exporter
+
+	reroutedSpansCounter        instrument.Int64Counter
+	reroutedMetricPointsCounter instrument.Int64Counter
+	reroutedLogRecordsCounter   instrument.Int64Counter
+}
+
+// ConnectorSettings are settings for creating a Connector.
+type ConnectorSettings struct {
+	ConnectorID             component.ID
+	ConnectorCreateSettings connector.CreateSettings
+	// StatusMapper is used to set the status of the operation spans from the
+	// returned errors. If nil, any error sets the status to codes.Error.
+	StatusMapper StatusMapper
+	// SignalLevels overrides the metrics level of the ConnectorCreateSettings for individual signals.
+	SignalLevels SignalLevels
+	// MetricNaming is the strategy used to build the metric names, defaults to MetricNamingDefault.
+	MetricNaming MetricNaming
+	// InstanceID is added as the collector.instance.id tag to all the metrics, see ReceiverSettings.
+	InstanceID string
+	// SpanMinDuration only keeps the spans of the slow or failed operations, see ReceiverSettings.
+	SpanMinDuration time.Duration
+	// Recorder records the item counters of the operations, see ReceiverSettings.
+	Recorder Recorder
+}
+
+// NewConnector creates a new Connector.
+func NewConnector(cfg ConnectorSettings) (*Connector, error) {
+	return newConnector(cfg, obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled())
+}
+
+func newConnector(cfg ConnectorSettings, useOtel bool) (*Connector, error) {
+	rec, err := newReceiverForKind(component.KindConnector, ReceiverSettings{
+		ReceiverID: cfg.ConnectorID,
+		ReceiverCreateSettings: receiver.CreateSettings{
+			ID:                cfg.ConnectorCreateSettings.ID,
+			TelemetrySettings: cfg.ConnectorCreateSettings.TelemetrySettings,
+			BuildInfo:         cfg.ConnectorCreateSettings.BuildInfo,
+		},
+		StatusMapper:    cfg.StatusMapper,
+		SignalLevels:    cfg.SignalLevels,
+		MetricNaming:    cfg.MetricNaming,
+		InstanceID:      cfg.InstanceID,
+		SpanMinDuration: cfg.SpanMinDuration,
+		Recorder:        cfg.Recorder,
+	}, useOtel)
+	if err != nil {
+		return nil, err
+	}
+
+	exp, err := newExporterForKind(component.KindConnector, ExporterSettings{
+		ExporterID: cfg.ConnectorID,
+		ExporterCreateSettings: exporter.CreateSettings{
+			ID:                cfg.ConnectorCreateSettings.ID,
+			TelemetrySettings: cfg.ConnectorCreateSettings.TelemetrySettings,
+			BuildInfo:         cfg.ConnectorCreateSettings.BuildInfo,
+		},
+		StatusMapper:    cfg.StatusMapper,
+		SignalLevels:    cfg.SignalLevels,
+		MetricNaming:    cfg.MetricNaming,
+		InstanceID:      cfg.InstanceID,
+		SpanMinDuration: cfg.SpanMinDuration,
+		Recorder:        cfg.Recorder,
+	}, useOtel)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &Connector{receiver: rec, exporter: exp}
+	if err = conn.createOtelMetrics(); err != nil {
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (c *Connector) createOtelMetrics() error {
+	if !c.exporter.useOtelForMetrics {
+		return nil
+	}
+	// The metrics of the connector itself are reported like the ones of its exporter side.
+	meter, metricPrefix := c.exporter.meter, c.exporter.metricPrefix
+
+	var errors, err error
+
+	c.reroutedSpansCounter, err = meter.Int64Counter(
+		metricPrefix+obsmetrics.ReroutedSpansKey,
+		instrument.WithDescription("Number of spans rerouted to a fallback pipeline after failing to be routed to another."),
+		instrument.WithUnit(obsmetrics.UnitSpans))
+	errors = multierr.Append(errors, err)
+
+	c.reroutedMetricPointsCounter, err = meter.Int64Counter(
+		metricPrefix+obsmetrics.ReroutedMetricPointsKey,
+		instrument.WithDescription("Number of metric points rerouted to a fallback pipeline after failing to be routed to another."),
+		instrument.WithUnit(obsmetrics.UnitMetricPoints))
+	errors = multierr.Append(errors, err)
+
+	c.reroutedLogRecordsCounter, err = meter.Int64Counter(
+		metricPrefix+obsmetrics.ReroutedLogRecordsKey,
+		instrument.WithDescription("Number of log records rerouted to a fallback pipeline after failing to be routed to another."),
+		instrument.WithUnit(obsmetrics.UnitLogRecords))
+	errors = multierr.Append(errors, err)
+
+	return errors
+}
+
+// Receiver returns the helper used to report the data emitted by the connector
+// into its output pipelines.
+func (c *Connector) Receiver() *Receiver {
+	return c.receiver
+}
+
+// Exporter returns the helper used to report the data consumed by the connector
+// from its input pipelines.
+func (c *Connector) Exporter() *Exporter {
+	return c.exporter
+}
+
+// RecordReroute reports that a routing connector sent the given number of items to the
+// toPipeline fallback pipeline after failing to route them to the fromPipeline one. The
+// pipelines must be identified by their IDs in the configuration, e.g. "traces/backup".
+// Any signal other than traces, metrics or logs is ignored.
+func (c *Connector) RecordReroute(ctx context.Context, signal component.DataType, numItems int, fromPipeline, toPipeline string) {
+	exp := c.exporter
+	if exp.signalLevels.levelFor(signal, exp.level.Load()) == configtelemetry.LevelNone {
+		return
+	}
+
+	var counter instrument.Int64Counter
+	var measure *stats.Int64Measure
+	switch signal {
+	case component.DataTypeTraces:
+		counter, measure = c.reroutedSpansCounter, obsmetrics.ConnectorReroutedSpans
+	case component.DataTypeMetrics:
+		counter, measure = c.reroutedMetricPointsCounter, obsmetrics.ConnectorReroutedMetricPoints
+	case component.DataTypeLogs:
+		counter, measure = c.reroutedLogRecordsCounter, obsmetrics.ConnectorReroutedLogRecords
+	default:
+		return
+	}
+	if exp.useOtelForMetrics {
+		counter.Add(ctx, int64(numItems), withAttrs(exp.otelAttrs,
+			attribute.String(obsmetrics.FromPipelineKey, fromPipeline),
+			attribute.String(obsmetrics.ToPipelineKey, toPipeline))...)
+		return
+	}
+	mutators := append(exp.mutators[:len(exp.mutators):len(exp.mutators)],
+		tag.Upsert(obsmetrics.TagKeyFromPipeline, fromPipeline, tag.WithTTL(tag.TTLNoPropagation)),
+		tag.Upsert(obsmetrics.TagKeyToPipeline, toPipeline, tag.WithTTL(tag.TTLNoPropagation)))
+	_ = stats.RecordWithTags(ctx, mutators, measure.M(int64(numItems)))
+}
@@ -16,10 +16,14 @@ package obsreport // import "go.opentelemetry.io/collector/obsreport"
 
 import (
 	"context"
+	"strconv"
+	"sync"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/instrument"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/multierr"
@@ -36,30 +40,277 @@ const (
 	exporterName = "exporter"
 
 	exporterScope = scopeName + nameSep + exporterName
+
+	// chunkEventName is the name of the span events added by AddChunkEvent.
+	chunkEventName = "ExportChunk"
+	// chunkIndexKey and chunkItemsKey are the attributes of the events added by
+	// AddChunkEvent, the position of the chunk in the batch and its number of items.
+	chunkIndexKey = "chunk.index"
+	chunkItemsKey = "chunk.items"
+
+	// queueWaitKey and sendTimeKey are the attributes of the spans of the operations started
+	// with StartTracesOpQueued, the time in milliseconds the data waited in the queue and the
+	// time spent sending it.
+	queueWaitKey = "queue.wait_ms"
+	sendTimeKey  = "send.duration_ms"
+)
+
+// opChunksKey is the context key for the chunks of an export operation started with
+// StartTracesOpWithChunkEvents.
+type opChunksKey struct{}
+
+// opQueuedKey is the context key for the time an export operation started with
+// StartTracesOpQueued started sending the data.
+type opQueuedKey struct{}
+
+// opChunks counts the chunks an export operation was split into.
+type opChunks struct {
+	next int
+}
+
+// Connection states reported by RecordConnectionState, which mirror the gRPC connectivity states.
+const (
+	// ConnectionStateIdle is used when the exporter is not connected and not trying to connect.
+	ConnectionStateIdle = "idle"
+	// ConnectionStateConnecting is used while the exporter is establishing the connection.
+	ConnectionStateConnecting = "connecting"
+	// ConnectionStateReady is used when the connection is established and ready to send data.
+	ConnectionStateReady = "ready"
+	// ConnectionStateTransientFailure is used when the connection failed and will be retried.
+	ConnectionStateTransientFailure = "transient_failure"
+	// ConnectionStateShutdown is used when the connection was closed and will not be retried.
+	ConnectionStateShutdown = "shutdown"
 )
 
-// Exporter is a helper to add observability to a component.Exporter.
+// Destinations reported by EndTracesOpToDestination for exporters with failover.
+const (
+	// DestinationPrimary is used when the data was handled by the primary endpoint.
+	DestinationPrimary = "primary"
+	// DestinationSecondary is used when the data was handled by the secondary endpoint.
+	DestinationSecondary = "secondary"
+)
+
+// Outcomes of the export operations used to break down their duration.
+const (
+	// OutcomeSuccess is used for the export operations that completed without error.
+	OutcomeSuccess = "success"
+	// OutcomeFailure is used for the export operations that returned an error.
+	OutcomeFailure = "failure"
+)
+
+// Kinds of the TLS errors reported by RecordTLSError.
+const (
+	// TLSErrorHandshake is used when the TLS handshake with the destination failed.
+	TLSErrorHandshake = "handshake"
+	// TLSErrorCertExpired is used when a certificate of the chain expired or is not valid yet.
+	TLSErrorCertExpired = "cert_expired"
+	// TLSErrorVerify is used when the certificate of the destination could not be verified,
+	// e.g. because it is signed by an unknown authority or does not match the host name.
+	TLSErrorVerify = "verify"
+	// TLSErrorOther is used for any other kind of TLS error.
+	TLSErrorOther = "other"
+)
+
+// Exporter is a helper to add observability to a component.Exporter. When it reports the
+// data consumed by a connector, see Connector.Exporter, the functions about the destination
+// of the data, e.g. RecordConnectionState, are no-ops since connectors do not have one.
 type Exporter struct {
-	level          configtelemetry.Level
-	spanNamePrefix string
-	mutators       []tag.Mutator
-	tracer         trace.Tracer
-	logger         *zap.Logger
+	level           *atomicLevel
+	signalLevels    SignalLevels
+	spanNamePrefix  string
+	metricPrefix    string
+	ocMeasures      exporterMeasures
+	statusMapper    StatusMapper
+	spanMinDuration time.Duration
+	recorder        *fanoutRecorder
+	batchSizes      bool
+	compression     string
+	mutators        []tag.Mutator
+	tracer          trace.Tracer
+	meter           metric.Meter
+	logger          *zap.Logger
 
 	useOtelForMetrics        bool
 	otelAttrs                []attribute.KeyValue
+	interner                 *attrsInterner
 	sentSpans                instrument.Int64Counter
 	failedToSendSpans        instrument.Int64Counter
 	sentMetricPoints         instrument.Int64Counter
 	failedToSendMetricPoints instrument.Int64Counter
 	sentLogRecords           instrument.Int64Counter
 	failedToSendLogRecords   instrument.Int64Counter
+	// itemCounters records the sent and failed to send items together, see counterGroups.
+	itemCounters *counterGroups
+
+	failedToSendSpansByCode instrument.Int64Counter
+
+	sentSpansByDestination         instrument.Int64Counter
+	failedToSendSpansByDestination instrument.Int64Counter
+
+	connectionStateMu                 sync.Mutex
+	connectionState                   string
+	connectionStateUpDownCounter      instrument.Int64UpDownCounter
+	connectionStateTransitionsCounter instrument.Int64Counter
+
+	backpressureMu              sync.Mutex
+	backpressureSince           time.Time
+	backpressureUpDownCounter   instrument.Int64UpDownCounter
+	backpressureDurationCounter instrument.Float64Counter
+
+	sentBatchSizeHistogram instrument.Int64Histogram
+	sendDurationHistogram  instrument.Float64Histogram
+	ackLatencyHistogram    instrument.Float64Histogram
+	queueWait              bool
+	queueWaitHistogram     instrument.Float64Histogram
+	// pipelineLatencyName is the name of pipelineLatencyHistogram, which is not prefixed
+	// by the kind of component. It is empty when RecordPipelineLatency is not enabled.
+	pipelineLatencyName      string
+	pipelineLatencyHistogram instrument.Float64Histogram
+
+	persistentQueueMu                 sync.Mutex
+	persistentQueueItems              int64
+	persistentQueueBytes              int64
+	persistentQueueItemsUpDownCounter instrument.Int64UpDownCounter
+	persistentQueueBytesUpDownCounter instrument.Int64UpDownCounter
+
+	oldestQueuedAgeMu sync.Mutex
+	// oldestQueuedAge holds the last age in milliseconds reported by RecordOldestQueuedAge,
+	// observed by oldestQueuedAgeGauge, it is negative until the first one is reported.
+	oldestQueuedAge      float64
+	oldestQueuedAgeGauge instrument.Float64ObservableGauge
+
+	sendsCounter    instrument.Int64Counter
+	requestsCounter instrument.Int64Counter
+
+	retriesExhaustedSpansCounter        instrument.Int64Counter
+	retriesExhaustedMetricPointsCounter instrument.Int64Counter
+	retriesExhaustedLogRecordsCounter   instrument.Int64Counter
+
+	partialWarningsTracesCounter  instrument.Int64Counter
+	partialWarningsMetricsCounter instrument.Int64Counter
+	partialWarningsLogsCounter    instrument.Int64Counter
+
+	tlsErrorsCounter instrument.Int64Counter
+
+	// now returns the current time, used to measure the time spent under backpressure.
+	now func() time.Time
+
+	overhead overheadRecorder
+}
+
+// exporterMeasures are the OpenCensus measures recorded by an Exporter for each data type.
+type exporterMeasures struct {
+	sentSpans                *stats.Int64Measure
+	failedToSendSpans        *stats.Int64Measure
+	sentMetricPoints         *stats.Int64Measure
+	failedToSendMetricPoints *stats.Int64Measure
+	sentLogRecords           *stats.Int64Measure
+	failedToSendLogRecords   *stats.Int64Measure
+	// failedToSendSpansByCode, the *ByDestination, connectionState*, backpressure*, sendDuration, requests,
+	// ackLatency, queueWait, pipelineLatency, persistentQueue*, oldestQueuedAge, retriesExhausted*,
+	// partialWarnings* and tlsErrors measures are nil for connectors.
+	failedToSendSpansByCode        *stats.Int64Measure
+	sentSpansByDestination         *stats.Int64Measure
+	failedToSendSpansByDestination *stats.Int64Measure
+	connectionState                *stats.Int64Measure
+	connectionStateTransitions     *stats.Int64Measure
+	backpressure                   *stats.Int64Measure
+	backpressureDuration           *stats.Float64Measure
+	sendDuration                   *stats.Float64Measure
+	requests                       *stats.Int64Measure
+	ackLatency                     *stats.Float64Measure
+	queueWait                      *stats.Float64Measure
+	pipelineLatency                *stats.Float64Measure
+	persistentQueueItems           *stats.Int64Measure
+	persistentQueueBytes           *stats.Int64Measure
+	oldestQueuedAge                *stats.Float64Measure
+	retriesExhaustedSpans          *stats.Int64Measure
+	retriesExhaustedMetricPoints   *stats.Int64Measure
+	retriesExhaustedLogRecords     *stats.Int64Measure
+	partialWarningsTraces          *stats.Int64Measure
+	partialWarningsMetrics         *stats.Int64Measure
+	partialWarningsLogs            *stats.Int64Measure
+	tlsErrors                      *stats.Int64Measure
 }
 
+var (
+	exporterKindMeasures = exporterMeasures{
+		sentSpans:                      obsmetrics.ExporterSentSpans,
+		failedToSendSpans:              obsmetrics.ExporterFailedToSendSpans,
+		sentMetricPoints:               obsmetrics.ExporterSentMetricPoints,
+		failedToSendMetricPoints:       obsmetrics.ExporterFailedToSendMetricPoints,
+		sentLogRecords:                 obsmetrics.ExporterSentLogRecords,
+		failedToSendLogRecords:         obsmetrics.ExporterFailedToSendLogRecords,
+		failedToSendSpansByCode:        obsmetrics.ExporterFailedToSendSpansByCode,
+		sentSpansByDestination:         obsmetrics.ExporterSentSpansByDestination,
+		failedToSendSpansByDestination: obsmetrics.ExporterFailedToSendSpansByDestination,
+		connectionState:                obsmetrics.ExporterConnectionState,
+		connectionStateTransitions:     obsmetrics.ExporterConnectionStateTransitions,
+		backpressure:                   obsmetrics.ExporterBackpressure,
+		backpressureDuration:           obsmetrics.ExporterBackpressureDuration,
+		sendDuration:                   obsmetrics.ExporterSendDuration,
+		requests:                       obsmetrics.ExporterRequests,
+		ackLatency:                     obsmetrics.ExporterAckLatency,
+		queueWait:                      obsmetrics.ExporterQueueWait,
+		pipelineLatency:                obsmetrics.PipelineLatency,
+		persistentQueueItems:           obsmetrics.ExporterPersistentQueueItems,
+		persistentQueueBytes:           obsmetrics.ExporterPersistentQueueBytes,
+		oldestQueuedAge:                obsmetrics.ExporterOldestQueuedAge,
+		retriesExhaustedSpans:          obsmetrics.ExporterRetriesExhaustedSpans,
+		retriesExhaustedMetricPoints:   obsmetrics.ExporterRetriesExhaustedMetricPoints,
+		retriesExhaustedLogRecords:     obsmetrics.ExporterRetriesExhaustedLogRecords,
+		partialWarningsTraces:          obsmetrics.ExporterPartialWarningsTraces,
+		partialWarningsMetrics:         obsmetrics.ExporterPartialWarningsMetrics,
+		partialWarningsLogs:            obsmetrics.ExporterPartialWarningsLogs,
+		tlsErrors:                      obsmetrics.ExporterTLSErrors,
+	}
+	connectorKindExporterMeasures = exporterMeasures{
+		sentSpans:                obsmetrics.ConnectorSentSpans,
+		failedToSendSpans:        obsmetrics.ConnectorFailedToSendSpans,
+		sentMetricPoints:         obsmetrics.ConnectorSentMetricPoints,
+		failedToSendMetricPoints: obsmetrics.ConnectorFailedToSendMetricPoints,
+		sentLogRecords:           obsmetrics.ConnectorSentLogRecords,
+		failedToSendLogRecords:   obsmetrics.ConnectorFailedToSendLogRecords,
+	}
+)
+
 // ExporterSettings are settings for creating an Exporter.
 type ExporterSettings struct {
 	ExporterID             component.ID
 	ExporterCreateSettings exporter.CreateSettings
+	// StatusMapper is used to set the status of the operation spans from the
+	// returned errors. If nil, any error sets the status to codes.Error.
+	StatusMapper StatusMapper
+	// SignalLevels overrides the metrics level of the ExporterCreateSettings for individual signals.
+	SignalLevels SignalLevels
+	// MetricNaming is the strategy used to build the metric names, defaults to MetricNamingDefault.
+	MetricNaming MetricNaming
+	// InstanceID is added as the collector.instance.id tag to all the metrics, see ReceiverSettings.
+	InstanceID string
+	// SpanMinDuration, when positive, only keeps the spans of the operations that took at
+	// least this long or failed, to reduce the volume of traces. The spans are deferred
+	// until the operation ends, so the spans started during it are children of its parent.
+	SpanMinDuration time.Duration
+	// Recorder records the item counters of the operations instead of OpenCensus or
+	// OpenTelemetry, see Recorder. If nil, they are recorded like the other metrics.
+	Recorder Recorder
+	// RecordBatchSizes enables recording the distribution of the number of items passed
+	// to the End*Op functions, by signal, to help tuning the batching upstream. It only
+	// has an effect when the metrics level of the signal is detailed.
+	RecordBatchSizes bool
+	// Compression is the compression codec the exporter sends the data with, e.g. "gzip",
+	// "zstd" or "none". When set, the End*Op functions count the export operations in the
+	// sends metric tagged with it, to audit that the configured compression is applied.
+	Compression string
+	// RecordPipelineLatency enables recording, at the end of the successful export operations,
+	// the time since the start of the receive operation of the data, as the pipeline/latency
+	// histogram. It requires the receivers to set RecordPipelineStart, the operations whose
+	// context has no start time are not recorded. It has no effect for connectors.
+	RecordPipelineLatency bool
+	// RecordQueueWait enables recording the time the data waited in the queue of the exporter,
+	// as reported to StartTracesOpQueued, as the queue_wait histogram. The time spent sending
+	// it is recorded by the send_duration histogram. It has no effect for connectors.
+	RecordQueueWait bool
 }
 
 // NewExporter creates a new Exporter.
@@ -68,73 +319,290 @@ func NewExporter(cfg ExporterSettings) (*Exporter, error) {
 }
 
 func newExporter(cfg ExporterSettings, useOtel bool) (*Exporter, error) {
+	return newExporterForKind(component.KindExporter, cfg, useOtel)
+}
+
+// newExporterForKind creates an Exporter reporting under the prefix and tag
+// of the given kind of component, which must be an exporter or a connector.
+func newExporterForKind(kind component.Kind, cfg ExporterSettings, useOtel bool) (*Exporter, error) {
+	key, tagKey, measures, scope := obsmetrics.ExporterKey, obsmetrics.TagKeyExporter, exporterKindMeasures, exporterScope
+	if kind == component.KindConnector {
+		key, tagKey, measures, scope = obsmetrics.ConnectorKey, obsmetrics.TagKeyConnector, connectorKindExporterMeasures, connectorScope
+	}
+
 	exp := &Exporter{
-		level:          cfg.ExporterCreateSettings.TelemetrySettings.MetricsLevel,
-		spanNamePrefix: obsmetrics.ExporterPrefix + cfg.ExporterID.String(),
-		mutators:       []tag.Mutator{tag.Upsert(obsmetrics.TagKeyExporter, cfg.ExporterID.String(), tag.WithTTL(tag.TTLNoPropagation))},
-		tracer:         cfg.ExporterCreateSettings.TracerProvider.Tracer(cfg.ExporterID.String()),
-		logger:         cfg.ExporterCreateSettings.Logger,
+		level:           newAtomicLevel(cfg.ExporterCreateSettings.TelemetrySettings.MetricsLevel),
+		signalLevels:    cfg.SignalLevels,
+		spanNamePrefix:  key + nameSep + cfg.ExporterID.String(),
+		metricPrefix:    cfg.MetricNaming.metricPrefix(key),
+		ocMeasures:      measures,
+		statusMapper:    cfg.StatusMapper,
+		spanMinDuration: cfg.SpanMinDuration,
+		batchSizes:      cfg.RecordBatchSizes,
+		compression:     cfg.Compression,
+		queueWait:       cfg.RecordQueueWait && measures.queueWait != nil,
+		mutators:        []tag.Mutator{tag.Upsert(tagKey, cfg.ExporterID.String(), tag.WithTTL(tag.TTLNoPropagation))},
+		tracer:          cfg.ExporterCreateSettings.TracerProvider.Tracer(cfg.ExporterID.String()),
+		meter:           cfg.ExporterCreateSettings.MeterProvider.Meter(scope),
+		logger:          cfg.ExporterCreateSettings.Logger,
+		now:             time.Now,
+		oldestQueuedAge: -1,
 
 		useOtelForMetrics: useOtel,
 		otelAttrs: []attribute.KeyValue{
-			attribute.String(obsmetrics.ExporterKey, cfg.ExporterID.String()),
+			attribute.String(key, cfg.ExporterID.String()),
 		},
 	}
+	instanceMutators, instanceAttrs := instanceIDTags(cfg.InstanceID)
+	exp.mutators = append(exp.mutators, instanceMutators...)
+	exp.otelAttrs = append(exp.otelAttrs, instanceAttrs...)
+	exp.interner = newAttrsInterner(exp.otelAttrs, exp.mutators)
+	primary := cfg.Recorder
+	if primary == nil {
+		primary = backendRecorder{exporter: exp}
+	}
+	exp.recorder = newFanoutRecorder(primary)
+	if cfg.RecordPipelineLatency && measures.pipelineLatency != nil {
+		exp.pipelineLatencyName = cfg.MetricNaming.metricPrefix(obsmetrics.PipelineKey) + obsmetrics.LatencyKey
+	}
+
+	overhead, err := newOverheadRecorder(key, exp.level, cfg.MetricNaming, exp.meter, useOtel, instanceMutators, instanceAttrs)
+	if err != nil {
+		return nil, err
+	}
+	exp.overhead = overhead
 
-	if err := exp.createOtelMetrics(cfg); err != nil {
+	if err = exp.createOtelMetrics(); err != nil {
 		return nil, err
 	}
 
 	return exp, nil
 }
 
-func (exp *Exporter) createOtelMetrics(cfg ExporterSettings) error {
+// SetLevel changes the metrics level of the exporter while it is running, see Receiver.SetLevel.
+// The overhead recording is not enabled by raising the level later.
+func (exp *Exporter) SetLevel(level configtelemetry.Level) {
+	exp.level.Store(level)
+}
+
+func (exp *Exporter) createOtelMetrics() error {
 	if !exp.useOtelForMetrics {
 		return nil
 	}
-	meter := cfg.ExporterCreateSettings.MeterProvider.Meter(exporterScope)
+	meter := exp.meter
 
 	var errors, err error
 
 	exp.sentSpans, err = meter.Int64Counter(
-		obsmetrics.ExporterPrefix+obsmetrics.SentSpansKey,
+		exp.metricPrefix+obsmetrics.SentSpansKey,
 		instrument.WithDescription("Number of spans successfully sent to destination."),
-		instrument.WithUnit("1"))
+		instrument.WithUnit(obsmetrics.UnitSpans))
 	errors = multierr.Append(errors, err)
 
 	exp.failedToSendSpans, err = meter.Int64Counter(
-		obsmetrics.ExporterPrefix+obsmetrics.FailedToSendSpansKey,
+		exp.metricPrefix+obsmetrics.FailedToSendSpansKey,
 		instrument.WithDescription("Number of spans in failed attempts to send to destination."),
-		instrument.WithUnit("1"))
+		instrument.WithUnit(obsmetrics.UnitSpans))
 	errors = multierr.Append(errors, err)
 
 	exp.sentMetricPoints, err = meter.Int64Counter(
-		obsmetrics.ExporterPrefix+obsmetrics.SentMetricPointsKey,
+		exp.metricPrefix+obsmetrics.SentMetricPointsKey,
 		instrument.WithDescription("Number of metric points successfully sent to destination."),
-		instrument.WithUnit("1"))
+		instrument.WithUnit(obsmetrics.UnitMetricPoints))
 	errors = multierr.Append(errors, err)
 
 	exp.failedToSendMetricPoints, err = meter.Int64Counter(
-		obsmetrics.ExporterPrefix+obsmetrics.FailedToSendMetricPointsKey,
+		exp.metricPrefix+obsmetrics.FailedToSendMetricPointsKey,
 		instrument.WithDescription("Number of metric points in failed attempts to send to destination."),
-		instrument.WithUnit("1"))
+		instrument.WithUnit(obsmetrics.UnitMetricPoints))
 	errors = multierr.Append(errors, err)
 
 	exp.sentLogRecords, err = meter.Int64Counter(
-		obsmetrics.ExporterPrefix+obsmetrics.SentLogRecordsKey,
+		exp.metricPrefix+obsmetrics.SentLogRecordsKey,
 		instrument.WithDescription("Number of log record successfully sent to destination."),
-		instrument.WithUnit("1"))
+		instrument.WithUnit(obsmetrics.UnitLogRecords))
 	errors = multierr.Append(errors, err)
 
 	exp.failedToSendLogRecords, err = meter.Int64Counter(
-		obsmetrics.ExporterPrefix+obsmetrics.FailedToSendLogRecordsKey,
+		exp.metricPrefix+obsmetrics.FailedToSendLogRecordsKey,
 		instrument.WithDescription("Number of log records in failed attempts to send to destination."),
-		instrument.WithUnit("1"))
+		instrument.WithUnit(obsmetrics.UnitLogRecords))
 	errors = multierr.Append(errors, err)
 
+	exp.failedToSendSpansByCode, err = meter.Int64Counter(
+		exp.metricPrefix+obsmetrics.FailedToSendSpansByCodeKey,
+		instrument.WithDescription("Number of spans in failed attempts to send to destination by status code."),
+		instrument.WithUnit(obsmetrics.UnitSpans))
+	errors = multierr.Append(errors, err)
+
+	exp.sentSpansByDestination, err = meter.Int64Counter(
+		exp.metricPrefix+obsmetrics.SentSpansByDestinationKey,
+		instrument.WithDescription("Number of spans successfully sent by destination."),
+		instrument.WithUnit(obsmetrics.UnitSpans))
+	errors = multierr.Append(errors, err)
+
+	exp.failedToSendSpansByDestination, err = meter.Int64Counter(
+		exp.metricPrefix+obsmetrics.FailedToSendSpansByDestinationKey,
+		instrument.WithDescription("Number of spans in failed attempts to send by destination."),
+		instrument.WithUnit(obsmetrics.UnitSpans))
+	errors = multierr.Append(errors, err)
+
+	exp.connectionStateUpDownCounter, err = meter.Int64UpDownCounter(
+		exp.metricPrefix+obsmetrics.ConnectionStateKey,
+		instrument.WithDescription("Whether the connection to the destination is in the given state (1) or not (0)."),
+		instrument.WithUnit(obsmetrics.UnitConnections))
+	errors = multierr.Append(errors, err)
+
+	exp.connectionStateTransitionsCounter, err = meter.Int64Counter(
+		exp.metricPrefix+obsmetrics.ConnectionStateTransitionsKey,
+		instrument.WithDescription("Number of times the connection to the destination changed to the given state."),
+		instrument.WithUnit(obsmetrics.UnitTransitions))
+	errors = multierr.Append(errors, err)
+
+	exp.backpressureUpDownCounter, err = meter.Int64UpDownCounter(
+		exp.metricPrefix+obsmetrics.BackpressureKey,
+		instrument.WithDescription("Whether the destination applies backpressure to the exporter (1) or not (0)."),
+		instrument.WithUnit(obsmetrics.UnitExporters))
+	errors = multierr.Append(errors, err)
+
+	exp.backpressureDurationCounter, err = meter.Float64Counter(
+		exp.metricPrefix+obsmetrics.BackpressureDurationKey,
+		instrument.WithDescription("Time spent by the exporter under backpressure from the destination."),
+		instrument.WithUnit("ms"))
+	errors = multierr.Append(errors, err)
+
+	exp.sentBatchSizeHistogram, err = meter.Int64Histogram(
+		exp.metricPrefix+obsmetrics.SentBatchSizeKey,
+		instrument.WithDescription("Number of items in the batches the exporter attempted to send."),
+		instrument.WithUnit(obsmetrics.UnitItems))
+	errors = multierr.Append(errors, err)
+
+	exp.sendDurationHistogram, err = meter.Float64Histogram(
+		exp.metricPrefix+obsmetrics.SendDurationKey,
+		instrument.WithDescription("Duration of the export operations by outcome."),
+		instrument.WithUnit("ms"))
+	errors = multierr.Append(errors, err)
+
+	if exp.pipelineLatencyName != "" {
+		exp.pipelineLatencyHistogram, err = meter.Float64Histogram(
+			exp.pipelineLatencyName,
+			instrument.WithDescription("Time from the start of the receive operation of the data to the end of its export operation."),
+			instrument.WithUnit("ms"))
+		errors = multierr.Append(errors, err)
+	}
+
+	exp.ackLatencyHistogram, err = meter.Float64Histogram(
+		exp.metricPrefix+obsmetrics.AckLatencyKey,
+		instrument.WithDescription("Time from sending the data until the destination acknowledged it."),
+		instrument.WithUnit("ms"))
+	errors = multierr.Append(errors, err)
+
+	exp.queueWaitHistogram, err = meter.Float64Histogram(
+		exp.metricPrefix+obsmetrics.QueueWaitKey,
+		instrument.WithDescription("Time the data waited in the queue of the exporter before the export operation started."),
+		instrument.WithUnit("ms"))
+	errors = multierr.Append(errors, err)
+
+	exp.persistentQueueItemsUpDownCounter, err = meter.Int64UpDownCounter(
+		exp.metricPrefix+obsmetrics.PersistentQueueItemsKey,
+		instrument.WithDescription("Number of items held in the persistent queue of the exporter."),
+		instrument.WithUnit(obsmetrics.UnitItems))
+	errors = multierr.Append(errors, err)
+
+	exp.persistentQueueBytesUpDownCounter, err = meter.Int64UpDownCounter(
+		exp.metricPrefix+obsmetrics.PersistentQueueBytesKey,
+		instrument.WithDescription("Size in bytes of the persistent queue of the exporter on disk."),
+		instrument.WithUnit("By"))
+	errors = multierr.Append(errors, err)
+
+	exp.oldestQueuedAgeGauge, err = meter.Float64ObservableGauge(
+		exp.metricPrefix+obsmetrics.OldestQueuedAgeKey,
+		instrument.WithDescription("Age of the oldest item in the queue of the exporter."),
+		instrument.WithUnit("ms"),
+		instrument.WithFloat64Callback(func(_ context.Context, o instrument.Float64Observer) error {
+			exp.oldestQueuedAgeMu.Lock()
+			defer exp.oldestQueuedAgeMu.Unlock()
+			if exp.oldestQueuedAge >= 0 {
+				o.Observe(exp.oldestQueuedAge, withAttrs(exp.otelAttrs)...)
+			}
+			return nil
+		}))
+	errors = multierr.Append(errors, err)
+
+	exp.sendsCounter, err = meter.Int64Counter(
+		exp.metricPrefix+obsmetrics.SendsKey,
+		instrument.WithDescription("Number of export operations by the compression codec of the data sent."),
+		instrument.WithUnit(obsmetrics.UnitSends))
+	errors = multierr.Append(errors, err)
+
+	exp.requestsCounter, err = meter.Int64Counter(
+		exp.metricPrefix+obsmetrics.RequestsKey,
+		instrument.WithDescription("Number of export requests by outcome, regardless of the number of items they hold."),
+		instrument.WithUnit(obsmetrics.UnitRequests))
+	errors = multierr.Append(errors, err)
+
+	exp.retriesExhaustedSpansCounter, err = meter.Int64Counter(
+		exp.metricPrefix+obsmetrics.RetriesExhaustedSpansKey,
+		instrument.WithDescription("Number of spans dropped after exhausting the retries to send them to destination."),
+		instrument.WithUnit(obsmetrics.UnitSpans))
+	errors = multierr.Append(errors, err)
+
+	exp.retriesExhaustedMetricPointsCounter, err = meter.Int64Counter(
+		exp.metricPrefix+obsmetrics.RetriesExhaustedMetricPointsKey,
+		instrument.WithDescription("Number of metric points dropped after exhausting the retries to send them to destination."),
+		instrument.WithUnit(obsmetrics.UnitMetricPoints))
+	errors = multierr.Append(errors, err)
+
+	exp.retriesExhaustedLogRecordsCounter, err = meter.Int64Counter(
+		exp.metricPrefix+obsmetrics.RetriesExhaustedLogRecordsKey,
+		instrument.WithDescription("Number of log records dropped after exhausting the retries to send them to destination."),
+		instrument.WithUnit(obsmetrics.UnitLogRecords))
+	errors = multierr.Append(errors, err)
+
+	exp.partialWarningsTracesCounter, err = meter.Int64Counter(
+		exp.metricPrefix+obsmetrics.PartialWarningsTracesKey,
+		instrument.WithDescription("Number of traces export responses with a partial success warning, whether or not items were rejected."),
+		instrument.WithUnit(obsmetrics.UnitRequests))
+	errors = multierr.Append(errors, err)
+
+	exp.partialWarningsMetricsCounter, err = meter.Int64Counter(
+		exp.metricPrefix+obsmetrics.PartialWarningsMetricsKey,
+		instrument.WithDescription("Number of metrics export responses with a partial success warning, whether or not items were rejected."),
+		instrument.WithUnit(obsmetrics.UnitRequests))
+	errors = multierr.Append(errors, err)
+
+	exp.partialWarningsLogsCounter, err = meter.Int64Counter(
+		exp.metricPrefix+obsmetrics.PartialWarningsLogsKey,
+		instrument.WithDescription("Number of logs export responses with a partial success warning, whether or not items were rejected."),
+		instrument.WithUnit(obsmetrics.UnitRequests))
+	errors = multierr.Append(errors, err)
+
+	exp.tlsErrorsCounter, err = meter.Int64Counter(
+		exp.metricPrefix+obsmetrics.TLSErrorsKey,
+		instrument.WithDescription("Number of TLS errors of the exporter by kind, e.g. handshake failures or expired certificates."),
+		instrument.WithUnit(obsmetrics.UnitFailures))
+	errors = multierr.Append(errors, err)
+
+	exp.itemCounters = newCounterGroups(exp.otelAttrs, map[component.DataType][]instrument.Int64Counter{
+		component.DataTypeTraces:  {exp.sentSpans, exp.failedToSendSpans},
+		component.DataTypeMetrics: {exp.sentMetricPoints, exp.failedToSendMetricPoints},
+		component.DataTypeLogs:    {exp.sentLogRecords, exp.failedToSendLogRecords},
+	})
+
 	return errors
 }
 
+// AddRecorder adds a Recorder which the item counters of the Exporter are also recorded
+// with, see Receiver.AddRecorder.
+func (exp *Exporter) AddRecorder(r Recorder) RecorderID {
+	return exp.recorder.add(r)
+}
+
+// RemoveRecorder removes the Recorder added with AddRecorder which returned the given ID.
+func (exp *Exporter) RemoveRecorder(id RecorderID) {
+	exp.recorder.remove(id)
+}
+
 // StartTracesOp is called at the start of an Export operation.
 // The returned context should be used in other calls to the Exporter functions
 // dealing with the same export operation.
@@ -142,11 +610,367 @@ func (exp *Exporter) StartTracesOp(ctx context.Context) context.Context {
 	return exp.startOp(ctx, obsmetrics.ExportTraceDataOperationSuffix)
 }
 
+// StartTracesOpWithHandle is like StartTracesOp but it also returns an OpHandle,
+// which can be passed to EndTracesOp instead of the returned context, see OpHandle.
+func (exp *Exporter) StartTracesOpWithHandle(ctx context.Context) (context.Context, OpHandle) {
+	return newOpHandle(exp.StartTracesOp(ctx))
+}
+
+// StartTracesOpWithChunkEvents is like StartTracesOp but, if recordChunks is set, AddChunkEvent
+// adds an event to the span of the operation for each chunk the batch is split into, e.g. when
+// an exporter sends a large batch with multiple requests. It is meant to debug the splitting of
+// large payloads, so recordChunks is usually set from a debug option of the exporter.
+func (exp *Exporter) StartTracesOpWithChunkEvents(ctx context.Context, recordChunks bool) context.Context {
+	ctx = exp.StartTracesOp(ctx)
+	if recordChunks {
+		ctx = context.WithValue(ctx, opChunksKey{}, &opChunks{})
+	}
+	return ctx
+}
+
+// AddChunkEvent is called for each chunk sent by an export operation started with
+// StartTracesOpWithChunkEvents, in the order they are sent. It adds an event to the span of
+// the operation with the index of the chunk, starting at 0, and its number of items. It is
+// a no-op if the operation was not started with chunk events enabled. Like the span of the
+// operation, it is not safe for concurrent use, the chunks sent in parallel must be reported
+// from a single goroutine.
+func (exp *Exporter) AddChunkEvent(ctx context.Context, chunkItems int) {
+	chunks, ok := ctx.Value(opChunksKey{}).(*opChunks)
+	if !ok {
+		return
+	}
+	trace.SpanFromContext(ctx).AddEvent(chunkEventName, trace.WithAttributes(
+		attribute.Int(chunkIndexKey, chunks.next),
+		attribute.Int(chunkItemsKey, chunkItems)))
+	chunks.next++
+}
+
+// StartTracesOpQueued is like StartTracesOp for the exporters sending the data from a queue,
+// with the time the data was added to the queue. It splits the latency of the data between the
+// time it waited in the queue, computed when the operation starts, and the time spent sending
+// it, computed when it ends, both added as attributes to the span of the operation. The time
+// waited is also recorded as the queue_wait histogram if RecordQueueWait is enabled.
+func (exp *Exporter) StartTracesOpQueued(ctx context.Context, enqueuedAt time.Time) context.Context {
+	ctx = exp.StartTracesOp(ctx)
+	sendStart := exp.now()
+	queueWait := sendStart.Sub(enqueuedAt)
+	if queueWait < 0 {
+		queueWait = 0
+	}
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		span.SetAttributes(attribute.Float64(queueWaitKey, float64(queueWait)/float64(time.Millisecond)))
+	}
+	if exp.queueWait && exp.signalLevels.levelFor(component.DataTypeTraces, exp.level.Load()) != configtelemetry.LevelNone {
+		exp.recordQueueWait(ctx, queueWait)
+	}
+	return context.WithValue(ctx, opQueuedKey{}, sendStart)
+}
+
 // EndTracesOp completes the export operation that was started with StartTracesOp.
 func (exp *Exporter) EndTracesOp(ctx context.Context, numSpans int, err error) {
-	numSent, numFailedToSend := toNumItems(numSpans, err)
-	exp.recordMetrics(ctx, component.DataTypeTraces, numSent, numFailedToSend)
-	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentSpansKey, obsmetrics.FailedToSendSpansKey)
+	numSent, numFailedToSend, err := toNumItems(numSpans, err)
+	exp.recordMetrics(ctx, component.DataTypeTraces, numSent, numFailedToSend, err)
+	exp.endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentSpansKey, obsmetrics.FailedToSendSpansKey)
+}
+
+// EndTracesOpWithCode is like EndTracesOp but, if err is not nil, the failed spans
+// are also recorded broken down by the status code returned by the destination.
+// The code must be the decimal representation of an HTTP status code (100-599),
+// recorded as http.status_code, or of a gRPC status code (0-16), recorded as
+// rpc.grpc.status_code. Any other code is ignored.
+func (exp *Exporter) EndTracesOpWithCode(ctx context.Context, numSpans int, code string, err error) {
+	exp.EndTracesOp(ctx, numSpans, err)
+	if err == nil || exp.ocMeasures.failedToSendSpansByCode == nil ||
+		exp.signalLevels.levelFor(component.DataTypeTraces, exp.level.Load()) == configtelemetry.LevelNone {
+		return
+	}
+	_, numFailedToSend, _ := toAcceptedRefused(numSpans, err)
+
+	codeKey, ok := statusCodeKey(code)
+	if !ok {
+		exp.logger.Debug("Ignoring unknown status code", zap.String("code", code))
+		return
+	}
+	codeAttrs := exp.interner.with(codeKey, code)
+	if exp.useOtelForMetrics {
+		exp.failedToSendSpansByCode.Add(ctx, int64(numFailedToSend), codeAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(ctx, codeAttrs.mutators, exp.ocMeasures.failedToSendSpansByCode.M(int64(numFailedToSend)))
+	}
+}
+
+// EndTracesOpToDestination is like EndTracesOp but the spans are also recorded broken
+// down by the destination that handled them, for exporters that fail over to a secondary
+// endpoint. The destination must be DestinationPrimary or DestinationSecondary, any other
+// destination is ignored.
+func (exp *Exporter) EndTracesOpToDestination(ctx context.Context, numSpans int, destination string, err error) {
+	exp.EndTracesOp(ctx, numSpans, err)
+	if exp.ocMeasures.sentSpansByDestination == nil ||
+		exp.signalLevels.levelFor(component.DataTypeTraces, exp.level.Load()) == configtelemetry.LevelNone {
+		return
+	}
+	switch destination {
+	case DestinationPrimary, DestinationSecondary:
+	default:
+		exp.logger.Debug("Ignoring unknown destination", zap.String(obsmetrics.DestinationKey, destination))
+		return
+	}
+
+	numSent, numFailedToSend, _ := toNumItems(numSpans, err)
+	destinationAttrs := exp.interner.with(obsmetrics.TagKeyDestination, destination)
+	if exp.useOtelForMetrics {
+		exp.sentSpansByDestination.Add(ctx, numSent, destinationAttrs.attrs()...)
+		exp.failedToSendSpansByDestination.Add(ctx, numFailedToSend, destinationAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(
+			ctx,
+			destinationAttrs.mutators,
+			exp.ocMeasures.sentSpansByDestination.M(numSent),
+			exp.ocMeasures.failedToSendSpansByDestination.M(numFailedToSend))
+	}
+}
+
+// RecordConnectionState reports that the connection of the exporter to the destination
+// changed to the given state, which must be one of the ConnectionState* constants.
+// The connection_state gauge is set to 1 for the new state and to 0 for the previous
+// one, and the transitions to the new state are counted. Recording the current state
+// again is not a transition. Any unknown state is ignored.
+func (exp *Exporter) RecordConnectionState(ctx context.Context, state string) {
+	if exp.ocMeasures.connectionState == nil || exp.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	switch state {
+	case ConnectionStateIdle, ConnectionStateConnecting, ConnectionStateReady, ConnectionStateTransientFailure, ConnectionStateShutdown:
+	default:
+		exp.logger.Debug("Ignoring unknown connection state", zap.String(obsmetrics.StateKey, state))
+		return
+	}
+
+	exp.connectionStateMu.Lock()
+	defer exp.connectionStateMu.Unlock()
+	prev := exp.connectionState
+	if prev == state {
+		return
+	}
+	exp.connectionState = state
+
+	stateAttrs := exp.interner.with(obsmetrics.TagKeyState, state)
+	if exp.useOtelForMetrics {
+		if prev != "" {
+			exp.connectionStateUpDownCounter.Add(ctx, -1, exp.interner.with(obsmetrics.TagKeyState, prev).attrs()...)
+		}
+		exp.connectionStateUpDownCounter.Add(ctx, 1, stateAttrs.attrs()...)
+		exp.connectionStateTransitionsCounter.Add(ctx, 1, stateAttrs.attrs()...)
+		return
+	}
+	if prev != "" {
+		_ = stats.RecordWithTags(ctx, exp.interner.with(obsmetrics.TagKeyState, prev).mutators, exp.ocMeasures.connectionState.M(0))
+	}
+	_ = stats.RecordWithTags(
+		ctx,
+		stateAttrs.mutators,
+		exp.ocMeasures.connectionState.M(1),
+		exp.ocMeasures.connectionStateTransitions.M(1))
+}
+
+// RecordBackpressure reports whether the destination currently applies backpressure to
+// the exporter, e.g. because it is throttling the requests or the sending queue is full.
+// The backpressure gauge is set to 1 while it is active and to 0 otherwise, and the time
+// spent under backpressure is added to the backpressure_duration counter when it ends.
+// Reporting the current state again has no effect.
+func (exp *Exporter) RecordBackpressure(ctx context.Context, active bool) {
+	if exp.ocMeasures.backpressure == nil || exp.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+
+	exp.backpressureMu.Lock()
+	defer exp.backpressureMu.Unlock()
+	if active == !exp.backpressureSince.IsZero() {
+		return
+	}
+
+	if active {
+		exp.backpressureSince = exp.now()
+		if exp.useOtelForMetrics {
+			exp.backpressureUpDownCounter.Add(ctx, 1, withAttrs(exp.otelAttrs)...)
+		} else {
+			_ = stats.RecordWithTags(ctx, exp.mutators, exp.ocMeasures.backpressure.M(1))
+		}
+		return
+	}
+
+	duration := float64(exp.now().Sub(exp.backpressureSince)) / float64(time.Millisecond)
+	exp.backpressureSince = time.Time{}
+	if exp.useOtelForMetrics {
+		exp.backpressureUpDownCounter.Add(ctx, -1, withAttrs(exp.otelAttrs)...)
+		exp.backpressureDurationCounter.Add(ctx, duration, withAttrs(exp.otelAttrs)...)
+	} else {
+		_ = stats.RecordWithTags(
+			ctx,
+			exp.mutators,
+			exp.ocMeasures.backpressure.M(0),
+			exp.ocMeasures.backpressureDuration.M(duration))
+	}
+}
+
+// RecordPersistentQueueSize reports the number of items held in the persistent queue of
+// the exporter and its size in bytes on disk, which are set as the persistent_queue_items
+// and persistent_queue_bytes gauges. It should be called whenever they change, by the
+// exporters with a persistent queue only, so the gauges are not reported for the others.
+func (exp *Exporter) RecordPersistentQueueSize(ctx context.Context, numItems, numBytes int) {
+	if exp.ocMeasures.persistentQueueItems == nil || exp.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+
+	exp.persistentQueueMu.Lock()
+	defer exp.persistentQueueMu.Unlock()
+	items, bytes := int64(numItems), int64(numBytes)
+	if exp.useOtelForMetrics {
+		exp.persistentQueueItemsUpDownCounter.Add(ctx, items-exp.persistentQueueItems, withAttrs(exp.otelAttrs)...)
+		exp.persistentQueueBytesUpDownCounter.Add(ctx, bytes-exp.persistentQueueBytes, withAttrs(exp.otelAttrs)...)
+	} else {
+		_ = stats.RecordWithTags(
+			ctx,
+			exp.mutators,
+			exp.ocMeasures.persistentQueueItems.M(items),
+			exp.ocMeasures.persistentQueueBytes.M(bytes))
+	}
+	exp.persistentQueueItems, exp.persistentQueueBytes = items, bytes
+}
+
+// RecordOldestQueuedAge reports the age of the oldest item in the queue of the exporter, e.g.
+// the time since the oldest batch of its persistent queue was enqueued, which is set as the
+// oldest_queued_age gauge. It should be called periodically, and with 0 when the queue is
+// empty, so that alerts can detect the data stuck in the queue. Negative ages are reported
+// as 0.
+func (exp *Exporter) RecordOldestQueuedAge(ctx context.Context, d time.Duration) {
+	if exp.ocMeasures.oldestQueuedAge == nil || exp.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	if d < 0 {
+		d = 0
+	}
+	age := float64(d) / float64(time.Millisecond)
+	if exp.useOtelForMetrics {
+		exp.oldestQueuedAgeMu.Lock()
+		exp.oldestQueuedAge = age
+		exp.oldestQueuedAgeMu.Unlock()
+		return
+	}
+	_ = stats.RecordWithTags(ctx, exp.mutators, exp.ocMeasures.oldestQueuedAge.M(age))
+}
+
+// RecordRetriesExhausted reports that the given number of items of the signal were dropped
+// because the exporter exhausted the retries to send them, e.g. from its retry queue. Unlike
+// the items failed to send, which may be retried, these items are lost, so they pinpoint
+// persistent outages of the destination. They should also be reported as failed to send by
+// the last export operation.
+// Any signal other than traces, metrics or logs is ignored.
+func (exp *Exporter) RecordRetriesExhausted(ctx context.Context, signal component.DataType, numItems int) {
+	if exp.ocMeasures.retriesExhaustedSpans == nil || exp.signalLevels.levelFor(signal, exp.level.Load()) == configtelemetry.LevelNone {
+		return
+	}
+
+	var counter instrument.Int64Counter
+	var measure *stats.Int64Measure
+	switch signal {
+	case component.DataTypeTraces:
+		counter, measure = exp.retriesExhaustedSpansCounter, exp.ocMeasures.retriesExhaustedSpans
+	case component.DataTypeMetrics:
+		counter, measure = exp.retriesExhaustedMetricPointsCounter, exp.ocMeasures.retriesExhaustedMetricPoints
+	case component.DataTypeLogs:
+		counter, measure = exp.retriesExhaustedLogRecordsCounter, exp.ocMeasures.retriesExhaustedLogRecords
+	default:
+		return
+	}
+	if exp.useOtelForMetrics {
+		counter.Add(ctx, int64(numItems), withAttrs(exp.otelAttrs)...)
+	} else {
+		_ = stats.RecordWithTags(ctx, exp.mutators, measure.M(int64(numItems)))
+	}
+}
+
+// RecordPartialWarning reports that the destination answered an export request of the signal
+// with a partial success holding a non-empty warning message. It must be called for every such
+// response, even when no items were rejected, as the warnings may otherwise go unnoticed: the
+// rejected items, if any, should still be reported as failed to send by the export operation.
+// Any signal other than traces, metrics or logs is ignored.
+func (exp *Exporter) RecordPartialWarning(ctx context.Context, signal component.DataType) {
+	if exp.ocMeasures.partialWarningsTraces == nil || exp.signalLevels.levelFor(signal, exp.level.Load()) == configtelemetry.LevelNone {
+		return
+	}
+
+	var counter instrument.Int64Counter
+	var measure *stats.Int64Measure
+	switch signal {
+	case component.DataTypeTraces:
+		counter, measure = exp.partialWarningsTracesCounter, exp.ocMeasures.partialWarningsTraces
+	case component.DataTypeMetrics:
+		counter, measure = exp.partialWarningsMetricsCounter, exp.ocMeasures.partialWarningsMetrics
+	case component.DataTypeLogs:
+		counter, measure = exp.partialWarningsLogsCounter, exp.ocMeasures.partialWarningsLogs
+	default:
+		return
+	}
+	if exp.useOtelForMetrics {
+		counter.Add(ctx, 1, withAttrs(exp.otelAttrs)...)
+	} else {
+		_ = stats.RecordWithTags(ctx, exp.mutators, measure.M(1))
+	}
+}
+
+// RecordTLSError reports that the exporter failed to establish a TLS connection to the
+// destination, so that these failures can be told apart from the other errors to send data,
+// e.g. to monitor the rotation of the certificates. The kind should be one of the TLSError*
+// constants, any other kind is reported as TLSErrorOther. The export operations that failed
+// because of the error should still be reported as failed.
+func (exp *Exporter) RecordTLSError(ctx context.Context, kind string) {
+	if exp.ocMeasures.tlsErrors == nil || exp.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	switch kind {
+	case TLSErrorHandshake, TLSErrorCertExpired, TLSErrorVerify:
+	default:
+		kind = TLSErrorOther
+	}
+
+	kindAttrs := exp.interner.with(obsmetrics.TagKeyTLSErrorKind, kind)
+	if exp.useOtelForMetrics {
+		exp.tlsErrorsCounter.Add(ctx, 1, kindAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(ctx, kindAttrs.mutators, exp.ocMeasures.tlsErrors.M(1))
+	}
+}
+
+// RecordAckLatency reports the time from sending data until the destination acknowledged it.
+// It is meant for the exporters that send the data asynchronously and receive the
+// acknowledgments later, e.g. over a stream, for which the duration of the export operations
+// does not include the time to acknowledge the data. It should be called when the
+// acknowledgment is received.
+func (exp *Exporter) RecordAckLatency(ctx context.Context, d time.Duration) {
+	if exp.ocMeasures.ackLatency == nil || exp.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	latency := float64(d) / float64(time.Millisecond)
+	if exp.useOtelForMetrics {
+		exp.ackLatencyHistogram.Record(ctx, latency, withAttrs(exp.otelAttrs)...)
+	} else {
+		_ = stats.RecordWithTags(ctx, exp.mutators, exp.ocMeasures.ackLatency.M(latency))
+	}
+}
+
+// statusCodeKey returns the tag key used to record the given HTTP or gRPC status code.
+func statusCodeKey(code string) (tag.Key, bool) {
+	n, err := strconv.Atoi(code)
+	switch {
+	case err != nil || strconv.Itoa(n) != code:
+		return tag.Key{}, false
+	case n >= 100 && n <= 599:
+		return obsmetrics.TagKeyHTTPStatusCode, true
+	case n >= 0 && n <= 16:
+		return obsmetrics.TagKeyGRPCStatusCode, true
+	}
+	return tag.Key{}, false
 }
 
 // StartMetricsOp is called at the start of an Export operation.
@@ -156,12 +980,18 @@ func (exp *Exporter) StartMetricsOp(ctx context.Context) context.Context {
 	return exp.startOp(ctx, obsmetrics.ExportMetricsOperationSuffix)
 }
 
+// StartMetricsOpWithHandle is like StartMetricsOp but it also returns an OpHandle,
+// which can be passed to EndMetricsOp instead of the returned context, see OpHandle.
+func (exp *Exporter) StartMetricsOpWithHandle(ctx context.Context) (context.Context, OpHandle) {
+	return newOpHandle(exp.StartMetricsOp(ctx))
+}
+
 // EndMetricsOp completes the export operation that was started with
 // StartMetricsOp.
 func (exp *Exporter) EndMetricsOp(ctx context.Context, numMetricPoints int, err error) {
-	numSent, numFailedToSend := toNumItems(numMetricPoints, err)
-	exp.recordMetrics(ctx, component.DataTypeMetrics, numSent, numFailedToSend)
-	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentMetricPointsKey, obsmetrics.FailedToSendMetricPointsKey)
+	numSent, numFailedToSend, err := toNumItems(numMetricPoints, err)
+	exp.recordMetrics(ctx, component.DataTypeMetrics, numSent, numFailedToSend, err)
+	exp.endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentMetricPointsKey, obsmetrics.FailedToSendMetricPointsKey)
 }
 
 // StartLogsOp is called at the start of an Export operation.
@@ -171,62 +1001,165 @@ func (exp *Exporter) StartLogsOp(ctx context.Context) context.Context {
 	return exp.startOp(ctx, obsmetrics.ExportLogsOperationSuffix)
 }
 
+// StartLogsOpWithHandle is like StartLogsOp but it also returns an OpHandle,
+// which can be passed to EndLogsOp instead of the returned context, see OpHandle.
+func (exp *Exporter) StartLogsOpWithHandle(ctx context.Context) (context.Context, OpHandle) {
+	return newOpHandle(exp.StartLogsOp(ctx))
+}
+
 // EndLogsOp completes the export operation that was started with StartLogsOp.
 func (exp *Exporter) EndLogsOp(ctx context.Context, numLogRecords int, err error) {
-	numSent, numFailedToSend := toNumItems(numLogRecords, err)
-	exp.recordMetrics(ctx, component.DataTypeLogs, numSent, numFailedToSend)
-	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentLogRecordsKey, obsmetrics.FailedToSendLogRecordsKey)
+	numSent, numFailedToSend, err := toNumItems(numLogRecords, err)
+	exp.recordMetrics(ctx, component.DataTypeLogs, numSent, numFailedToSend, err)
+	exp.endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentLogRecordsKey, obsmetrics.FailedToSendLogRecordsKey)
+}
+
+// EndLogsOpPartial completes the export operation that was started with StartLogsOp
+// when the destination reported that only part of the log records were accepted,
+// following the OTLP partial success semantics. The numRejected log records are
+// recorded as failed to send and the remaining ones as sent. The span status is only
+// set from err when the whole request failed, ie.: all the log records were rejected.
+func (exp *Exporter) EndLogsOpPartial(ctx context.Context, numLogRecords, numRejected int, err error) {
+	numSent, numFailedToSend := toNumItemsPartial(numLogRecords, numRejected)
+	if numSent > 0 {
+		err = nil
+	}
+	exp.recordMetrics(ctx, component.DataTypeLogs, numSent, numFailedToSend, err)
+	exp.endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentLogRecordsKey, obsmetrics.FailedToSendLogRecordsKey)
 }
 
 // startOp creates the span used to trace the operation. Returning
 // the updated context and the created span.
 func (exp *Exporter) startOp(ctx context.Context, operationSuffix string) context.Context {
+	if exp.overhead.enabled() {
+		defer exp.overhead.record(time.Now())
+	}
 	spanName := exp.spanNamePrefix + operationSuffix
-	ctx, _ = exp.tracer.Start(ctx, spanName)
+	ctx, _ = startSpan(ctx, exp.tracer, exp.spanMinDuration, spanName)
+	if exp.ocMeasures.sendDuration != nil && exp.level.Load() != configtelemetry.LevelNone {
+		ctx = context.WithValue(ctx, opStartTimeKey{}, time.Now())
+	}
 	return ctx
 }
 
-func (exp *Exporter) recordMetrics(ctx context.Context, dataType component.DataType, numSent, numFailed int64) {
-	if exp.level == configtelemetry.LevelNone {
+// recordMetrics records the metrics of an export operation, err is the error that sets
+// the status of its span, which determines its outcome.
+func (exp *Exporter) recordMetrics(ctx context.Context, dataType component.DataType, numSent, numFailed int64, err error) {
+	if exp.overhead.enabled() {
+		defer exp.overhead.record(time.Now())
+	}
+	level := exp.signalLevels.levelFor(dataType, exp.level.Load())
+	if level == configtelemetry.LevelNone {
 		return
 	}
+	exp.recorder.RecordSent(ctx, dataType, numSent, numFailed)
+	if exp.batchSizes && level == configtelemetry.LevelDetailed {
+		exp.recordBatchSize(ctx, dataType, numSent+numFailed)
+	}
+	if exp.compression != "" {
+		exp.recordSend(ctx)
+	}
+	if exp.ocMeasures.requests != nil {
+		exp.recordRequest(ctx, err)
+	}
+	if exp.pipelineLatencyName != "" && err == nil {
+		if startTime, ok := ctx.Value(pipelineStartKey{}).(time.Time); ok {
+			exp.recordPipelineLatency(ctx, exp.now().Sub(startTime))
+		}
+	}
+	// The context of a connector may hold the start time of the receive operation.
+	if startTime, ok := ctx.Value(opStartTimeKey{}).(time.Time); ok && exp.ocMeasures.sendDuration != nil {
+		exp.recordSendDuration(ctx, time.Since(startTime), err)
+	}
+}
+
+func (exp *Exporter) recordSend(ctx context.Context) {
+	compressionAttrs := exp.interner.with(obsmetrics.TagKeyCompression, exp.compression)
 	if exp.useOtelForMetrics {
-		exp.recordWithOtel(ctx, dataType, numSent, numFailed)
+		exp.sendsCounter.Add(ctx, 1, compressionAttrs.attrs()...)
 	} else {
-		exp.recordWithOC(ctx, dataType, numSent, numFailed)
+		_ = stats.RecordWithTags(ctx, compressionAttrs.mutators, obsmetrics.ExporterSends.M(1))
 	}
 }
 
-func (exp *Exporter) recordWithOtel(ctx context.Context, dataType component.DataType, sent int64, failed int64) {
-	var sentMeasure, failedMeasure instrument.Int64Counter
-	switch dataType {
-	case component.DataTypeTraces:
-		sentMeasure = exp.sentSpans
-		failedMeasure = exp.failedToSendSpans
-	case component.DataTypeMetrics:
-		sentMeasure = exp.sentMetricPoints
-		failedMeasure = exp.failedToSendMetricPoints
-	case component.DataTypeLogs:
-		sentMeasure = exp.sentLogRecords
-		failedMeasure = exp.failedToSendLogRecords
+func (exp *Exporter) recordRequest(ctx context.Context, err error) {
+	outcome := OutcomeSuccess
+	if err != nil {
+		outcome = OutcomeFailure
 	}
+	outcomeAttrs := exp.interner.with(obsmetrics.TagKeyOutcome, outcome)
+	if exp.useOtelForMetrics {
+		exp.requestsCounter.Add(ctx, 1, outcomeAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(ctx, outcomeAttrs.mutators, exp.ocMeasures.requests.M(1))
+	}
+}
+
+func (exp *Exporter) recordPipelineLatency(ctx context.Context, d time.Duration) {
+	latency := float64(d) / float64(time.Millisecond)
+	if exp.useOtelForMetrics {
+		exp.pipelineLatencyHistogram.Record(ctx, latency, withAttrs(exp.otelAttrs)...)
+	} else {
+		_ = stats.RecordWithTags(ctx, exp.mutators, exp.ocMeasures.pipelineLatency.M(latency))
+	}
+}
+
+func (exp *Exporter) recordQueueWait(ctx context.Context, d time.Duration) {
+	wait := float64(d) / float64(time.Millisecond)
+	if exp.useOtelForMetrics {
+		exp.queueWaitHistogram.Record(ctx, wait, withAttrs(exp.otelAttrs)...)
+	} else {
+		_ = stats.RecordWithTags(ctx, exp.mutators, exp.ocMeasures.queueWait.M(wait))
+	}
+}
+
+func (exp *Exporter) recordSendDuration(ctx context.Context, d time.Duration, err error) {
+	outcome := OutcomeSuccess
+	if err != nil {
+		outcome = OutcomeFailure
+	}
+	duration := float64(d) / float64(time.Millisecond)
+	outcomeAttrs := exp.interner.with(obsmetrics.TagKeyOutcome, outcome)
+	if exp.useOtelForMetrics {
+		exp.sendDurationHistogram.Record(ctx, duration, outcomeAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(ctx, outcomeAttrs.mutators, exp.ocMeasures.sendDuration.M(duration))
+	}
+}
+
+func (exp *Exporter) recordBatchSize(ctx context.Context, dataType component.DataType, numItems int64) {
+	signalAttrs := exp.interner.with(obsmetrics.TagKeySignal, string(dataType))
+	if exp.useOtelForMetrics {
+		exp.sentBatchSizeHistogram.Record(ctx, numItems, signalAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(ctx, signalAttrs.mutators, obsmetrics.ExporterSentBatchSize.M(numItems))
+	}
+}
+
+func (exp *Exporter) recordWithBackend(ctx context.Context, dataType component.DataType, numSent, numFailed int64) {
+	if exp.useOtelForMetrics {
+		exp.recordWithOtel(dataType, numSent, numFailed)
+	} else {
+		exp.recordWithOC(ctx, dataType, numSent, numFailed)
+	}
+}
 
-	sentMeasure.Add(ctx, sent, exp.otelAttrs...)
-	failedMeasure.Add(ctx, failed, exp.otelAttrs...)
+func (exp *Exporter) recordWithOtel(dataType component.DataType, sent int64, failed int64) {
+	exp.itemCounters.add(dataType, sent, failed)
 }
 
 func (exp *Exporter) recordWithOC(ctx context.Context, dataType component.DataType, sent int64, failed int64) {
 	var sentMeasure, failedMeasure *stats.Int64Measure
 	switch dataType {
 	case component.DataTypeTraces:
-		sentMeasure = obsmetrics.ExporterSentSpans
-		failedMeasure = obsmetrics.ExporterFailedToSendSpans
+		sentMeasure = exp.ocMeasures.sentSpans
+		failedMeasure = exp.ocMeasures.failedToSendSpans
 	case component.DataTypeMetrics:
-		sentMeasure = obsmetrics.ExporterSentMetricPoints
-		failedMeasure = obsmetrics.ExporterFailedToSendMetricPoints
+		sentMeasure = exp.ocMeasures.sentMetricPoints
+		failedMeasure = exp.ocMeasures.failedToSendMetricPoints
 	case component.DataTypeLogs:
-		sentMeasure = obsmetrics.ExporterSentLogRecords
-		failedMeasure = obsmetrics.ExporterFailedToSendLogRecords
+		sentMeasure = exp.ocMeasures.sentLogRecords
+		failedMeasure = exp.ocMeasures.failedToSendLogRecords
 	}
 
 	if failed > 0 {
@@ -243,7 +1176,10 @@ func (exp *Exporter) recordWithOC(ctx context.Context, dataType component.DataTy
 	}
 }
 
-func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sentItemsKey, failedToSendItemsKey string) {
+func (exp *Exporter) endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sentItemsKey, failedToSendItemsKey string) {
+	if exp.overhead.enabled() {
+		defer exp.overhead.record(time.Now())
+	}
 	span := trace.SpanFromContext(ctx)
 	// End the span according to errors.
 	if span.IsRecording() {
@@ -251,14 +1187,25 @@ func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sen
 			attribute.Int64(sentItemsKey, numSent),
 			attribute.Int64(failedToSendItemsKey, numFailedToSend),
 		)
-		recordError(span, err)
+		if sendStart, ok := ctx.Value(opQueuedKey{}).(time.Time); ok {
+			span.SetAttributes(attribute.Float64(sendTimeKey, float64(exp.now().Sub(sendStart))/float64(time.Millisecond)))
+		}
+		recordError(span, err, exp.statusMapper)
 	}
 	span.End()
 }
 
-func toNumItems(numExportedItems int, err error) (int64, int64) {
-	if err != nil {
-		return 0, int64(numExportedItems)
+func toNumItems(numExportedItems int, err error) (int64, int64, error) {
+	numSent, numFailedToSend, err := toAcceptedRefused(numExportedItems, err)
+	return int64(numSent), int64(numFailedToSend), err
+}
+
+func toNumItemsPartial(numExportedItems, numRejectedItems int) (int64, int64) {
+	if numRejectedItems < 0 {
+		numRejectedItems = 0
+	}
+	if numRejectedItems > numExportedItems {
+		numRejectedItems = numExportedItems
 	}
-	return int64(numExportedItems), 0
+	return int64(numExportedItems - numRejectedItems), int64(numRejectedItems)
 }
@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreport // import "go.opentelemetry.io/collector/obsreport"
+
+import (
+	"context"
+	"sync"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// lazyInstrument is an OpenTelemetry instrument created on its first measurement. The
+// helpers use it for the optional metrics, which most components never record, so only
+// the instruments of the metrics a component records are created.
+type lazyInstrument[T any] struct {
+	once   sync.Once
+	create func() (T, error)
+	inst   T
+	err    error
+}
+
+// get returns the instrument, creating it on the first call. It returns false if the
+// instrument could not be created, the error is reported to the OpenTelemetry error handler.
+func (li *lazyInstrument[T]) get() (T, bool) {
+	li.once.Do(func() {
+		if li.inst, li.err = li.create(); li.err != nil {
+			otel.Handle(li.err)
+		}
+	})
+	return li.inst, li.err == nil
+}
+
+// lazyInt64Counter is an instrument.Int64Counter created on its first measurement.
+type lazyInt64Counter struct {
+	lazyInstrument[instrument.Int64Counter]
+}
+
+func newLazyInt64Counter(meter metric.Meter, name string, opts ...instrument.Int64Option) *lazyInt64Counter {
+	return &lazyInt64Counter{lazyInstrument[instrument.Int64Counter]{create: func() (instrument.Int64Counter, error) {
+		return meter.Int64Counter(name, opts...)
+	}}}
+}
+
+// Add records the increment with the counter.
+func (c *lazyInt64Counter) Add(ctx context.Context, incr int64, attrs ...attribute.KeyValue) {
+	if counter, ok := c.get(); ok {
+		counter.Add(ctx, incr, attrs...)
+	}
+}
+
+// lazyInt64UpDownCounter is an instrument.Int64UpDownCounter created on its first measurement.
+type lazyInt64UpDownCounter struct {
+	lazyInstrument[instrument.Int64UpDownCounter]
+}
+
+func newLazyInt64UpDownCounter(meter metric.Meter, name string, opts ...instrument.Int64Option) *lazyInt64UpDownCounter {
+	return &lazyInt64UpDownCounter{lazyInstrument[instrument.Int64UpDownCounter]{create: func() (instrument.Int64UpDownCounter, error) {
+		return meter.Int64UpDownCounter(name, opts...)
+	}}}
+}
+
+// Add records the increment with the counter.
+func (c *lazyInt64UpDownCounter) Add(ctx context.Context, incr int64, attrs ...attribute.KeyValue) {
+	if counter, ok := c.get(); ok {
+		counter.Add(ctx, incr, attrs...)
+	}
+}
+
+// lazyInt64Histogram is an instrument.Int64Histogram created on its first measurement.
+type lazyInt64Histogram struct {
+	lazyInstrument[instrument.Int64Histogram]
+}
+
+func newLazyInt64Histogram(meter metric.Meter, name string, opts ...instrument.Int64Option) *lazyInt64Histogram {
+	return &lazyInt64Histogram{lazyInstrument[instrument.Int64Histogram]{create: func() (instrument.Int64Histogram, error) {
+		return meter.Int64Histogram(name, opts...)
+	}}}
+}
+
+// Record records the value with the histogram.
+func (h *lazyInt64Histogram) Record(ctx context.Context, value int64, attrs ...attribute.KeyValue) {
+	if histogram, ok := h.get(); ok {
+		histogram.Record(ctx, value, attrs...)
+	}
+}
+
+// lazyFloat64Histogram is an instrument.Float64Histogram created on its first measurement.
+type lazyFloat64Histogram struct {
+	lazyInstrument[instrument.Float64Histogram]
+}
+
+func newLazyFloat64Histogram(meter metric.Meter, name string, opts ...instrument.Float64Option) *lazyFloat64Histogram {
+	return &lazyFloat64Histogram{lazyInstrument[instrument.Float64Histogram]{create: func() (instrument.Float64Histogram, error) {
+		return meter.Float64Histogram(name, opts...)
+	}}}
+}
+
+// Record records the value with the histogram.
+func (h *lazyFloat64Histogram) Record(ctx context.Context, value float64, attrs ...attribute.KeyValue) {
+	if histogram, ok := h.get(); ok {
+		histogram.Record(ctx, value, attrs...)
+	}
+}
+
+// signalCounter is the OpenTelemetry counter and the OpenCensus measure of a metric for
+// one signal. The counter is nil unless the metrics are recorded with OpenTelemetry.
+type signalCounter struct {
+	counter *lazyInt64Counter
+	measure *stats.Int64Measure
+}
+
+func newSignalCounter(meter metric.Meter, name, description, unit string, measure *stats.Int64Measure) signalCounter {
+	sc := signalCounter{measure: measure}
+	if meter != nil {
+		sc.counter = newLazyInt64Counter(meter, name, instrument.WithDescription(description), instrument.WithUnit(unit))
+	}
+	return sc
+}
+
+// signalCounters are the instruments of a metric recorded separately for the spans, the
+// metric points and the log records, by signal.
+type signalCounters map[component.DataType]signalCounter
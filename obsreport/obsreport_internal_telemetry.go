@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreport // import "go.opentelemetry.io/collector/obsreport"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric/instrument"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+var errInternalTelemetryExporterShutdown = errors.New("internal telemetry exporter is shut down")
+
+// InternalTelemetry queues the internal metrics of a service for the exporters wrapped
+// with WrapExporter, and reports the number of data points waiting in the queues and
+// the number of data points dropped because a queue was full or the export failed.
+type InternalTelemetry struct {
+	pending atomic.Int64
+	dropped atomic.Int64
+}
+
+// NewInternalTelemetry creates an InternalTelemetry, it is meant to be created once per service.
+func NewInternalTelemetry() *InternalTelemetry {
+	return &InternalTelemetry{}
+}
+
+// Register registers the obsreport/pending_internal_telemetry gauge and the
+// obsreport/dropped_internal_telemetry counter with the telemetry settings of the
+// service. They are only reported with OpenTelemetry, the only backend able to push
+// the internal metrics to exporters.
+func (it *InternalTelemetry) Register(set component.TelemetrySettings) error {
+	return it.register(set, obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled())
+}
+
+func (it *InternalTelemetry) register(set component.TelemetrySettings, useOtel bool) error {
+	if set.MetricsLevel == configtelemetry.LevelNone || !useOtel {
+		return nil
+	}
+	meter := set.MeterProvider.Meter(scopeName)
+	_, err := meter.Int64ObservableGauge(
+		obsmetrics.ObsreportPrefix+obsmetrics.PendingInternalTelemetryKey,
+		instrument.WithDescription("Number of data points of the internal metrics waiting to be exported."),
+		instrument.WithUnit(obsmetrics.UnitMetricPoints),
+		instrument.WithInt64Callback(func(_ context.Context, o instrument.Int64Observer) error {
+			o.Observe(it.pending.Load())
+			return nil
+		}))
+	errs := err
+	_, err = meter.Int64ObservableCounter(
+		obsmetrics.ObsreportPrefix+obsmetrics.DroppedInternalTelemetryKey,
+		instrument.WithDescription("Number of data points of the internal metrics dropped before being exported."),
+		instrument.WithUnit(obsmetrics.UnitMetricPoints),
+		instrument.WithInt64Callback(func(_ context.Context, o instrument.Int64Observer) error {
+			o.Observe(it.dropped.Load())
+			return nil
+		}))
+	return multierr.Append(errs, err)
+}
+
+// WrapExporter wraps an exporter of the internal metrics, e.g. the OTLP exporter of a
+// periodic reader passed in the MetricReaders of the service settings, so the metrics
+// are exported in the background from a queue holding up to queueSize collections.
+// The collections that do not fit in the queue are dropped.
+func (it *InternalTelemetry) WrapExporter(exporter sdkmetric.Exporter, queueSize int) sdkmetric.Exporter {
+	qe := &queuedExporter{
+		Exporter: exporter,
+		it:       it,
+		queue:    make(chan queuedMetrics, queueSize),
+		stopped:  make(chan struct{}),
+	}
+	go qe.run()
+	return qe
+}
+
+// queuedMetrics is a collection of internal metrics waiting to be exported, or, if
+// flushed is set, a marker closing it once the collections queued before are exported.
+type queuedMetrics struct {
+	rm         metricdata.ResourceMetrics
+	dataPoints int64
+	flushed    chan struct{}
+}
+
+type queuedExporter struct {
+	sdkmetric.Exporter
+	it    *InternalTelemetry
+	queue chan queuedMetrics
+
+	mu       sync.RWMutex
+	shutdown bool
+	stopped  chan struct{}
+}
+
+func (qe *queuedExporter) run() {
+	defer close(qe.stopped)
+	for qm := range qe.queue {
+		if qm.flushed != nil {
+			close(qm.flushed)
+			continue
+		}
+		err := qe.Exporter.Export(context.Background(), qm.rm)
+		qe.it.pending.Add(-qm.dataPoints)
+		if err != nil {
+			qe.it.dropped.Add(qm.dataPoints)
+			otel.Handle(err)
+		}
+	}
+}
+
+// Export queues rm to be exported, or drops it if the queue is full.
+func (qe *queuedExporter) Export(_ context.Context, rm metricdata.ResourceMetrics) error {
+	qe.mu.RLock()
+	defer qe.mu.RUnlock()
+	if qe.shutdown {
+		return errInternalTelemetryExporterShutdown
+	}
+	qm := queuedMetrics{rm: rm, dataPoints: int64(countDataPoints(rm))}
+	qe.it.pending.Add(qm.dataPoints)
+	select {
+	case qe.queue <- qm:
+	default:
+		qe.it.pending.Add(-qm.dataPoints)
+		qe.it.dropped.Add(qm.dataPoints)
+	}
+	return nil
+}
+
+// ForceFlush waits for the queued metrics to be exported, then flushes the wrapped exporter.
+func (qe *queuedExporter) ForceFlush(ctx context.Context) error {
+	if err := qe.waitQueued(ctx); err != nil {
+		return err
+	}
+	return qe.Exporter.ForceFlush(ctx)
+}
+
+func (qe *queuedExporter) waitQueued(ctx context.Context) error {
+	qe.mu.RLock()
+	if qe.shutdown {
+		qe.mu.RUnlock()
+		return nil
+	}
+	flushed := make(chan struct{})
+	select {
+	case qe.queue <- queuedMetrics{flushed: flushed}:
+	case <-ctx.Done():
+		qe.mu.RUnlock()
+		return ctx.Err()
+	}
+	qe.mu.RUnlock()
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown exports the queued metrics, then shuts down the wrapped exporter.
+func (qe *queuedExporter) Shutdown(ctx context.Context) error {
+	qe.mu.Lock()
+	if qe.shutdown {
+		qe.mu.Unlock()
+		return nil
+	}
+	qe.shutdown = true
+	close(qe.queue)
+	qe.mu.Unlock()
+
+	select {
+	case <-qe.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return qe.Exporter.Shutdown(ctx)
+}
+
+// countDataPoints returns the number of data points of all the metrics of rm.
+func countDataPoints(rm metricdata.ResourceMetrics) int {
+	count := 0
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Gauge[int64]:
+				count += len(data.DataPoints)
+			case metricdata.Gauge[float64]:
+				count += len(data.DataPoints)
+			case metricdata.Sum[int64]:
+				count += len(data.DataPoints)
+			case metricdata.Sum[float64]:
+				count += len(data.DataPoints)
+			case metricdata.Histogram:
+				count += len(data.DataPoints)
+			}
+		}
+	}
+	return count
+}
@@ -16,11 +16,15 @@ package obsreport // import "go.opentelemetry.io/collector/obsreport"
 
 import (
 	"context"
+	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/instrument"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -37,9 +41,49 @@ var (
 	processorScope = scopeName + nameSep + processorName
 )
 
+// Reasons for a processor to flush its data, reported by RecordFlushReason.
+const (
+	// FlushReasonSize is used when the data is flushed because it reached the configured size.
+	FlushReasonSize = "size"
+	// FlushReasonTimeout is used when the data is flushed because the configured timeout expired.
+	FlushReasonTimeout = "timeout"
+	// FlushReasonForce is used when the data is flushed on demand, e.g. during shutdown.
+	FlushReasonForce = "force"
+)
+
+// Sampling decisions of a processor, reported by RecordSamplingDecision.
+const (
+	// SamplingDecisionKept is used when the sampled spans are kept.
+	SamplingDecisionKept = "kept"
+	// SamplingDecisionDropped is used when the sampled spans are dropped.
+	SamplingDecisionDropped = "dropped"
+)
+
+// Outcomes of the external lookups of an enrichment processor, reported by RecordEnrichment.
+const (
+	// EnrichmentHit is used when the lookup found data to enrich the items with.
+	EnrichmentHit = "hit"
+	// EnrichmentMiss is used when the lookup succeeded but found no data for the items.
+	EnrichmentMiss = "miss"
+	// EnrichmentError is used when the lookup failed, e.g. the external source was unavailable.
+	EnrichmentError = "error"
+)
+
+// DropRuleOther is the rule ID reported by TracesDroppedByRule for any rule
+// not listed in ProcessorSettings.DropRuleIDs.
+const DropRuleOther = "other"
+
+// ThresholdOther is the threshold name reported by RecordThresholdBreach for any
+// threshold not listed in ProcessorSettings.ThresholdNames.
+const ThresholdOther = "other"
+
+// opAllocsKey is the context key for the bytes allocated by the runtime when a processor operation started.
+type opAllocsKey struct{}
+
 // BuildProcessorCustomMetricName is used to be build a metric name following
 // the standards used in the Collector. The configType should be the same
-// value used to identify the type on the config.
+// value used to identify the type on the config. The name uses MetricNamingDefault,
+// see MetricNaming.BuildProcessorCustomMetricName for the other naming strategies.
 func BuildProcessorCustomMetricName(configType, metric string) string {
 	componentPrefix := obsmetrics.ProcessorPrefix
 	if !strings.HasSuffix(componentPrefix, obsmetrics.NameSep) {
@@ -53,13 +97,18 @@ func BuildProcessorCustomMetricName(configType, metric string) string {
 
 // Processor is a helper to add observability to a component.Processor.
 type Processor struct {
-	level    configtelemetry.Level
-	mutators []tag.Mutator
+	level *atomicLevel
+	// tagsCtx holds the processor tags, built once so that recording with OpenCensus
+	// does not need to apply the tag mutators to the caller context on every call.
+	tagsCtx context.Context
 
 	logger *zap.Logger
 
 	useOtelForMetrics bool
 	otelAttrs         []attribute.KeyValue
+	// interner only holds the extra tag in its mutators since tagsCtx is already tagged.
+	interner *attrsInterner
+	recorder *fanoutRecorder
 
 	acceptedSpansCounter        instrument.Int64Counter
 	refusedSpansCounter         instrument.Int64Counter
@@ -70,12 +119,85 @@ type Processor struct {
 	acceptedLogRecordsCounter   instrument.Int64Counter
 	refusedLogRecordsCounter    instrument.Int64Counter
 	droppedLogRecordsCounter    instrument.Int64Counter
+	// itemCounters records the accepted, refused and dropped items together, see counterGroups.
+	itemCounters *counterGroups
+
+	// The metrics recorded separately for each signal, see recordSignal.
+	deduplicated     signalCounters
+	passthrough      signalCounters
+	memoryLimited    signalCounters
+	droppedBytes     signalCounters
+	processingErrors signalCounters
+	outOfOrder       signalCounters
+	transformDropped signalCounters
+	pipelineDrops    signalCounters
+
+	acceptedSpansBySourceCounter  *lazyInt64Counter
+	flushByReasonCounter          *lazyInt64Counter
+	sampledSpansCounter           *lazyInt64Counter
+	droppedSpansByRuleCounter     *lazyInt64Counter
+	droppedSpansByResourceCounter *lazyInt64Counter
+	thresholdBreachesCounter      *lazyInt64Counter
+	enrichedItemsCounter          *lazyInt64Counter
+
+	// timeoutFlushes holds the flushes by size and timeout over the last timeoutFlushRatioWindow.
+	timeoutFlushes      *slidingRatio
+	timeoutFlushRatioMu sync.Mutex
+	// timeoutFlushRatio holds the last ratio computed by RecordFlushReason, observed by
+	// timeoutFlushRatioGauge, it is negative until the first one is computed.
+	timeoutFlushRatio      float64
+	timeoutFlushRatioGauge instrument.Float64ObservableGauge
+
+	sampleRatiosMu sync.Mutex
+	// sampleRatios holds the last ratio reported by RecordEffectiveSampleRatio for each
+	// signal, observed by effectiveSampleRatioGauge.
+	sampleRatios              map[component.DataType]float64
+	effectiveSampleRatioGauge instrument.Float64ObservableGauge
+
+	dropRuleIDs       map[string]struct{}
+	thresholdNames    map[string]struct{}
+	droppedByPipeline bool
+
+	trackAllocs             bool
+	allocatedBytesHistogram *lazyInt64Histogram
+
+	queueLatencyHistogram       *lazyFloat64Histogram
+	processingDurationHistogram *lazyFloat64Histogram
+	batchSplitFactorHistogram   *lazyFloat64Histogram
+	fanoutDegreeHistogram       *lazyInt64Histogram
+
+	// now returns the current time, used to compute the ratio of the timeout flushes.
+	now func() time.Time
 }
 
 // ProcessorSettings are settings for creating a Processor.
 type ProcessorSettings struct {
 	ProcessorID             component.ID
 	ProcessorCreateSettings processor.CreateSettings
+	// TrackAllocs enables recording the bytes allocated during each operation delimited
+	// by StartOp and EndOp. It is a debugging aid for memory-heavy processors: the values
+	// are only recorded at the detailed metrics level, reading the runtime memory statistics
+	// is expensive, and allocations from other goroutines are included in the measurements.
+	TrackAllocs bool
+	// MetricNaming is the strategy used to build the metric names, defaults to MetricNamingDefault.
+	MetricNaming MetricNaming
+	// DropRuleIDs lists the IDs of the configured rules that the processor drops data by,
+	// reported by TracesDroppedByRule. They should be names from the processor configuration,
+	// not values derived from the data.
+	DropRuleIDs []string
+	// ThresholdNames lists the names of the configured thresholds reported by RecordThresholdBreach.
+	ThresholdNames []string
+	// DroppedByPipeline breaks down the items dropped by the processor by the pipeline they
+	// flow through, see WithPipeline. It is meant for the processors shared by multiple
+	// pipelines, which must also expose it with a DroppedByPipeline method, see
+	// Processor.DroppedByPipeline: the service only sets the pipeline in the context of the
+	// pipelines with such a processor, to spare the others an allocation per batch.
+	DroppedByPipeline bool
+	// InstanceID is added as the collector.instance.id tag to all the metrics, see ReceiverSettings.
+	InstanceID string
+	// Recorder records the item counters of the operations instead of OpenCensus or
+	// OpenTelemetry, see Recorder. If nil, they are recorded like the other metrics.
+	Recorder Recorder
 }
 
 // NewProcessor creates a new Processor.
@@ -84,119 +206,326 @@ func NewProcessor(cfg ProcessorSettings) (*Processor, error) {
 }
 
 func newProcessor(cfg ProcessorSettings, useOtel bool) (*Processor, error) {
+	instanceMutators, instanceAttrs := instanceIDTags(cfg.InstanceID)
+	mutators := append([]tag.Mutator{tag.Upsert(obsmetrics.TagKeyProcessor, cfg.ProcessorID.String(), tag.WithTTL(tag.TTLNoPropagation))}, instanceMutators...)
+	tagsCtx, err := tag.New(context.Background(), mutators...)
+	if err != nil {
+		return nil, err
+	}
+
 	proc := &Processor{
-		level:             cfg.ProcessorCreateSettings.MetricsLevel,
-		mutators:          []tag.Mutator{tag.Upsert(obsmetrics.TagKeyProcessor, cfg.ProcessorID.String(), tag.WithTTL(tag.TTLNoPropagation))},
+		level:             newAtomicLevel(cfg.ProcessorCreateSettings.MetricsLevel),
+		tagsCtx:           tagsCtx,
 		logger:            cfg.ProcessorCreateSettings.Logger,
 		useOtelForMetrics: useOtel,
-		otelAttrs: []attribute.KeyValue{
+		otelAttrs: append([]attribute.KeyValue{
 			attribute.String(obsmetrics.ProcessorKey, cfg.ProcessorID.String()),
-		},
+		}, instanceAttrs...),
+		trackAllocs:       cfg.TrackAllocs && cfg.ProcessorCreateSettings.MetricsLevel == configtelemetry.LevelDetailed,
+		dropRuleIDs:       make(map[string]struct{}, len(cfg.DropRuleIDs)),
+		droppedByPipeline: cfg.DroppedByPipeline,
+		sampleRatios:      make(map[component.DataType]float64),
+
+		timeoutFlushes:    newSlidingRatio(timeoutFlushRatioWindow, timeoutFlushRatioBuckets),
+		timeoutFlushRatio: -1,
+		now:               time.Now,
+	}
+	proc.interner = newAttrsInterner(proc.otelAttrs, nil)
+	primary := cfg.Recorder
+	if primary == nil {
+		primary = backendRecorder{processor: proc}
+	}
+	proc.recorder = newFanoutRecorder(primary)
+	for _, ruleID := range cfg.DropRuleIDs {
+		proc.dropRuleIDs[ruleID] = struct{}{}
+	}
+	proc.thresholdNames = make(map[string]struct{}, len(cfg.ThresholdNames))
+	for _, name := range cfg.ThresholdNames {
+		proc.thresholdNames[name] = struct{}{}
 	}
 
-	if err := proc.createOtelMetrics(cfg); err != nil {
+	var meter metric.Meter
+	if useOtel {
+		meter = cfg.ProcessorCreateSettings.MeterProvider.Meter(processorScope)
+	}
+	metricPrefix := cfg.MetricNaming.metricPrefix(obsmetrics.ProcessorKey)
+	proc.createSignalCounters(meter, metricPrefix)
+	if err := proc.createOtelMetrics(meter, metricPrefix); err != nil {
 		return nil, err
 	}
 
 	return proc, nil
 }
 
-func (por *Processor) createOtelMetrics(cfg ProcessorSettings) error {
+// DroppedByPipeline returns whether the processor was created with DroppedByPipeline. The
+// processor components breaking down their drops by pipeline return it from a method with
+// the same signature, which the service checks to set the pipeline in the context.
+func (por *Processor) DroppedByPipeline() bool {
+	return por.droppedByPipeline
+}
+
+// SetLevel changes the metrics level of the processor while it is running, see Receiver.SetLevel.
+// TrackAllocs is not enabled by raising the level later.
+func (por *Processor) SetLevel(level configtelemetry.Level) {
+	por.level.Store(level)
+}
+
+func (por *Processor) createOtelMetrics(meter metric.Meter, metricPrefix string) error {
 	if !por.useOtelForMetrics {
 		return nil
 	}
-	meter := cfg.ProcessorCreateSettings.MeterProvider.Meter(processorScope)
 	var errors, err error
 
 	por.acceptedSpansCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.AcceptedSpansKey,
+		metricPrefix+obsmetrics.AcceptedSpansKey,
 		instrument.WithDescription("Number of spans successfully pushed into the next component in the pipeline."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitSpans),
 	)
 	errors = multierr.Append(errors, err)
 
 	por.refusedSpansCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.RefusedSpansKey,
+		metricPrefix+obsmetrics.RefusedSpansKey,
 		instrument.WithDescription("Number of spans that were rejected by the next component in the pipeline."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitSpans),
 	)
 	errors = multierr.Append(errors, err)
 
 	por.droppedSpansCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.DroppedSpansKey,
+		metricPrefix+obsmetrics.DroppedSpansKey,
 		instrument.WithDescription("Number of spans that were dropped."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitSpans),
 	)
 	errors = multierr.Append(errors, err)
 
 	por.acceptedMetricPointsCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.AcceptedMetricPointsKey,
+		metricPrefix+obsmetrics.AcceptedMetricPointsKey,
 		instrument.WithDescription("Number of metric points successfully pushed into the next component in the pipeline."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitMetricPoints),
 	)
 	errors = multierr.Append(errors, err)
 
 	por.refusedMetricPointsCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.RefusedMetricPointsKey,
+		metricPrefix+obsmetrics.RefusedMetricPointsKey,
 		instrument.WithDescription("Number of metric points that were rejected by the next component in the pipeline."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitMetricPoints),
 	)
 	errors = multierr.Append(errors, err)
 
 	por.droppedMetricPointsCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.DroppedMetricPointsKey,
+		metricPrefix+obsmetrics.DroppedMetricPointsKey,
 		instrument.WithDescription("Number of metric points that were dropped."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitMetricPoints),
 	)
 	errors = multierr.Append(errors, err)
 
 	por.acceptedLogRecordsCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.AcceptedLogRecordsKey,
+		metricPrefix+obsmetrics.AcceptedLogRecordsKey,
 		instrument.WithDescription("Number of log records successfully pushed into the next component in the pipeline."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitLogRecords),
 	)
 	errors = multierr.Append(errors, err)
 
 	por.refusedLogRecordsCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.RefusedLogRecordsKey,
+		metricPrefix+obsmetrics.RefusedLogRecordsKey,
 		instrument.WithDescription("Number of log records that were rejected by the next component in the pipeline."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitLogRecords),
 	)
 	errors = multierr.Append(errors, err)
 
 	por.droppedLogRecordsCounter, err = meter.Int64Counter(
-		obsmetrics.ProcessorPrefix+obsmetrics.DroppedLogRecordsKey,
+		metricPrefix+obsmetrics.DroppedLogRecordsKey,
 		instrument.WithDescription("Number of log records that were dropped."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitLogRecords),
+	)
+	errors = multierr.Append(errors, err)
+
+	por.itemCounters = newCounterGroups(por.otelAttrs, map[component.DataType][]instrument.Int64Counter{
+		component.DataTypeTraces:  {por.acceptedSpansCounter, por.refusedSpansCounter, por.droppedSpansCounter},
+		component.DataTypeMetrics: {por.acceptedMetricPointsCounter, por.refusedMetricPointsCounter, por.droppedMetricPointsCounter},
+		component.DataTypeLogs:    {por.acceptedLogRecordsCounter, por.refusedLogRecordsCounter, por.droppedLogRecordsCounter},
+	})
+
+	// The instruments of the optional metrics are only created when first recorded.
+	por.acceptedSpansBySourceCounter = newLazyInt64Counter(meter,
+		metricPrefix+obsmetrics.AcceptedSpansBySourceKey,
+		instrument.WithDescription("Number of spans successfully pushed into the next component in the pipeline by source receiver."),
+		instrument.WithUnit(obsmetrics.UnitSpans),
+	)
+
+	por.flushByReasonCounter = newLazyInt64Counter(meter,
+		metricPrefix+obsmetrics.FlushByReasonKey,
+		instrument.WithDescription("Number of times the processor flushed its data by reason."),
+		instrument.WithUnit(obsmetrics.UnitFlushes),
+	)
+
+	por.droppedSpansByRuleCounter = newLazyInt64Counter(meter,
+		metricPrefix+obsmetrics.DroppedSpansByRuleKey,
+		instrument.WithDescription("Number of spans that were dropped by rule."),
+		instrument.WithUnit(obsmetrics.UnitSpans),
+	)
+
+	por.droppedSpansByResourceCounter = newLazyInt64Counter(meter,
+		metricPrefix+obsmetrics.DroppedSpansByResourceKey,
+		instrument.WithDescription("Number of spans that were dropped by resource."),
+		instrument.WithUnit(obsmetrics.UnitSpans),
+	)
+
+	por.sampledSpansCounter = newLazyInt64Counter(meter,
+		metricPrefix+obsmetrics.SampledSpansKey,
+		instrument.WithDescription("Number of spans the processor took a sampling decision on, by decision."),
+		instrument.WithUnit(obsmetrics.UnitSpans),
+	)
+
+	por.thresholdBreachesCounter = newLazyInt64Counter(meter,
+		metricPrefix+obsmetrics.ThresholdBreachesKey,
+		instrument.WithDescription("Number of items that breached a threshold checked by the processor, by signal and threshold."),
+		instrument.WithUnit(obsmetrics.UnitItems),
+	)
+
+	por.enrichedItemsCounter = newLazyInt64Counter(meter,
+		metricPrefix+obsmetrics.EnrichedItemsKey,
+		instrument.WithDescription("Number of items the processor looked up in an external source to enrich them, by signal and outcome."),
+		instrument.WithUnit(obsmetrics.UnitItems),
+	)
+
+	por.allocatedBytesHistogram = newLazyInt64Histogram(meter,
+		metricPrefix+obsmetrics.AllocatedBytesKey,
+		instrument.WithDescription("Number of bytes allocated while the processor handled an operation."),
+		instrument.WithUnit("By"),
+	)
+
+	por.queueLatencyHistogram = newLazyFloat64Histogram(meter,
+		metricPrefix+obsmetrics.QueueLatencyKey,
+		instrument.WithDescription("Time the data spent queued in the processor before being processed."),
+		instrument.WithUnit("ms"),
+	)
+
+	por.processingDurationHistogram = newLazyFloat64Histogram(meter,
+		metricPrefix+obsmetrics.ProcessingDurationKey,
+		instrument.WithDescription("Time the processor spent processing the data, by signal."),
+		instrument.WithUnit("ms"),
+	)
+
+	por.fanoutDegreeHistogram = newLazyInt64Histogram(meter,
+		metricPrefix+obsmetrics.FanoutDegreeKey,
+		instrument.WithDescription("Number of consumers the processor wrote each batch to, by signal."),
+		instrument.WithUnit(obsmetrics.UnitConsumers),
+	)
+
+	por.batchSplitFactorHistogram = newLazyFloat64Histogram(meter,
+		metricPrefix+obsmetrics.BatchSplitFactorKey,
+		instrument.WithDescription("Number of batches resulting from splitting each oversized batch."),
+		instrument.WithUnit(obsmetrics.UnitRatio),
+	)
+
+	// The observable gauges observe nothing until the processor records a ratio.
+	por.timeoutFlushRatioGauge, err = meter.Float64ObservableGauge(
+		metricPrefix+obsmetrics.TimeoutFlushRatioKey,
+		instrument.WithDescription("Ratio of the flushes of the processor triggered by the timeout rather than by the size, over the last minute."),
+		instrument.WithUnit(obsmetrics.UnitRatio),
+		instrument.WithFloat64Callback(func(_ context.Context, o instrument.Float64Observer) error {
+			por.timeoutFlushRatioMu.Lock()
+			defer por.timeoutFlushRatioMu.Unlock()
+			if por.timeoutFlushRatio >= 0 {
+				o.Observe(por.timeoutFlushRatio, withAttrs(por.otelAttrs)...)
+			}
+			return nil
+		}),
+	)
+	errors = multierr.Append(errors, err)
+
+	por.effectiveSampleRatioGauge, err = meter.Float64ObservableGauge(
+		metricPrefix+obsmetrics.EffectiveSampleRatioKey,
+		instrument.WithDescription("Ratio of the data kept by the processor when sampling, by signal."),
+		instrument.WithUnit(obsmetrics.UnitRatio),
+		instrument.WithFloat64Callback(func(_ context.Context, o instrument.Float64Observer) error {
+			por.sampleRatiosMu.Lock()
+			defer por.sampleRatiosMu.Unlock()
+			for signal, ratio := range por.sampleRatios {
+				o.Observe(ratio, por.interner.with(obsmetrics.TagKeySignal, string(signal)).attrs()...)
+			}
+			return nil
+		}),
 	)
 	errors = multierr.Append(errors, err)
 
 	return errors
 }
 
-func (por *Processor) recordWithOtel(ctx context.Context, dataType component.DataType, accepted, refused, dropped int64) {
-	var acceptedCount, refusedCount, droppedCount instrument.Int64Counter
-	switch dataType {
-	case component.DataTypeTraces:
-		acceptedCount = por.acceptedSpansCounter
-		refusedCount = por.refusedSpansCounter
-		droppedCount = por.droppedSpansCounter
-	case component.DataTypeMetrics:
-		acceptedCount = por.acceptedMetricPointsCounter
-		refusedCount = por.refusedMetricPointsCounter
-		droppedCount = por.droppedMetricPointsCounter
-	case component.DataTypeLogs:
-		acceptedCount = por.acceptedLogRecordsCounter
-		refusedCount = por.refusedLogRecordsCounter
-		droppedCount = por.droppedLogRecordsCounter
+// createSignalCounters creates the instruments of the metrics recorded with recordSignal.
+// The meter is nil unless the metrics are recorded with OpenTelemetry.
+func (por *Processor) createSignalCounters(meter metric.Meter, metricPrefix string) {
+	por.deduplicated = signalCounters{
+		component.DataTypeTraces: newSignalCounter(meter, metricPrefix+obsmetrics.DeduplicatedSpansKey,
+			"Number of spans that were dropped as duplicates.", obsmetrics.UnitSpans, obsmetrics.ProcessorDeduplicatedSpans),
+		component.DataTypeMetrics: newSignalCounter(meter, metricPrefix+obsmetrics.DeduplicatedMetricPointsKey,
+			"Number of metric points that were dropped as duplicates.", obsmetrics.UnitMetricPoints, obsmetrics.ProcessorDeduplicatedMetricPoints),
+		component.DataTypeLogs: newSignalCounter(meter, metricPrefix+obsmetrics.DeduplicatedLogRecordsKey,
+			"Number of log records that were dropped as duplicates.", obsmetrics.UnitLogRecords, obsmetrics.ProcessorDeduplicatedLogRecords),
+	}
+	por.passthrough = signalCounters{
+		component.DataTypeTraces: newSignalCounter(meter, metricPrefix+obsmetrics.PassthroughSpansKey,
+			"Number of spans that were passed through unchanged to the next component in the pipeline.", obsmetrics.UnitSpans, obsmetrics.ProcessorPassthroughSpans),
+		component.DataTypeMetrics: newSignalCounter(meter, metricPrefix+obsmetrics.PassthroughMetricPointsKey,
+			"Number of metric points that were passed through unchanged to the next component in the pipeline.", obsmetrics.UnitMetricPoints, obsmetrics.ProcessorPassthroughMetricPoints),
+		component.DataTypeLogs: newSignalCounter(meter, metricPrefix+obsmetrics.PassthroughLogRecordsKey,
+			"Number of log records that were passed through unchanged to the next component in the pipeline.", obsmetrics.UnitLogRecords, obsmetrics.ProcessorPassthroughLogRecords),
+	}
+	por.memoryLimited = signalCounters{
+		component.DataTypeTraces: newSignalCounter(meter, metricPrefix+obsmetrics.MemoryLimitedSpansKey,
+			"Number of spans that were refused because the memory usage was above the limit.", obsmetrics.UnitSpans, obsmetrics.ProcessorMemoryLimitedSpans),
+		component.DataTypeMetrics: newSignalCounter(meter, metricPrefix+obsmetrics.MemoryLimitedMetricPointsKey,
+			"Number of metric points that were refused because the memory usage was above the limit.", obsmetrics.UnitMetricPoints, obsmetrics.ProcessorMemoryLimitedMetricPoints),
+		component.DataTypeLogs: newSignalCounter(meter, metricPrefix+obsmetrics.MemoryLimitedLogRecordsKey,
+			"Number of log records that were refused because the memory usage was above the limit.", obsmetrics.UnitLogRecords, obsmetrics.ProcessorMemoryLimitedLogRecords),
+	}
+	por.droppedBytes = signalCounters{
+		component.DataTypeTraces: newSignalCounter(meter, metricPrefix+obsmetrics.DroppedSpanBytesKey,
+			"Size in bytes of the spans that were dropped.", "By", obsmetrics.ProcessorDroppedSpanBytes),
+		component.DataTypeMetrics: newSignalCounter(meter, metricPrefix+obsmetrics.DroppedMetricPointBytesKey,
+			"Size in bytes of the metric points that were dropped.", "By", obsmetrics.ProcessorDroppedMetricPointBytes),
+		component.DataTypeLogs: newSignalCounter(meter, metricPrefix+obsmetrics.DroppedLogRecordBytesKey,
+			"Size in bytes of the log records that were dropped.", "By", obsmetrics.ProcessorDroppedLogRecordBytes),
+	}
+	por.processingErrors = signalCounters{
+		component.DataTypeTraces: newSignalCounter(meter, metricPrefix+obsmetrics.ProcessingErrorsSpansKey,
+			"Number of spans the processor failed to fully process but still passed on.", obsmetrics.UnitSpans, obsmetrics.ProcessorProcessingErrorsSpans),
+		component.DataTypeMetrics: newSignalCounter(meter, metricPrefix+obsmetrics.ProcessingErrorsMetricPointsKey,
+			"Number of metric points the processor failed to fully process but still passed on.", obsmetrics.UnitMetricPoints, obsmetrics.ProcessorProcessingErrorsMetricPoints),
+		component.DataTypeLogs: newSignalCounter(meter, metricPrefix+obsmetrics.ProcessingErrorsLogRecordsKey,
+			"Number of log records the processor failed to fully process but still passed on.", obsmetrics.UnitLogRecords, obsmetrics.ProcessorProcessingErrorsLogRecords),
+	}
+	por.outOfOrder = signalCounters{
+		component.DataTypeTraces: newSignalCounter(meter, metricPrefix+obsmetrics.OutOfOrderSpansKey,
+			"Number of spans the processor received out of order.", obsmetrics.UnitSpans, obsmetrics.ProcessorOutOfOrderSpans),
+		component.DataTypeMetrics: newSignalCounter(meter, metricPrefix+obsmetrics.OutOfOrderMetricPointsKey,
+			"Number of metric points the processor received out of order.", obsmetrics.UnitMetricPoints, obsmetrics.ProcessorOutOfOrderMetricPoints),
+		component.DataTypeLogs: newSignalCounter(meter, metricPrefix+obsmetrics.OutOfOrderLogRecordsKey,
+			"Number of log records the processor received out of order.", obsmetrics.UnitLogRecords, obsmetrics.ProcessorOutOfOrderLogRecords),
 	}
+	por.transformDropped = signalCounters{
+		component.DataTypeTraces: newSignalCounter(meter, metricPrefix+obsmetrics.TransformDroppedSpansKey,
+			"Number of spans dropped because the processor could not transform them, by reason.", obsmetrics.UnitSpans, obsmetrics.ProcessorTransformDroppedSpans),
+		component.DataTypeMetrics: newSignalCounter(meter, metricPrefix+obsmetrics.TransformDroppedMetricPointsKey,
+			"Number of metric points dropped because the processor could not transform them, by reason.", obsmetrics.UnitMetricPoints, obsmetrics.ProcessorTransformDroppedMetricPoints),
+		component.DataTypeLogs: newSignalCounter(meter, metricPrefix+obsmetrics.TransformDroppedLogRecordsKey,
+			"Number of log records dropped because the processor could not transform them, by reason.", obsmetrics.UnitLogRecords, obsmetrics.ProcessorTransformDroppedLogRecords),
+	}
+	por.pipelineDrops = signalCounters{
+		component.DataTypeTraces: newSignalCounter(meter, metricPrefix+obsmetrics.DroppedSpansByPipelineKey,
+			"Number of spans that were dropped by pipeline.", obsmetrics.UnitSpans, obsmetrics.ProcessorDroppedSpansByPipeline),
+		component.DataTypeMetrics: newSignalCounter(meter, metricPrefix+obsmetrics.DroppedMetricPointsByPipelineKey,
+			"Number of metric points that were dropped by pipeline.", obsmetrics.UnitMetricPoints, obsmetrics.ProcessorDroppedMetricPointsByPipeline),
+		component.DataTypeLogs: newSignalCounter(meter, metricPrefix+obsmetrics.DroppedLogRecordsByPipelineKey,
+			"Number of log records that were dropped by pipeline.", obsmetrics.UnitLogRecords, obsmetrics.ProcessorDroppedLogRecordsByPipeline),
+	}
+}
 
-	acceptedCount.Add(ctx, accepted, por.otelAttrs...)
-	refusedCount.Add(ctx, refused, por.otelAttrs...)
-	droppedCount.Add(ctx, dropped, por.otelAttrs...)
+func (por *Processor) recordWithOtel(dataType component.DataType, accepted, refused, dropped int64) {
+	por.itemCounters.add(dataType, accepted, refused, dropped)
 }
 
-func (por *Processor) recordWithOC(ctx context.Context, dataType component.DataType, accepted, refused, dropped int64) {
+func (por *Processor) recordWithOC(dataType component.DataType, accepted, refused, dropped int64) {
 	var acceptedMeasure, refusedMeasure, droppedMeasure *stats.Int64Measure
 
 	switch dataType {
@@ -214,10 +543,10 @@ func (por *Processor) recordWithOC(ctx context.Context, dataType component.DataT
 		droppedMeasure = obsmetrics.ProcessorDroppedLogRecords
 	}
 
-	// ignore the error for now; should not happen
-	_ = stats.RecordWithTags(
-		ctx,
-		por.mutators,
+	// The processor views only use the processor tag, so the measurements are
+	// recorded with the cached tags instead of the ones from the caller context.
+	stats.Record(
+		por.tagsCtx,
 		acceptedMeasure.M(accepted),
 		refusedMeasure.M(refused),
 		droppedMeasure.M(dropped),
@@ -225,72 +554,597 @@ func (por *Processor) recordWithOC(ctx context.Context, dataType component.DataT
 }
 
 func (por *Processor) recordData(ctx context.Context, dataType component.DataType, accepted, refused, dropped int64) {
+	por.recorder.RecordProcessed(ctx, dataType, accepted, refused, dropped)
+}
+
+func (por *Processor) recordWithBackend(dataType component.DataType, accepted, refused, dropped int64) {
 	if por.useOtelForMetrics {
-		por.recordWithOtel(ctx, dataType, accepted, refused, dropped)
+		por.recordWithOtel(dataType, accepted, refused, dropped)
 	} else {
-		por.recordWithOC(ctx, dataType, accepted, refused, dropped)
+		por.recordWithOC(dataType, accepted, refused, dropped)
 	}
 }
 
+func (por *Processor) recordDroppedByPipeline(ctx context.Context, dataType component.DataType, dropped int64) {
+	if !por.droppedByPipeline {
+		return
+	}
+	pipeline, ok := PipelineFromContext(ctx)
+	if !ok {
+		return
+	}
+	por.recordSignal(ctx, por.pipelineDrops, dataType, dropped, por.interner.with(obsmetrics.TagKeyPipeline, pipeline))
+}
+
+// recordSignal records the value for the signal with the instruments of counters, with the
+// attributes and tag mutators of attrs. Any signal without instruments is ignored.
+func (por *Processor) recordSignal(ctx context.Context, counters signalCounters, signal component.DataType, value int64, attrs internedAttrs) {
+	sc, ok := counters[signal]
+	if !ok {
+		return
+	}
+	if por.useOtelForMetrics {
+		sc.counter.Add(ctx, value, attrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(por.tagsCtx, attrs.mutators, sc.measure.M(value))
+	}
+}
+
+// AddRecorder adds a Recorder which the item counters of the Processor are also recorded
+// with, see Receiver.AddRecorder.
+func (por *Processor) AddRecorder(r Recorder) RecorderID {
+	return por.recorder.add(r)
+}
+
+// RemoveRecorder removes the Recorder added with AddRecorder which returned the given ID.
+func (por *Processor) RemoveRecorder(id RecorderID) {
+	por.recorder.remove(id)
+}
+
 // TracesAccepted reports that the trace data was accepted.
 func (por *Processor) TracesAccepted(ctx context.Context, numSpans int) {
-	if por.level != configtelemetry.LevelNone {
+	if por.level.Load() != configtelemetry.LevelNone {
 		por.recordData(ctx, component.DataTypeTraces, int64(numSpans), int64(0), int64(0))
 	}
 }
 
+// TracesAcceptedFrom reports that the trace data received by the given receiver was accepted.
+// In addition to the metrics recorded by TracesAccepted, the accepted spans are broken down
+// by source receiver.
+func (por *Processor) TracesAcceptedFrom(ctx context.Context, numSpans int, source component.ID) {
+	if por.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	por.recordData(ctx, component.DataTypeTraces, int64(numSpans), int64(0), int64(0))
+	sourceAttrs := por.interner.with(obsmetrics.TagKeySourceReceiver, source.String())
+	if por.useOtelForMetrics {
+		por.acceptedSpansBySourceCounter.Add(ctx, int64(numSpans), sourceAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(por.tagsCtx, sourceAttrs.mutators, obsmetrics.ProcessorAcceptedSpansBySource.M(int64(numSpans)))
+	}
+}
+
 // TracesRefused reports that the trace data was refused.
 func (por *Processor) TracesRefused(ctx context.Context, numSpans int) {
-	if por.level != configtelemetry.LevelNone {
+	if por.level.Load() != configtelemetry.LevelNone {
 		por.recordData(ctx, component.DataTypeTraces, int64(0), int64(numSpans), int64(0))
 	}
 }
 
-// TracesDropped reports that the trace data was dropped.
+// TracesDropped reports that the trace data was dropped. If the processor was created with
+// DroppedByPipeline and the context holds the pipeline the data flows through, see WithPipeline,
+// the dropped spans are also broken down by pipeline.
 func (por *Processor) TracesDropped(ctx context.Context, numSpans int) {
-	if por.level != configtelemetry.LevelNone {
+	if por.level.Load() != configtelemetry.LevelNone {
 		por.recordData(ctx, component.DataTypeTraces, int64(0), int64(0), int64(numSpans))
+		por.recordDroppedByPipeline(ctx, component.DataTypeTraces, int64(numSpans))
+	}
+}
+
+// TracesDroppedByRule reports that the trace data was dropped by the given rule.
+// In addition to the metrics recorded by TracesDropped, the dropped spans are broken
+// down by rule ID, which must be one of ProcessorSettings.DropRuleIDs. Any other rule
+// ID is reported as DropRuleOther.
+func (por *Processor) TracesDroppedByRule(ctx context.Context, numSpans int, ruleID string) {
+	if por.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	if _, ok := por.dropRuleIDs[ruleID]; !ok {
+		ruleID = DropRuleOther
+	}
+	por.recordData(ctx, component.DataTypeTraces, int64(0), int64(0), int64(numSpans))
+	por.recordDroppedByPipeline(ctx, component.DataTypeTraces, int64(numSpans))
+	ruleAttrs := por.interner.with(obsmetrics.TagKeyRuleID, ruleID)
+	if por.useOtelForMetrics {
+		por.droppedSpansByRuleCounter.Add(ctx, int64(numSpans), ruleAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(por.tagsCtx, ruleAttrs.mutators, obsmetrics.ProcessorDroppedSpansByRule.M(int64(numSpans)))
+	}
+}
+
+// TracesDroppedForResource reports that the trace data of the given resource was dropped,
+// e.g. because its service is unknown. In addition to the metrics recorded by TracesDropped,
+// the dropped spans are broken down by the resource key, which identifies the resource, e.g.
+// its service name. When the resource key is empty, it is the same as TracesDropped.
+// Beware that every resource key adds new time series to the metrics, so the keys should
+// come from a small and bounded set, e.g. the services known to the processor, never from
+// unvalidated client input.
+func (por *Processor) TracesDroppedForResource(ctx context.Context, numSpans int, resourceKey string) {
+	if por.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	por.recordData(ctx, component.DataTypeTraces, int64(0), int64(0), int64(numSpans))
+	por.recordDroppedByPipeline(ctx, component.DataTypeTraces, int64(numSpans))
+	if resourceKey == "" {
+		return
+	}
+	resourceAttrs := por.interner.with(obsmetrics.TagKeyResource, resourceKey)
+	if por.useOtelForMetrics {
+		por.droppedSpansByResourceCounter.Add(ctx, int64(numSpans), resourceAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(por.tagsCtx, resourceAttrs.mutators, obsmetrics.ProcessorDroppedSpansByResource.M(int64(numSpans)))
+	}
+}
+
+// TracesDeduplicated reports that the trace data was dropped as duplicate.
+// Unlike TracesDropped, this is an expected outcome of deduplicating the data.
+func (por *Processor) TracesDeduplicated(ctx context.Context, numSpans int) {
+	if por.level.Load() != configtelemetry.LevelNone {
+		por.recordSignal(ctx, por.deduplicated, component.DataTypeTraces, int64(numSpans), por.interner.base())
+	}
+}
+
+// TracesPassed reports that the trace data was passed through unchanged to the next
+// component. It is meant for processors that do not accept, refuse or drop data, e.g.
+// processors that only observe it, so the throughput is recorded without implying that
+// the processor made any decision about the data. It is not reported as accepted.
+func (por *Processor) TracesPassed(ctx context.Context, numSpans int) {
+	if por.level.Load() != configtelemetry.LevelNone {
+		por.recordSignal(ctx, por.passthrough, component.DataTypeTraces, int64(numSpans), por.interner.base())
 	}
 }
 
 // MetricsAccepted reports that the metrics were accepted.
 func (por *Processor) MetricsAccepted(ctx context.Context, numPoints int) {
-	if por.level != configtelemetry.LevelNone {
+	if por.level.Load() != configtelemetry.LevelNone {
 		por.recordData(ctx, component.DataTypeMetrics, int64(numPoints), int64(0), int64(0))
 	}
 }
 
 // MetricsRefused reports that the metrics were refused.
 func (por *Processor) MetricsRefused(ctx context.Context, numPoints int) {
-	if por.level != configtelemetry.LevelNone {
+	if por.level.Load() != configtelemetry.LevelNone {
 		por.recordData(ctx, component.DataTypeMetrics, int64(0), int64(numPoints), int64(0))
 	}
 }
 
-// MetricsDropped reports that the metrics were dropped.
+// MetricsDropped reports that the metrics were dropped. Like for TracesDropped, they are
+// also broken down by the pipeline held by the context, if any.
 func (por *Processor) MetricsDropped(ctx context.Context, numPoints int) {
-	if por.level != configtelemetry.LevelNone {
+	if por.level.Load() != configtelemetry.LevelNone {
 		por.recordData(ctx, component.DataTypeMetrics, int64(0), int64(0), int64(numPoints))
+		por.recordDroppedByPipeline(ctx, component.DataTypeMetrics, int64(numPoints))
+	}
+}
+
+// MetricsDeduplicated reports that the metrics were dropped as duplicate.
+// Unlike MetricsDropped, this is an expected outcome of deduplicating the data.
+func (por *Processor) MetricsDeduplicated(ctx context.Context, numPoints int) {
+	if por.level.Load() != configtelemetry.LevelNone {
+		por.recordSignal(ctx, por.deduplicated, component.DataTypeMetrics, int64(numPoints), por.interner.base())
+	}
+}
+
+// MetricsPassed reports that the metrics were passed through unchanged to the next component.
+// See TracesPassed for the semantics.
+func (por *Processor) MetricsPassed(ctx context.Context, numPoints int) {
+	if por.level.Load() != configtelemetry.LevelNone {
+		por.recordSignal(ctx, por.passthrough, component.DataTypeMetrics, int64(numPoints), por.interner.base())
 	}
 }
 
 // LogsAccepted reports that the logs were accepted.
 func (por *Processor) LogsAccepted(ctx context.Context, numRecords int) {
-	if por.level != configtelemetry.LevelNone {
+	if por.level.Load() != configtelemetry.LevelNone {
 		por.recordData(ctx, component.DataTypeLogs, int64(numRecords), int64(0), int64(0))
 	}
 }
 
 // LogsRefused reports that the logs were refused.
 func (por *Processor) LogsRefused(ctx context.Context, numRecords int) {
-	if por.level != configtelemetry.LevelNone {
+	if por.level.Load() != configtelemetry.LevelNone {
 		por.recordData(ctx, component.DataTypeLogs, int64(0), int64(numRecords), int64(0))
 	}
 }
 
-// LogsDropped reports that the logs were dropped.
+// LogsDropped reports that the logs were dropped. Like for TracesDropped, they are also
+// broken down by the pipeline held by the context, if any.
 func (por *Processor) LogsDropped(ctx context.Context, numRecords int) {
-	if por.level != configtelemetry.LevelNone {
+	if por.level.Load() != configtelemetry.LevelNone {
 		por.recordData(ctx, component.DataTypeLogs, int64(0), int64(0), int64(numRecords))
+		por.recordDroppedByPipeline(ctx, component.DataTypeLogs, int64(numRecords))
+	}
+}
+
+// LogsDeduplicated reports that the logs were dropped as duplicate.
+// Unlike LogsDropped, this is an expected outcome of deduplicating the data.
+func (por *Processor) LogsDeduplicated(ctx context.Context, numRecords int) {
+	if por.level.Load() != configtelemetry.LevelNone {
+		por.recordSignal(ctx, por.deduplicated, component.DataTypeLogs, int64(numRecords), por.interner.base())
+	}
+}
+
+// LogsPassed reports that the logs were passed through unchanged to the next component.
+// See TracesPassed for the semantics.
+func (por *Processor) LogsPassed(ctx context.Context, numRecords int) {
+	if por.level.Load() != configtelemetry.LevelNone {
+		por.recordSignal(ctx, por.passthrough, component.DataTypeLogs, int64(numRecords), por.interner.base())
+	}
+}
+
+// RecordMemoryLimited reports that the items of the given signal were refused because
+// the memory usage was above the limit, e.g. by the memory limiter processor. They are
+// recorded in the memory_limited_* metrics to separate them from the other refusals, but
+// they are still refused and should also be reported with TracesRefused, MetricsRefused
+// or LogsRefused.
+// Any signal other than traces, metrics or logs is ignored.
+func (por *Processor) RecordMemoryLimited(ctx context.Context, signal component.DataType, numItems int) {
+	if por.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	por.recordSignal(ctx, por.memoryLimited, signal, int64(numItems), por.interner.base())
+}
+
+// BytesDropped reports the size in bytes of the items of the given signal that were
+// dropped, to convey the memory and bandwidth impact of the drops that the number of items
+// does not. It complements TracesDropped, MetricsDropped and LogsDropped, which must still
+// be called to report the number of items dropped.
+// Any signal other than traces, metrics or logs is ignored.
+func (por *Processor) BytesDropped(ctx context.Context, signal component.DataType, bytes int) {
+	if por.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	por.recordSignal(ctx, por.droppedBytes, signal, int64(bytes), por.interner.base())
+}
+
+// RecordProcessingError reports that the processor failed to fully process the given
+// number of items of the signal, e.g. a best-effort enrichment failed, but still passed
+// them on. The items are recorded in the processing_errors_* metrics only, they must still
+// be reported with TracesAccepted, MetricsAccepted or LogsAccepted.
+// Any signal other than traces, metrics or logs is ignored.
+func (por *Processor) RecordProcessingError(ctx context.Context, signal component.DataType, numItems int) {
+	if por.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	por.recordSignal(ctx, por.processingErrors, signal, int64(numItems), por.interner.base())
+}
+
+// RecordOutOfOrder reports that the processor received the given number of items of the
+// signal out of order, e.g. metric points with a timestamp older than the last one of their
+// series, which surfaces the clock or ordering problems upstream. The items are recorded in
+// the out_of_order_* metrics only, they must still be reported with the other functions
+// depending on what the processor did with them, e.g. MetricsAccepted or MetricsDropped.
+// Any signal other than traces, metrics or logs is ignored.
+func (por *Processor) RecordOutOfOrder(ctx context.Context, signal component.DataType, numItems int) {
+	if por.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	por.recordSignal(ctx, por.outOfOrder, signal, int64(numItems), por.interner.base())
+}
+
+// RecordTransformDropped reports that the processor dropped the given number of items of the
+// signal because it could not transform them, e.g. a schema processor could not map them to
+// the target schema, by reason, e.g. "unmappable_attribute". The items are only recorded in
+// the transform_dropped_* metrics, not with TracesDropped, MetricsDropped or LogsDropped.
+// Any signal other than traces, metrics or logs is ignored.
+func (por *Processor) RecordTransformDropped(ctx context.Context, signal component.DataType, numItems int, reason string) {
+	if por.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	por.recordSignal(ctx, por.transformDropped, signal, int64(numItems), por.interner.with(obsmetrics.TagKeyTransformReason, reason))
+}
+
+// RecordFlushReason reports that the processor flushed its data for the given reason, which
+// must be one of FlushReasonSize, FlushReasonTimeout or FlushReasonForce. Any other reason
+// is ignored. The flushes by size and timeout also update the timeout_flush_ratio gauge, the
+// ratio of the flushes triggered by the timeout over the last minute.
+func (por *Processor) RecordFlushReason(ctx context.Context, reason string) {
+	if por.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	switch reason {
+	case FlushReasonSize, FlushReasonTimeout, FlushReasonForce:
+	default:
+		por.logger.Debug("Ignoring unknown flush reason", zap.String(obsmetrics.FlushReasonKey, reason))
+		return
+	}
+
+	reasonAttrs := por.interner.with(obsmetrics.TagKeyFlushReason, reason)
+	if por.useOtelForMetrics {
+		por.flushByReasonCounter.Add(ctx, 1, reasonAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(por.tagsCtx, reasonAttrs.mutators, obsmetrics.ProcessorFlushByReason.M(1))
+	}
+
+	if reason == FlushReasonForce {
+		return
+	}
+	ratio := por.timeoutFlushes.add(por.now(), reason == FlushReasonTimeout)
+	if por.useOtelForMetrics {
+		por.timeoutFlushRatioMu.Lock()
+		por.timeoutFlushRatio = ratio
+		por.timeoutFlushRatioMu.Unlock()
+	} else {
+		stats.Record(por.tagsCtx, obsmetrics.ProcessorTimeoutFlushRatio.M(ratio))
+	}
+}
+
+// RecordSamplingDecision reports that the processor took a sampling decision on
+// the given number of spans, either keeping or dropping them.
+func (por *Processor) RecordSamplingDecision(ctx context.Context, kept bool, numSpans int) {
+	if por.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	decision := SamplingDecisionDropped
+	if kept {
+		decision = SamplingDecisionKept
+	}
+
+	decisionAttrs := por.interner.with(obsmetrics.TagKeyDecision, decision)
+	if por.useOtelForMetrics {
+		por.sampledSpansCounter.Add(ctx, int64(numSpans), decisionAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(por.tagsCtx, decisionAttrs.mutators, obsmetrics.ProcessorSampledSpans.M(int64(numSpans)))
+	}
+}
+
+// RecordThresholdBreach reports that the given number of items of the signal breached
+// the threshold with the given name, e.g. in a processor detecting anomalies. The name
+// must be one of ProcessorSettings.ThresholdNames, any other name is reported as
+// ThresholdOther. Any signal other than traces, metrics or logs is ignored.
+func (por *Processor) RecordThresholdBreach(ctx context.Context, signal component.DataType, count int, thresholdName string) {
+	if por.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	switch signal {
+	case component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs:
+	default:
+		return
+	}
+
+	if _, ok := por.thresholdNames[thresholdName]; !ok {
+		thresholdName = ThresholdOther
+	}
+
+	thresholdAttrs := por.interner.with2(obsmetrics.TagKeySignal, string(signal), obsmetrics.TagKeyThreshold, thresholdName)
+	if por.useOtelForMetrics {
+		por.thresholdBreachesCounter.Add(ctx, int64(count), thresholdAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(por.tagsCtx, thresholdAttrs.mutators, obsmetrics.ProcessorThresholdBreaches.M(int64(count)))
+	}
+}
+
+// RecordEnrichment reports that the processor looked up the given number of items of the
+// signal in an external source to enrich them, e.g. a GeoIP database or the Kubernetes API,
+// with the given outcome, which must be one of EnrichmentHit, EnrichmentMiss or EnrichmentError.
+// Any other outcome, and any signal other than traces, metrics or logs, is ignored.
+func (por *Processor) RecordEnrichment(ctx context.Context, signal component.DataType, outcome string, count int) {
+	if por.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	switch signal {
+	case component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs:
+	default:
+		return
+	}
+	switch outcome {
+	case EnrichmentHit, EnrichmentMiss, EnrichmentError:
+	default:
+		por.logger.Debug("Ignoring unknown enrichment outcome", zap.String(obsmetrics.OutcomeKey, outcome))
+		return
+	}
+
+	outcomeAttrs := por.interner.with2(obsmetrics.TagKeySignal, string(signal), obsmetrics.TagKeyOutcome, outcome)
+	if por.useOtelForMetrics {
+		por.enrichedItemsCounter.Add(ctx, int64(count), outcomeAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(por.tagsCtx, outcomeAttrs.mutators, obsmetrics.ProcessorEnrichedItems.M(int64(count)))
+	}
+}
+
+// RecordEffectiveSampleRatio reports the ratio of the data of the given signal kept by the
+// processor when sampling, e.g. over the last sampling period, to verify that it matches the
+// configured one. Ratios outside of [0, 1] and signals other than traces, metrics or logs are
+// ignored, since they are always a bug of the processor.
+func (por *Processor) RecordEffectiveSampleRatio(ctx context.Context, signal component.DataType, ratio float64) {
+	if por.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	switch signal {
+	case component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs:
+	default:
+		por.logger.Debug("Ignoring the sample ratio of an unknown signal", zap.String(obsmetrics.SignalKey, string(signal)))
+		return
+	}
+	// The negated comparison also ignores NaN.
+	if !(ratio >= 0 && ratio <= 1) {
+		por.logger.Debug("Ignoring invalid sample ratio", zap.String(obsmetrics.SignalKey, string(signal)), zap.Float64(obsmetrics.EffectiveSampleRatioKey, ratio))
+		return
+	}
+
+	if por.useOtelForMetrics {
+		por.sampleRatiosMu.Lock()
+		por.sampleRatios[signal] = ratio
+		por.sampleRatiosMu.Unlock()
+		return
+	}
+	signalAttrs := por.interner.with(obsmetrics.TagKeySignal, string(signal))
+	_ = stats.RecordWithTags(por.tagsCtx, signalAttrs.mutators, obsmetrics.ProcessorEffectiveSampleRatio.M(ratio))
+}
+
+// RecordQueueLatency reports the time the data spent queued in an asynchronous
+// processor. It should be called when the data is dequeued to be processed.
+func (por *Processor) RecordQueueLatency(ctx context.Context, d time.Duration) {
+	if por.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	latency := float64(d) / float64(time.Millisecond)
+	if por.useOtelForMetrics {
+		por.queueLatencyHistogram.Record(ctx, latency, withAttrs(por.otelAttrs)...)
+	} else {
+		stats.Record(por.tagsCtx, obsmetrics.ProcessorQueueLatency.M(latency))
+	}
+}
+
+// RecordProcessingDuration reports the time a synchronous processor spent processing data
+// of the signal, e.g. in its Consume* function before passing it to the next consumer, which
+// helps to find the slow processors of a pipeline. Unlike RecordQueueLatency, it is only
+// recorded when the metrics level is detailed, since it is meant to be recorded for every
+// batch. Any signal other than traces, metrics or logs is ignored.
+func (por *Processor) RecordProcessingDuration(ctx context.Context, signal component.DataType, d time.Duration) {
+	if por.level.Load() != configtelemetry.LevelDetailed {
+		return
+	}
+	switch signal {
+	case component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs:
+	default:
+		return
+	}
+	duration := float64(d) / float64(time.Millisecond)
+	signalAttrs := por.interner.with(obsmetrics.TagKeySignal, string(signal))
+	if por.useOtelForMetrics {
+		por.processingDurationHistogram.Record(ctx, duration, signalAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(por.tagsCtx, signalAttrs.mutators, obsmetrics.ProcessorProcessingDuration.M(duration))
+	}
+}
+
+// RecordFanout reports that the processor wrote a batch of the signal to the given number of
+// consumers, e.g. a fan-out to the exporters of a pipeline. The distribution of the fan-out
+// degree shows how much the data is amplified, which helps to estimate the downstream load.
+// It is ignored if consumers is not positive, and for any signal other than traces, metrics
+// or logs.
+func (por *Processor) RecordFanout(ctx context.Context, signal component.DataType, consumers int) {
+	if por.level.Load() == configtelemetry.LevelNone || consumers <= 0 {
+		return
+	}
+	switch signal {
+	case component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs:
+	default:
+		return
+	}
+	signalAttrs := por.interner.with(obsmetrics.TagKeySignal, string(signal))
+	if por.useOtelForMetrics {
+		por.fanoutDegreeHistogram.Record(ctx, int64(consumers), signalAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(por.tagsCtx, signalAttrs.mutators, obsmetrics.ProcessorFanoutDegree.M(int64(consumers)))
+	}
+}
+
+// RecordBatchSplit reports that a batching processor split the given number of oversized
+// batches into resultingBatches smaller ones, e.g. to honor send_batch_max_size. The split
+// factor, i.e. the number of resulting batches per original batch, helps to tune the
+// maximum batch size. It is ignored if originalBatches is not positive or if there are
+// fewer resulting batches than original ones, which is not a split.
+func (por *Processor) RecordBatchSplit(ctx context.Context, originalBatches, resultingBatches int) {
+	if por.level.Load() == configtelemetry.LevelNone || originalBatches <= 0 || resultingBatches < originalBatches {
+		return
+	}
+	factor := float64(resultingBatches) / float64(originalBatches)
+	if por.useOtelForMetrics {
+		por.batchSplitFactorHistogram.Record(ctx, factor, withAttrs(por.otelAttrs)...)
+	} else {
+		stats.Record(por.tagsCtx, obsmetrics.ProcessorBatchSplitFactor.M(factor))
+	}
+}
+
+// StartOp is called at the start of an operation of the processor, e.g. when
+// consuming a batch of data. It is only needed when TrackAllocs is set, in which
+// case the returned context must be passed to EndOp.
+func (por *Processor) StartOp(ctx context.Context) context.Context {
+	if !por.trackAllocs || por.level.Load() != configtelemetry.LevelDetailed {
+		return ctx
+	}
+	return context.WithValue(ctx, opAllocsKey{}, totalAllocatedBytes())
+}
+
+// EndOp completes the operation that was started with StartOp. If TrackAllocs is
+// set, it records the bytes allocated since the start of the operation.
+func (por *Processor) EndOp(ctx context.Context) {
+	if !por.trackAllocs || por.level.Load() != configtelemetry.LevelDetailed {
+		return
+	}
+	startAllocs, ok := ctx.Value(opAllocsKey{}).(uint64)
+	if !ok {
+		return
+	}
+	// TotalAlloc is cumulative, so the difference is never negative.
+	allocated := int64(totalAllocatedBytes() - startAllocs)
+	if por.useOtelForMetrics {
+		por.allocatedBytesHistogram.Record(ctx, allocated, withAttrs(por.otelAttrs)...)
+	} else {
+		stats.Record(por.tagsCtx, obsmetrics.ProcessorAllocatedBytes.M(allocated))
+	}
+}
+
+func totalAllocatedBytes() uint64 {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.TotalAlloc
+}
+
+const (
+	// timeoutFlushRatioWindow is the sliding window over which the ratio of the timeout
+	// flushes is computed, it is divided in timeoutFlushRatioBuckets buckets.
+	timeoutFlushRatioWindow  = time.Minute
+	timeoutFlushRatioBuckets = 6
+)
+
+// slidingRatio computes the ratio of the events matching a condition over a sliding window.
+// The window is approximated by counting the events in buckets, each covering a fraction of
+// it, so the events leave the window one bucket at a time. It is safe for concurrent use.
+type slidingRatio struct {
+	bucketLen time.Duration
+
+	mu      sync.Mutex
+	buckets []ratioBucket
+}
+
+// ratioBucket counts the events which happened in the bucket starting at start.
+type ratioBucket struct {
+	start          time.Time
+	matched, total int64
+}
+
+func newSlidingRatio(window time.Duration, numBuckets int) *slidingRatio {
+	return &slidingRatio{
+		bucketLen: window / time.Duration(numBuckets),
+		buckets:   make([]ratioBucket, numBuckets),
+	}
+}
+
+// add adds an event which happened at the given time, and returns the ratio of the events
+// matching the condition over the window ending at that time.
+func (sr *slidingRatio) add(now time.Time, matched bool) float64 {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	start := now.Truncate(sr.bucketLen)
+	bucket := &sr.buckets[uint64(start.UnixNano()/int64(sr.bucketLen))%uint64(len(sr.buckets))]
+	if !bucket.start.Equal(start) {
+		*bucket = ratioBucket{start: start}
+	}
+	bucket.total++
+	if matched {
+		bucket.matched++
+	}
+
+	var numMatched, total int64
+	windowStart := start.Add(-sr.bucketLen * time.Duration(len(sr.buckets)-1))
+	for _, b := range sr.buckets {
+		if !b.start.Before(windowStart) && !b.start.After(start) {
+			numMatched += b.matched
+			total += b.total
+		}
 	}
+	return float64(numMatched) / float64(total)
 }
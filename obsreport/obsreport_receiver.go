@@ -16,10 +16,15 @@ package obsreport // import "go.opentelemetry.io/collector/obsreport"
 
 import (
 	"context"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/instrument"
 	"go.opentelemetry.io/otel/trace"
@@ -30,6 +35,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/receiver"
 )
 
@@ -37,21 +43,114 @@ const (
 	receiverName = "receiver"
 
 	receiverScope = scopeName + nameSep + receiverName
+
+	// firstByteEventName is the name of the span event added by RecordFirstByte.
+	firstByteEventName = "FirstByteReceived"
+
+	// distinctResourcesWindow is the period over which RecordResource estimates the
+	// number of distinct resources.
+	distinctResourcesWindow = time.Minute
+
+	// distinctTracesWindow is the period over which ObserveTraceID estimates the
+	// number of distinct traces.
+	distinctTracesWindow = time.Minute
+
+	// maxProtoVersions is the number of distinct versions EndTracesOpWithProtoVersion reports
+	// before reporting any new one as ProtoVersionOther.
+	maxProtoVersions = 10
+)
+
+// ValidationFieldOther is the field reported by RecordValidationError for any field
+// not listed in ReceiverSettings.ValidationFields.
+const ValidationFieldOther = "other"
+
+// Reasons used to break down the client streams closed by RecordStreamClose.
+const (
+	// StreamCloseEOF is a stream ended by the client.
+	StreamCloseEOF = "eof"
+	// StreamCloseError is a stream ended by an error, e.g. a transport or a decoding error.
+	StreamCloseError = "error"
+	// StreamCloseShutdown is a stream ended because the receiver is shutting down.
+	StreamCloseShutdown = "shutdown"
+	// StreamCloseIdleTimeout is a stream ended because the client sent no data for too long.
+	StreamCloseIdleTimeout = "idle_timeout"
+	// StreamCloseOther is used for any reason not listed above.
+	StreamCloseOther = "other"
+)
+
+// VolumeAttributeUnset is the value reported by the End*OpWithVolume functions for the items
+// of the resources without the attribute set in ReceiverSettings.VolumeAttributeKey.
+const VolumeAttributeUnset = "unset"
+
+// ResourceItems is the number of items of a receive operation belonging to a resource,
+// reported with the End*OpWithVolume functions.
+type ResourceItems struct {
+	Resource pcommon.Resource
+	NumItems int
+}
+
+// ProtoVersionOther is the version reported by EndTracesOpWithProtoVersion for any version
+// received after maxProtoVersions distinct ones.
+const ProtoVersionOther = "other"
+
+// opStartTimeKey is the context key for the start time of an export operation.
+type opStartTimeKey struct{}
+
+// receiveOpStartKey is the context key for the receiveOpStart of a receive operation. It is
+// distinct from opStartTimeKey, so an exporter never reads the start time of the receiver.
+type receiveOpStartKey struct{}
+
+// receiveOpStart is the start of a receive operation, needed to record its first byte latency.
+type receiveOpStart struct {
+	time     time.Time
+	dataType component.DataType
+}
+
+// Connection kinds used to break down the connections counted by RecordConnection.
+const (
+	// ConnectionNew is a connection opened by a client.
+	ConnectionNew = "new"
+	// ConnectionReused is a connection kept alive by a client for another request.
+	ConnectionReused = "reused"
+)
+
+// Clock skew ranges used to break down the accepted spans by EndTracesOpWithSkew.
+const (
+	// ClockSkewOK is the range of data with timestamps close to the receiver clock.
+	ClockSkewOK = "ok"
+	// ClockSkewFuture is the range of data with timestamps ahead of the receiver clock.
+	ClockSkewFuture = "future"
+	// ClockSkewStale is the range of data with timestamps far in the past.
+	ClockSkewStale = "stale"
+	// ClockSkewOther is used for any range not listed above.
+	ClockSkewOther = "other"
 )
 
 // Receiver is a helper to add observability to a receiver.Receiver.
 type Receiver struct {
-	level          configtelemetry.Level
-	spanNamePrefix string
-	transport      string
-	longLivedCtx   bool
-	mutators       []tag.Mutator
-	tracer         trace.Tracer
-	meter          metric.Meter
-	logger         *zap.Logger
+	level           *atomicLevel
+	signalLevels    SignalLevels
+	spanNamePrefix  string
+	metricPrefix    string
+	ocMeasures      receiverMeasures
+	transport       string
+	longLivedCtx    bool
+	statusMapper    StatusMapper
+	baggageKeys     []string
+	recordTenants   bool
+	volumeKey       string
+	recordDeadline  bool
+	recordPipeline  bool
+	recorder        *fanoutRecorder
+	spanMinDuration time.Duration
+	mutators        []tag.Mutator
+	tracer          trace.Tracer
+	meter           metric.Meter
+	logger          *zap.Logger
 
 	useOtelForMetrics bool
 	otelAttrs         []attribute.KeyValue
+	interner          *attrsInterner
 
 	acceptedSpansCounter        instrument.Int64Counter
 	refusedSpansCounter         instrument.Int64Counter
@@ -59,8 +158,122 @@ type Receiver struct {
 	refusedMetricPointsCounter  instrument.Int64Counter
 	acceptedLogRecordsCounter   instrument.Int64Counter
 	refusedLogRecordsCounter    instrument.Int64Counter
+	// itemCounters records the accepted and refused items together, see counterGroups.
+	itemCounters              *counterGroups
+	acceptedSpanEventsCounter *lazyInt64Counter
+	refusedSpanEventsCounter  *lazyInt64Counter
+	acceptedSpanLinksCounter  *lazyInt64Counter
+	refusedSpanLinksCounter   *lazyInt64Counter
+
+	acceptedSpansByClockSkewCounter    *lazyInt64Counter
+	acceptedSpansBySampledCounter      *lazyInt64Counter
+	convertedSpansCounter              *lazyInt64Counter
+	acceptedSpansByTenantCounter       *lazyInt64Counter
+	refusedSpansByTenantCounter        *lazyInt64Counter
+	refusedSpansByStatusCodeCounter    *lazyInt64Counter
+	acceptedSpansByProtoVersionCounter *lazyInt64Counter
+
+	acceptedSpansByVolumeCounter        *lazyInt64Counter
+	acceptedMetricPointsByVolumeCounter *lazyInt64Counter
+	acceptedLogRecordsByVolumeCounter   *lazyInt64Counter
+
+	// protoVersions holds the versions reported by EndTracesOpWithProtoVersion so far.
+	protoVersionsMu sync.Mutex
+	protoVersions   map[string]struct{}
+
+	acceptedResourcesCounter *lazyInt64Counter
+	acceptedScopesCounter    *lazyInt64Counter
+
+	acceptedLogRecordBytesCounter *lazyInt64Counter
+	schemaMismatchesCounter       *lazyInt64Counter
+	authFailuresCounter           *lazyInt64Counter
+	readErrorsCounter             *lazyInt64Counter
+	keepalivesCounter             *lazyInt64Counter
+	validationErrorsCounter       *lazyInt64Counter
+	streamClosesCounter           *lazyInt64Counter
+
+	validationFields    map[string]struct{}
+	emptyBatchesCounter *lazyInt64Counter
+	connectionsCounter  *lazyInt64Counter
+
+	activeConnectionsMu    sync.Mutex
+	activeConnections      int64
+	activeConnectionsGauge *lazyInt64UpDownCounter
+
+	distinctResources      *windowedEstimator
+	distinctResourcesGauge *lazyInt64UpDownCounter
+	distinctTraces         *windowedEstimator
+	distinctTracesGauge    *lazyInt64UpDownCounter
+
+	// acceptedItems holds the items accepted so far by data type, which the items per core
+	// gauge is derived from. They are nil unless RecordItemsPerCore is enabled and the
+	// metrics level is detailed when the receiver is created.
+	acceptedItemsMu   sync.Mutex
+	acceptedItems     map[component.DataType]int64
+	itemsPerCoreGauge instrument.Float64ObservableGauge
+	// gomaxprocs returns the number of cores the accepted items are divided by.
+	gomaxprocs func() int
+
+	// now returns the current time, used to reset the distinct resources estimate.
+	now func() time.Time
+
+	firstByteLatencyHistogram  *lazyFloat64Histogram
+	deadlineRemainingHistogram *lazyFloat64Histogram
+	parseDurationHistogram     *lazyFloat64Histogram
+	attributesPerSpanHistogram *lazyFloat64Histogram
+
+	overhead overheadRecorder
+}
+
+// receiverMeasures are the OpenCensus measures recorded by a Receiver for each data type.
+type receiverMeasures struct {
+	acceptedSpans        *stats.Int64Measure
+	refusedSpans         *stats.Int64Measure
+	acceptedMetricPoints *stats.Int64Measure
+	refusedMetricPoints  *stats.Int64Measure
+	acceptedLogRecords   *stats.Int64Measure
+	refusedLogRecords    *stats.Int64Measure
+	acceptedSpanEvents   *stats.Int64Measure
+	refusedSpanEvents    *stats.Int64Measure
+	acceptedSpanLinks    *stats.Int64Measure
+	refusedSpanLinks     *stats.Int64Measure
+	spansByClockSkew     *stats.Int64Measure
+	// emptyBatches and itemsPerCore are nil for connectors, which do not receive requests from clients.
+	emptyBatches *stats.Int64Measure
+	itemsPerCore *stats.Float64Measure
 }
 
+var (
+	receiverKindMeasures = receiverMeasures{
+		acceptedSpans:        obsmetrics.ReceiverAcceptedSpans,
+		refusedSpans:         obsmetrics.ReceiverRefusedSpans,
+		acceptedMetricPoints: obsmetrics.ReceiverAcceptedMetricPoints,
+		refusedMetricPoints:  obsmetrics.ReceiverRefusedMetricPoints,
+		acceptedLogRecords:   obsmetrics.ReceiverAcceptedLogRecords,
+		refusedLogRecords:    obsmetrics.ReceiverRefusedLogRecords,
+		acceptedSpanEvents:   obsmetrics.ReceiverAcceptedSpanEvents,
+		refusedSpanEvents:    obsmetrics.ReceiverRefusedSpanEvents,
+		acceptedSpanLinks:    obsmetrics.ReceiverAcceptedSpanLinks,
+		refusedSpanLinks:     obsmetrics.ReceiverRefusedSpanLinks,
+		spansByClockSkew:     obsmetrics.ReceiverAcceptedSpansByClockSkew,
+		emptyBatches:         obsmetrics.ReceiverEmptyBatches,
+		itemsPerCore:         obsmetrics.ReceiverItemsPerCore,
+	}
+	connectorKindReceiverMeasures = receiverMeasures{
+		acceptedSpans:        obsmetrics.ConnectorAcceptedSpans,
+		refusedSpans:         obsmetrics.ConnectorRefusedSpans,
+		acceptedMetricPoints: obsmetrics.ConnectorAcceptedMetricPoints,
+		refusedMetricPoints:  obsmetrics.ConnectorRefusedMetricPoints,
+		acceptedLogRecords:   obsmetrics.ConnectorAcceptedLogRecords,
+		refusedLogRecords:    obsmetrics.ConnectorRefusedLogRecords,
+		acceptedSpanEvents:   obsmetrics.ConnectorAcceptedSpanEvents,
+		refusedSpanEvents:    obsmetrics.ConnectorRefusedSpanEvents,
+		acceptedSpanLinks:    obsmetrics.ConnectorAcceptedSpanLinks,
+		refusedSpanLinks:     obsmetrics.ConnectorRefusedSpanLinks,
+		spansByClockSkew:     obsmetrics.ConnectorAcceptedSpansByClockSkew,
+	}
+)
+
 // ReceiverSettings are settings for creating an Receiver.
 type ReceiverSettings struct {
 	ReceiverID component.ID
@@ -72,6 +285,66 @@ type ReceiverSettings struct {
 	// operations without a corresponding new context per operation.
 	LongLivedCtx           bool
 	ReceiverCreateSettings receiver.CreateSettings
+	// StatusMapper is used to set the status of the operation spans from the
+	// returned errors. If nil, any error sets the status to codes.Error.
+	StatusMapper StatusMapper
+	// SignalLevels overrides the metrics level of the ReceiverCreateSettings for individual signals.
+	SignalLevels SignalLevels
+	// MetricNaming is the strategy used to build the metric names, defaults to MetricNamingDefault.
+	MetricNaming MetricNaming
+	// AttachBaggageKeys lists the keys of the baggage members of the context passed to
+	// the Start*Op functions that are added as attributes to the operation span.
+	// They are never added to the metrics, to keep their cardinality low.
+	AttachBaggageKeys []string
+	// InstanceID, if not empty, is added as the collector.instance.id tag to all the metrics,
+	// e.g. the service.instance.id of the collector, for a backend aggregating the metrics of
+	// a fleet of collectors. With OpenCensus, the tag is only exported if the views are
+	// registered with it, see the include_instance_id option of the telemetry metrics.
+	InstanceID string
+	// RecordTenants enables breaking down the spans reported with EndTracesOpForTenant
+	// by tenant. Since each tenant adds a new time series to the metrics, it should only
+	// be enabled when the number of tenants is bounded and known to be small.
+	RecordTenants bool
+	// SpanMinDuration, when positive, only keeps the spans of the operations that took at
+	// least this long or failed, to reduce the volume of traces. The spans are deferred
+	// until the operation ends, so the spans started during it are children of its parent.
+	SpanMinDuration time.Duration
+	// Recorder records the item counters of the operations instead of OpenCensus or
+	// OpenTelemetry, see Recorder. If nil, they are recorded like the other metrics.
+	Recorder Recorder
+	// EstimateDistinctResources enables estimating the number of distinct resources
+	// reported with RecordResource, to debug cardinality explosions. It is a diagnostics
+	// feature which only has an effect when the metrics level is detailed.
+	EstimateDistinctResources bool
+	// EstimateDistinctTraces enables estimating the number of distinct trace IDs reported
+	// with ObserveTraceID, e.g. to analyze the deduplication or the tail sampling of the
+	// traces. It is a diagnostics feature which only has an effect when the metrics level
+	// is detailed.
+	EstimateDistinctTraces bool
+	// RecordDeadlineRemaining enables recording the time remaining until the deadline of the
+	// context passed to the Start*Op functions, to detect the clients sending requests that
+	// are about to expire. The operations whose context has no deadline are not recorded.
+	RecordDeadlineRemaining bool
+	// RecordPipelineStart adds the start time of the Start*Op functions to the returned context,
+	// for the exporters with RecordPipelineLatency enabled to record the end-to-end latency of
+	// the pipeline. It only works when the context is passed along the pipeline, the components
+	// handling the data asynchronously, e.g. the batch processor, lose it.
+	RecordPipelineStart bool
+	// ValidationFields lists the names of the fields validated by the receiver, reported by
+	// RecordValidationError. They should be a fixed set of names, e.g. "trace_id" or
+	// "span.name", not values derived from the data.
+	ValidationFields []string
+	// RecordItemsPerCore enables reporting the items accepted by the receiver divided by the
+	// number of cores available to the collector, as read from runtime.GOMAXPROCS, to compare
+	// the load of collectors running on hosts of different sizes. It is a diagnostics feature
+	// which only has an effect when the metrics level is detailed.
+	RecordItemsPerCore bool
+	// VolumeAttributeKey is the key of a resource attribute, e.g. "service.namespace", by which
+	// the items accepted in the operations completed with the End*OpWithVolume functions are
+	// broken down, e.g. for chargeback. Every value of the attribute adds new time series to the
+	// metrics, so it should only be set for an attribute with a small and bounded set of values.
+	// If empty, the End*OpWithVolume functions are the same as the End*Op ones.
+	VolumeAttributeKey string
 }
 
 // NewReceiver creates a new Receiver.
@@ -80,33 +353,97 @@ func NewReceiver(cfg ReceiverSettings) (*Receiver, error) {
 }
 
 func newReceiver(cfg ReceiverSettings, useOtel bool) (*Receiver, error) {
+	return newReceiverForKind(component.KindReceiver, cfg, useOtel)
+}
+
+// newReceiverForKind creates a Receiver reporting under the prefix and tag
+// of the given kind of component, which must be a receiver or a connector.
+func newReceiverForKind(kind component.Kind, cfg ReceiverSettings, useOtel bool) (*Receiver, error) {
+	key, tagKey, measures, scope := obsmetrics.ReceiverKey, obsmetrics.TagKeyReceiver, receiverKindMeasures, receiverScope
+	if kind == component.KindConnector {
+		key, tagKey, measures, scope = obsmetrics.ConnectorKey, obsmetrics.TagKeyConnector, connectorKindReceiverMeasures, connectorScope
+	}
+
 	rec := &Receiver{
-		level:          cfg.ReceiverCreateSettings.TelemetrySettings.MetricsLevel,
-		spanNamePrefix: obsmetrics.ReceiverPrefix + cfg.ReceiverID.String(),
-		transport:      cfg.Transport,
-		longLivedCtx:   cfg.LongLivedCtx,
+		level:            newAtomicLevel(cfg.ReceiverCreateSettings.TelemetrySettings.MetricsLevel),
+		signalLevels:     cfg.SignalLevels,
+		spanNamePrefix:   key + nameSep + cfg.ReceiverID.String(),
+		metricPrefix:     cfg.MetricNaming.metricPrefix(key),
+		ocMeasures:       measures,
+		transport:        cfg.Transport,
+		longLivedCtx:     cfg.LongLivedCtx,
+		statusMapper:     cfg.StatusMapper,
+		baggageKeys:      cfg.AttachBaggageKeys,
+		recordTenants:    cfg.RecordTenants,
+		volumeKey:        cfg.VolumeAttributeKey,
+		recordDeadline:   cfg.RecordDeadlineRemaining,
+		recordPipeline:   cfg.RecordPipelineStart,
+		spanMinDuration:  cfg.SpanMinDuration,
+		now:              time.Now,
+		gomaxprocs:       currentGOMAXPROCS,
+		protoVersions:    make(map[string]struct{}),
+		validationFields: make(map[string]struct{}, len(cfg.ValidationFields)),
 		mutators: []tag.Mutator{
-			tag.Upsert(obsmetrics.TagKeyReceiver, cfg.ReceiverID.String(), tag.WithTTL(tag.TTLNoPropagation)),
-			tag.Upsert(obsmetrics.TagKeyTransport, cfg.Transport, tag.WithTTL(tag.TTLNoPropagation)),
+			tag.Upsert(tagKey, cfg.ReceiverID.String(), tag.WithTTL(tag.TTLNoPropagation)),
 		},
 		tracer: cfg.ReceiverCreateSettings.TracerProvider.Tracer(cfg.ReceiverID.String()),
-		meter:  cfg.ReceiverCreateSettings.MeterProvider.Meter(receiverScope),
+		meter:  cfg.ReceiverCreateSettings.MeterProvider.Meter(scope),
 		logger: cfg.ReceiverCreateSettings.Logger,
 
 		useOtelForMetrics: useOtel,
 		otelAttrs: []attribute.KeyValue{
-			attribute.String(obsmetrics.ReceiverKey, cfg.ReceiverID.String()),
-			attribute.String(obsmetrics.TransportKey, cfg.Transport),
+			attribute.String(key, cfg.ReceiverID.String()),
 		},
 	}
+	// Connectors are not associated with any transport.
+	if kind != component.KindConnector {
+		rec.mutators = append(rec.mutators, tag.Upsert(obsmetrics.TagKeyTransport, cfg.Transport, tag.WithTTL(tag.TTLNoPropagation)))
+		rec.otelAttrs = append(rec.otelAttrs, attribute.String(obsmetrics.TransportKey, cfg.Transport))
+	}
+	instanceMutators, instanceAttrs := instanceIDTags(cfg.InstanceID)
+	rec.mutators = append(rec.mutators, instanceMutators...)
+	rec.otelAttrs = append(rec.otelAttrs, instanceAttrs...)
+	rec.interner = newAttrsInterner(rec.otelAttrs, rec.mutators)
+	for _, field := range cfg.ValidationFields {
+		rec.validationFields[field] = struct{}{}
+	}
+	primary := cfg.Recorder
+	if primary == nil {
+		primary = backendRecorder{receiver: rec}
+	}
+	rec.recorder = newFanoutRecorder(primary)
+	if cfg.EstimateDistinctResources && rec.level.Load() == configtelemetry.LevelDetailed {
+		rec.distinctResources = newWindowedEstimator(distinctResourcesWindow)
+	}
+	if cfg.EstimateDistinctTraces && rec.level.Load() == configtelemetry.LevelDetailed {
+		rec.distinctTraces = newWindowedEstimator(distinctTracesWindow)
+	}
+	if cfg.RecordItemsPerCore && measures.itemsPerCore != nil && rec.level.Load() == configtelemetry.LevelDetailed {
+		rec.acceptedItems = make(map[component.DataType]int64)
+	}
 
-	if err := rec.createOtelMetrics(); err != nil {
+	overhead, err := newOverheadRecorder(key, rec.level, cfg.MetricNaming, rec.meter, useOtel, instanceMutators, instanceAttrs)
+	if err != nil {
+		return nil, err
+	}
+	rec.overhead = overhead
+
+	if err = rec.createOtelMetrics(); err != nil {
 		return nil, err
 	}
 
 	return rec, nil
 }
 
+// SetLevel changes the metrics level of the receiver while it is running, e.g. to shed the
+// cost of the metrics under load. The operations read the level on each call, so the change
+// applies to the next ones. The SignalLevels overrides keep precedence over the level, and
+// the distinct resources and traces estimators and the overhead recording, only set up if
+// the level is detailed at creation, are not enabled by raising the level later.
+func (rec *Receiver) SetLevel(level configtelemetry.Level) {
+	rec.level.Store(level)
+}
+
 func (rec *Receiver) createOtelMetrics() error {
 	if !rec.useOtelForMetrics {
 		return nil
@@ -115,55 +452,512 @@ func (rec *Receiver) createOtelMetrics() error {
 	var errors, err error
 
 	rec.acceptedSpansCounter, err = rec.meter.Int64Counter(
-		obsmetrics.ReceiverPrefix+obsmetrics.AcceptedSpansKey,
+		rec.metricPrefix+obsmetrics.AcceptedSpansKey,
 		instrument.WithDescription("Number of spans successfully pushed into the pipeline."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitSpans),
 	)
 	errors = multierr.Append(errors, err)
 
 	rec.refusedSpansCounter, err = rec.meter.Int64Counter(
-		obsmetrics.ReceiverPrefix+obsmetrics.RefusedSpansKey,
+		rec.metricPrefix+obsmetrics.RefusedSpansKey,
 		instrument.WithDescription("Number of spans that could not be pushed into the pipeline."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitSpans),
 	)
 	errors = multierr.Append(errors, err)
 
 	rec.acceptedMetricPointsCounter, err = rec.meter.Int64Counter(
-		obsmetrics.ReceiverPrefix+obsmetrics.AcceptedMetricPointsKey,
+		rec.metricPrefix+obsmetrics.AcceptedMetricPointsKey,
 		instrument.WithDescription("Number of metric points successfully pushed into the pipeline."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitMetricPoints),
 	)
 	errors = multierr.Append(errors, err)
 
 	rec.refusedMetricPointsCounter, err = rec.meter.Int64Counter(
-		obsmetrics.ReceiverPrefix+obsmetrics.RefusedMetricPointsKey,
+		rec.metricPrefix+obsmetrics.RefusedMetricPointsKey,
 		instrument.WithDescription("Number of metric points that could not be pushed into the pipeline."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitMetricPoints),
 	)
 	errors = multierr.Append(errors, err)
 
 	rec.acceptedLogRecordsCounter, err = rec.meter.Int64Counter(
-		obsmetrics.ReceiverPrefix+obsmetrics.AcceptedLogRecordsKey,
+		rec.metricPrefix+obsmetrics.AcceptedLogRecordsKey,
 		instrument.WithDescription("Number of log records successfully pushed into the pipeline."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitLogRecords),
 	)
 	errors = multierr.Append(errors, err)
 
 	rec.refusedLogRecordsCounter, err = rec.meter.Int64Counter(
-		obsmetrics.ReceiverPrefix+obsmetrics.RefusedLogRecordsKey,
+		rec.metricPrefix+obsmetrics.RefusedLogRecordsKey,
 		instrument.WithDescription("Number of log records that could not be pushed into the pipeline."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitLogRecords),
 	)
 	errors = multierr.Append(errors, err)
 
+	rec.itemCounters = newCounterGroups(rec.otelAttrs, map[component.DataType][]instrument.Int64Counter{
+		component.DataTypeTraces:  {rec.acceptedSpansCounter, rec.refusedSpansCounter},
+		component.DataTypeMetrics: {rec.acceptedMetricPointsCounter, rec.refusedMetricPointsCounter},
+		component.DataTypeLogs:    {rec.acceptedLogRecordsCounter, rec.refusedLogRecordsCounter},
+	})
+
+	// The instruments of the optional metrics are only created when first recorded.
+	rec.acceptedSpanEventsCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.AcceptedSpanEventsKey,
+		instrument.WithDescription("Number of span events successfully pushed into the pipeline."),
+		instrument.WithUnit(obsmetrics.UnitSpanEvents),
+	)
+
+	rec.refusedSpanEventsCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.RefusedSpanEventsKey,
+		instrument.WithDescription("Number of span events that could not be pushed into the pipeline."),
+		instrument.WithUnit(obsmetrics.UnitSpanEvents),
+	)
+
+	rec.acceptedSpanLinksCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.AcceptedSpanLinksKey,
+		instrument.WithDescription("Number of span links successfully pushed into the pipeline."),
+		instrument.WithUnit(obsmetrics.UnitSpanLinks),
+	)
+
+	rec.refusedSpanLinksCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.RefusedSpanLinksKey,
+		instrument.WithDescription("Number of span links that could not be pushed into the pipeline."),
+		instrument.WithUnit(obsmetrics.UnitSpanLinks),
+	)
+
+	rec.acceptedSpansByClockSkewCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.AcceptedSpansByClockSkewKey,
+		instrument.WithDescription("Number of spans successfully pushed into the pipeline by clock skew range of their timestamps."),
+		instrument.WithUnit(obsmetrics.UnitSpans),
+	)
+
+	rec.acceptedSpansBySampledCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.AcceptedSpansBySampledKey,
+		instrument.WithDescription("Number of spans successfully pushed into the pipeline by whether they were sampled."),
+		instrument.WithUnit(obsmetrics.UnitSpans),
+	)
+
+	rec.convertedSpansCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.ConvertedSpansKey,
+		instrument.WithDescription("Number of spans successfully pushed into the pipeline after being converted from the format they were received in."),
+		instrument.WithUnit(obsmetrics.UnitSpans),
+	)
+
+	rec.acceptedSpansByTenantCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.AcceptedSpansByTenantKey,
+		instrument.WithDescription("Number of spans successfully pushed into the pipeline by tenant."),
+		instrument.WithUnit(obsmetrics.UnitSpans),
+	)
+
+	rec.refusedSpansByTenantCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.RefusedSpansByTenantKey,
+		instrument.WithDescription("Number of spans that could not be pushed into the pipeline by tenant."),
+		instrument.WithUnit(obsmetrics.UnitSpans),
+	)
+
+	rec.acceptedSpansByVolumeCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.AcceptedSpansByVolumeKey,
+		instrument.WithDescription("Number of spans successfully pushed into the pipeline by the value of the configured resource attribute."),
+		instrument.WithUnit(obsmetrics.UnitSpans),
+	)
+
+	rec.acceptedMetricPointsByVolumeCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.AcceptedMetricPointsByVolumeKey,
+		instrument.WithDescription("Number of metric points successfully pushed into the pipeline by the value of the configured resource attribute."),
+		instrument.WithUnit(obsmetrics.UnitMetricPoints),
+	)
+
+	rec.acceptedLogRecordsByVolumeCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.AcceptedLogRecordsByVolumeKey,
+		instrument.WithDescription("Number of log records successfully pushed into the pipeline by the value of the configured resource attribute."),
+		instrument.WithUnit(obsmetrics.UnitLogRecords),
+	)
+
+	rec.refusedSpansByStatusCodeCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.RefusedSpansByStatusCodeKey,
+		instrument.WithDescription("Number of spans that could not be pushed into the pipeline by the HTTP status code returned to the client."),
+		instrument.WithUnit(obsmetrics.UnitSpans),
+	)
+
+	rec.acceptedSpansByProtoVersionCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.AcceptedSpansByProtoVersionKey,
+		instrument.WithDescription("Number of spans successfully pushed into the pipeline by the version of the protocol schema they were received in."),
+		instrument.WithUnit(obsmetrics.UnitSpans),
+	)
+
+	rec.acceptedResourcesCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.AcceptedResourcesKey,
+		instrument.WithDescription("Number of resource groupings successfully pushed into the pipeline."),
+		instrument.WithUnit(obsmetrics.UnitResources),
+	)
+
+	rec.acceptedScopesCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.AcceptedScopesKey,
+		instrument.WithDescription("Number of instrumentation scope groupings successfully pushed into the pipeline."),
+		instrument.WithUnit(obsmetrics.UnitScopes),
+	)
+
+	rec.acceptedLogRecordBytesCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.AcceptedLogRecordBytesKey,
+		instrument.WithDescription("Size in bytes of the log records successfully pushed into the pipeline."),
+		instrument.WithUnit("By"),
+	)
+
+	rec.schemaMismatchesCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.SchemaMismatchesKey,
+		instrument.WithDescription("Number of times data was received with an unexpected or missing schema URL."),
+		instrument.WithUnit(obsmetrics.UnitSchemaMismatches),
+	)
+
+	rec.authFailuresCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.AuthFailuresKey,
+		instrument.WithDescription("Number of requests rejected because they failed authentication."),
+		instrument.WithUnit(obsmetrics.UnitFailures),
+	)
+
+	rec.readErrorsCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.ReadErrorsKey,
+		instrument.WithDescription("Number of requests whose body could not be read."),
+		instrument.WithUnit(obsmetrics.UnitFailures),
+	)
+
+	rec.validationErrorsCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.ValidationErrorsKey,
+		instrument.WithDescription("Number of times data was rejected because a field failed validation, by field."),
+		instrument.WithUnit(obsmetrics.UnitFailures),
+	)
+
+	rec.streamClosesCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.StreamClosesKey,
+		instrument.WithDescription("Number of client streams closed by the receiver, by reason."),
+		instrument.WithUnit(obsmetrics.UnitStreams),
+	)
+
+	rec.keepalivesCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.KeepalivesKey,
+		instrument.WithDescription("Number of keepalive pings exchanged with the clients over long-lived streams."),
+		instrument.WithUnit(obsmetrics.UnitKeepalives),
+	)
+
+	rec.connectionsCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.ConnectionsKey,
+		instrument.WithDescription("Number of connections accepted by the receiver, by whether they are new or reused."),
+		instrument.WithUnit(obsmetrics.UnitConnections),
+	)
+
+	rec.activeConnectionsGauge = newLazyInt64UpDownCounter(rec.meter,
+		rec.metricPrefix+obsmetrics.ActiveConnectionsKey,
+		instrument.WithDescription("Number of connections currently open on the receiver."),
+		instrument.WithUnit(obsmetrics.UnitConnections),
+	)
+
+	rec.distinctResourcesGauge = newLazyInt64UpDownCounter(rec.meter,
+		rec.metricPrefix+obsmetrics.DistinctResourcesEstimateKey,
+		instrument.WithDescription("Estimated number of distinct resource attribute sets received in the last minute."),
+		instrument.WithUnit(obsmetrics.UnitResources),
+	)
+
+	rec.distinctTracesGauge = newLazyInt64UpDownCounter(rec.meter,
+		rec.metricPrefix+obsmetrics.DistinctTracesEstimateKey,
+		instrument.WithDescription("Estimated number of distinct trace IDs received in the last minute."),
+		instrument.WithUnit(obsmetrics.UnitTraces),
+	)
+
+	// Like acceptedItems, the gauge is only created if RecordItemsPerCore is enabled
+	// and the metrics level is detailed.
+	if rec.acceptedItems != nil {
+		rec.itemsPerCoreGauge, err = rec.meter.Float64ObservableGauge(
+			rec.metricPrefix+obsmetrics.ItemsPerCoreKey,
+			instrument.WithDescription("Number of items successfully pushed into the pipeline divided by the number of cores available to the collector, by signal."),
+			instrument.WithUnit(obsmetrics.UnitItems),
+			instrument.WithFloat64Callback(func(_ context.Context, o instrument.Float64Observer) error {
+				if rec.level.Load() != configtelemetry.LevelDetailed {
+					return nil
+				}
+				procs := rec.gomaxprocs()
+				rec.acceptedItemsMu.Lock()
+				defer rec.acceptedItemsMu.Unlock()
+				for dataType, accepted := range rec.acceptedItems {
+					o.Observe(itemsPerCore(accepted, procs), rec.interner.with(obsmetrics.TagKeySignal, string(dataType)).attrs()...)
+				}
+				return nil
+			}),
+		)
+		errors = multierr.Append(errors, err)
+	}
+
+	rec.emptyBatchesCounter = newLazyInt64Counter(rec.meter,
+		rec.metricPrefix+obsmetrics.EmptyBatchesKey,
+		instrument.WithDescription("Number of receive operations that successfully pushed no items into the pipeline."),
+		instrument.WithUnit(obsmetrics.UnitBatches),
+	)
+
+	rec.firstByteLatencyHistogram = newLazyFloat64Histogram(rec.meter,
+		rec.metricPrefix+obsmetrics.FirstByteLatencyKey,
+		instrument.WithDescription("Time from the start of the receive operation until the first data was received."),
+		instrument.WithUnit("ms"),
+	)
+
+	rec.deadlineRemainingHistogram = newLazyFloat64Histogram(rec.meter,
+		rec.metricPrefix+obsmetrics.DeadlineRemainingKey,
+		instrument.WithDescription("Time remaining until the deadline of the request when the receive operation started."),
+		instrument.WithUnit("ms"),
+	)
+
+	rec.parseDurationHistogram = newLazyFloat64Histogram(rec.meter,
+		rec.metricPrefix+obsmetrics.ParseDurationKey,
+		instrument.WithDescription("Time spent decoding the data received, by format."),
+		instrument.WithUnit("ms"),
+	)
+
+	rec.attributesPerSpanHistogram = newLazyFloat64Histogram(rec.meter,
+		rec.metricPrefix+obsmetrics.AttributesPerSpanKey,
+		instrument.WithDescription("Average number of attributes of the spans successfully pushed into the pipeline, per receive operation."),
+		instrument.WithUnit(obsmetrics.UnitAttributes),
+	)
+
 	return errors
 }
 
+// AddRecorder adds a Recorder which the item counters of the Receiver are recorded with,
+// in addition to the one of its settings or to the default backend. It returns the ID to
+// remove it with RemoveRecorder. A recorder added twice records the counters twice.
+func (rec *Receiver) AddRecorder(r Recorder) RecorderID {
+	return rec.recorder.add(r)
+}
+
+// RemoveRecorder removes the Recorder added with AddRecorder which returned the given ID.
+// It is a no-op if there is none, e.g. if it was already removed.
+func (rec *Receiver) RemoveRecorder(id RecorderID) {
+	rec.recorder.remove(id)
+}
+
 // StartTracesOp is called when a request is received from a client.
 // The returned context should be used in other calls to the obsreport functions
 // dealing with the same receive operation.
 func (rec *Receiver) StartTracesOp(operationCtx context.Context) context.Context {
-	return rec.startOp(operationCtx, obsmetrics.ReceiveTraceDataOperationSuffix)
+	return rec.startOp(operationCtx, obsmetrics.ReceiveTraceDataOperationSuffix, component.DataTypeTraces)
+}
+
+// StartTracesOpWithRemoteParent is like StartTracesOp but the span of the operation
+// is a child of the given remote span context, usually extracted from the headers
+// of the incoming request, so the trace continues the one of the client. If the
+// receiver was created with LongLivedCtx the span is linked to the remote span
+// context instead. An invalid remote span context is ignored.
+func (rec *Receiver) StartTracesOpWithRemoteParent(operationCtx context.Context, remote trace.SpanContext) context.Context {
+	if remote.IsValid() {
+		operationCtx = trace.ContextWithRemoteSpanContext(operationCtx, remote)
+	}
+	return rec.startOp(operationCtx, obsmetrics.ReceiveTraceDataOperationSuffix, component.DataTypeTraces)
+}
+
+// StartTracesOpWithHandle is like StartTracesOp but it also returns an OpHandle,
+// which can be passed to EndTracesOp instead of the returned context, see OpHandle.
+func (rec *Receiver) StartTracesOpWithHandle(operationCtx context.Context) (context.Context, OpHandle) {
+	return newOpHandle(rec.StartTracesOp(operationCtx))
+}
+
+// RecordFirstByte is called by streaming receivers when the first data of an operation
+// started with one of the Start*Op functions is received. It adds an event to the span
+// of the operation and, if the metrics level of its data type is detailed, records the
+// time since the start of the operation, which separates the connection setup latency
+// from the processing latency. It should be called at most once per operation.
+func (rec *Receiver) RecordFirstByte(receiverCtx context.Context) {
+	trace.SpanFromContext(receiverCtx).AddEvent(firstByteEventName)
+
+	start, ok := receiverCtx.Value(receiveOpStartKey{}).(receiveOpStart)
+	if !ok || rec.levelFor(start.dataType) != configtelemetry.LevelDetailed {
+		return
+	}
+	latency := float64(time.Since(start.time)) / float64(time.Millisecond)
+	if rec.useOtelForMetrics {
+		rec.firstByteLatencyHistogram.Record(receiverCtx, latency, withAttrs(rec.otelAttrs)...)
+	} else {
+		stats.Record(receiverCtx, obsmetrics.ReceiverFirstByteLatency.M(latency))
+	}
+}
+
+// RecordParseDuration is called by receivers after decoding the data received in the
+// given format, e.g. unmarshaling a protobuf or JSON payload, with the time it took.
+// This separates the time spent parsing the data from the time spent processing it.
+func (rec *Receiver) RecordParseDuration(ctx context.Context, format string, d time.Duration) {
+	if rec.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	duration := float64(d) / float64(time.Millisecond)
+	formatAttrs := rec.interner.with(obsmetrics.TagKeyFormat, format)
+	if rec.useOtelForMetrics {
+		rec.parseDurationHistogram.Record(ctx, duration, formatAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(ctx, formatAttrs.mutators, obsmetrics.ReceiverParseDuration.M(duration))
+	}
+}
+
+// RecordSchemaMismatch is called when the receiver accepts data with an unexpected
+// or missing schema URL, which usually means the client uses outdated semantic conventions.
+func (rec *Receiver) RecordSchemaMismatch(ctx context.Context) {
+	if rec.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	if rec.useOtelForMetrics {
+		rec.schemaMismatchesCounter.Add(ctx, 1, withAttrs(rec.otelAttrs)...)
+	} else {
+		_ = stats.RecordWithTags(ctx, rec.mutators, obsmetrics.ReceiverSchemaMismatches.M(1))
+	}
+}
+
+// RecordAuthFailure is called when the receiver rejects a request because it failed
+// authentication, e.g. by the configured auth extension. These requests are counted
+// separately from the refused items so that security events can be alerted on.
+func (rec *Receiver) RecordAuthFailure(ctx context.Context) {
+	if rec.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	if rec.useOtelForMetrics {
+		rec.authFailuresCounter.Add(ctx, 1, withAttrs(rec.otelAttrs)...)
+	} else {
+		_ = stats.RecordWithTags(ctx, rec.mutators, obsmetrics.ReceiverAuthFailures.M(1))
+	}
+}
+
+// RecordReadError is called when the receiver fails to read the body of a request, e.g.
+// because the client disconnected during the upload. These failures are counted separately
+// from the data that could not be decoded, to surface flaky clients and networks.
+func (rec *Receiver) RecordReadError(ctx context.Context) {
+	if rec.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	if rec.useOtelForMetrics {
+		rec.readErrorsCounter.Add(ctx, 1, withAttrs(rec.otelAttrs)...)
+	} else {
+		_ = stats.RecordWithTags(ctx, rec.mutators, obsmetrics.ReceiverReadErrors.M(1))
+	}
+}
+
+// RecordValidationError is called when the receiver rejects data because the given field
+// failed validation, e.g. an invalid trace ID, to find out which fields the clients most
+// often get wrong. The field must be one of ReceiverSettings.ValidationFields, any other
+// field is reported as ValidationFieldOther.
+// The rejected items are still reported as refused by the End*Op functions.
+func (rec *Receiver) RecordValidationError(ctx context.Context, field string) {
+	if rec.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	if _, ok := rec.validationFields[field]; !ok {
+		field = ValidationFieldOther
+	}
+	fieldAttrs := rec.interner.with(obsmetrics.TagKeyValidationField, field)
+	if rec.useOtelForMetrics {
+		rec.validationErrorsCounter.Add(ctx, 1, fieldAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(ctx, fieldAttrs.mutators, obsmetrics.ReceiverValidationErrors.M(1))
+	}
+}
+
+// RecordKeepalive is called by streaming receivers, e.g. over gRPC, for each keepalive ping
+// exchanged with a client. Comparing the pings with the connections closed helps to diagnose
+// the idle connections dropped by intermediaries, such as load balancers.
+func (rec *Receiver) RecordKeepalive(ctx context.Context) {
+	if rec.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	if rec.useOtelForMetrics {
+		rec.keepalivesCounter.Add(ctx, 1, withAttrs(rec.otelAttrs)...)
+	} else {
+		_ = stats.RecordWithTags(ctx, rec.mutators, obsmetrics.ReceiverKeepalives.M(1))
+	}
+}
+
+// RecordStreamClose is called by streaming receivers, e.g. over gRPC, when a client stream
+// ends, to tell apart the streams closed by the clients from the ones lost to errors or
+// timeouts. The reason should be StreamCloseEOF, StreamCloseError, StreamCloseShutdown or
+// StreamCloseIdleTimeout, any other reason is reported as StreamCloseOther.
+func (rec *Receiver) RecordStreamClose(ctx context.Context, reason string) {
+	if rec.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	reasonAttrs := rec.interner.with(obsmetrics.TagKeyStreamClose, streamCloseReason(reason))
+	if rec.useOtelForMetrics {
+		rec.streamClosesCounter.Add(ctx, 1, reasonAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(ctx, reasonAttrs.mutators, obsmetrics.ReceiverStreamCloses.M(1))
+	}
+}
+
+// RecordConnection is called when the receiver accepts a connection, with isNew false
+// when a client reuses a connection it kept alive for another request. The connections
+// are counted by kind to surface the churn, and the new ones are added to the
+// active_connections gauge until RecordConnectionClosed is called for them, to surface leaks.
+func (rec *Receiver) RecordConnection(ctx context.Context, isNew bool) {
+	if rec.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	kind := ConnectionReused
+	if isNew {
+		kind = ConnectionNew
+	}
+	connectionAttrs := rec.interner.with(obsmetrics.TagKeyConnection, kind)
+	if rec.useOtelForMetrics {
+		rec.connectionsCounter.Add(ctx, 1, connectionAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(ctx, connectionAttrs.mutators, obsmetrics.ReceiverConnections.M(1))
+	}
+	if isNew {
+		rec.addActiveConnections(ctx, 1)
+	}
+}
+
+// RecordConnectionClosed is called when a connection counted as new by RecordConnection
+// is closed, by the client or the receiver.
+func (rec *Receiver) RecordConnectionClosed(ctx context.Context) {
+	if rec.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	rec.addActiveConnections(ctx, -1)
+}
+
+func (rec *Receiver) addActiveConnections(ctx context.Context, delta int64) {
+	rec.activeConnectionsMu.Lock()
+	defer rec.activeConnectionsMu.Unlock()
+	rec.activeConnections += delta
+	if rec.useOtelForMetrics {
+		rec.activeConnectionsGauge.Add(ctx, delta, withAttrs(rec.otelAttrs)...)
+	} else {
+		_ = stats.RecordWithTags(ctx, rec.mutators, obsmetrics.ReceiverActiveConnections.M(rec.activeConnections))
+	}
+}
+
+// RecordResource adds the resource of the data received to the estimate of the number
+// of distinct resource attribute sets received, if enabled with EstimateDistinctResources.
+// The estimate is computed with a HyperLogLog sketch, within about 2% of the actual number,
+// over windows of distinctResourcesWindow and reported as the distinct_resources_estimate gauge.
+func (rec *Receiver) RecordResource(ctx context.Context, resource pcommon.Resource) {
+	if rec.distinctResources == nil || rec.level.Load() != configtelemetry.LevelDetailed {
+		return
+	}
+	rec.distinctResources.add(rec.now(), hashAttributes(resource.Attributes()), func(estimate, delta int64) {
+		if rec.useOtelForMetrics {
+			rec.distinctResourcesGauge.Add(ctx, delta, withAttrs(rec.otelAttrs)...)
+		} else {
+			_ = stats.RecordWithTags(ctx, rec.mutators, obsmetrics.ReceiverDistinctResourcesEstimate.M(estimate))
+		}
+	})
+}
+
+// ObserveTraceID adds the ID of a trace received to the estimate of the number of distinct
+// traces received, if enabled with EstimateDistinctTraces. Like for RecordResource, the
+// estimate is computed with a HyperLogLog sketch, over windows of distinctTracesWindow, and
+// reported as the distinct_traces_estimate gauge.
+func (rec *Receiver) ObserveTraceID(ctx context.Context, id pcommon.TraceID) {
+	if rec.distinctTraces == nil || rec.level.Load() != configtelemetry.LevelDetailed {
+		return
+	}
+	rec.distinctTraces.add(rec.now(), hashTraceID(id), func(estimate, delta int64) {
+		if rec.useOtelForMetrics {
+			rec.distinctTracesGauge.Add(ctx, delta, withAttrs(rec.otelAttrs)...)
+		} else {
+			_ = stats.RecordWithTags(ctx, rec.mutators, obsmetrics.ReceiverDistinctTracesEstimate.M(estimate))
+		}
+	})
 }
 
 // EndTracesOp completes the receive operation that was started with
@@ -177,11 +971,249 @@ func (rec *Receiver) EndTracesOp(
 	rec.endOp(receiverCtx, format, numReceivedSpans, err, component.DataTypeTraces)
 }
 
+// EndTracesOpDetailed completes the receive operation that was started with
+// StartTracesOp, additionally recording the number of span events and span links
+// carried by the received spans. This gives a better estimate of the work done
+// than the number of spans alone when spans have many events or links.
+// When only part of the spans are accepted, e.g. with a PartialError, the events and
+// links are split in the same proportion.
+func (rec *Receiver) EndTracesOpDetailed(
+	receiverCtx context.Context,
+	format string,
+	numReceivedSpans int,
+	numReceivedEvents int,
+	numReceivedLinks int,
+	err error,
+) {
+	numAcceptedSpans, _, _ := toAcceptedRefused(numReceivedSpans, err)
+	numAcceptedEvents, numRefusedEvents := numReceivedEvents, 0
+	numAcceptedLinks, numRefusedLinks := numReceivedLinks, 0
+	if !allAccepted(numReceivedSpans, err) {
+		numAcceptedEvents, numRefusedEvents = splitAcceptedRefused(numReceivedEvents, numReceivedSpans, numAcceptedSpans)
+		numAcceptedLinks, numRefusedLinks = splitAcceptedRefused(numReceivedLinks, numReceivedSpans, numAcceptedSpans)
+	}
+
+	if rec.levelFor(component.DataTypeTraces) != configtelemetry.LevelNone {
+		rec.recordSpanDetails(receiverCtx, numAcceptedEvents, numRefusedEvents, numAcceptedLinks, numRefusedLinks)
+	}
+
+	span := trace.SpanFromContext(receiverCtx)
+	if span.IsRecording() {
+		span.SetAttributes(
+			attribute.Int64(obsmetrics.AcceptedSpanEventsKey, int64(numAcceptedEvents)),
+			attribute.Int64(obsmetrics.RefusedSpanEventsKey, int64(numRefusedEvents)),
+			attribute.Int64(obsmetrics.AcceptedSpanLinksKey, int64(numAcceptedLinks)),
+			attribute.Int64(obsmetrics.RefusedSpanLinksKey, int64(numRefusedLinks)),
+		)
+	}
+
+	rec.endOp(receiverCtx, format, numReceivedSpans, err, component.DataTypeTraces)
+}
+
+// EndTracesOpWithStructure completes the receive operation that was started with
+// StartTracesOp, additionally recording the number of resource and scope groupings
+// of the received spans, which indicates the fan-out cost downstream.
+// The number of groupings is only recorded when the metrics level is detailed.
+func (rec *Receiver) EndTracesOpWithStructure(
+	receiverCtx context.Context,
+	format string,
+	numReceivedResources int,
+	numReceivedScopes int,
+	numReceivedSpans int,
+	err error,
+) {
+	if allAccepted(numReceivedSpans, err) && rec.levelFor(component.DataTypeTraces) == configtelemetry.LevelDetailed {
+		rec.recordStructure(receiverCtx, numReceivedResources, numReceivedScopes)
+	}
+
+	rec.endOp(receiverCtx, format, numReceivedSpans, err, component.DataTypeTraces)
+}
+
+// EndTracesOpWithAttrStats completes the receive operation that was started with
+// StartTracesOp, additionally recording the average number of attributes of the
+// received spans, given the total number of attributes across them. This helps to
+// spot clients emitting an excessive number of attributes.
+// The average is only recorded when the metrics level is detailed.
+func (rec *Receiver) EndTracesOpWithAttrStats(
+	receiverCtx context.Context,
+	format string,
+	numReceivedSpans int,
+	numTotalAttributes int,
+	err error,
+) {
+	if allAccepted(numReceivedSpans, err) && numReceivedSpans > 0 && rec.levelFor(component.DataTypeTraces) == configtelemetry.LevelDetailed {
+		rec.recordAttributesPerSpan(receiverCtx, float64(numTotalAttributes)/float64(numReceivedSpans))
+	}
+
+	rec.endOp(receiverCtx, format, numReceivedSpans, err, component.DataTypeTraces)
+}
+
+// EndTracesOpWithSkew completes the receive operation that was started with
+// StartTracesOp, additionally breaking down the accepted spans by the clock skew
+// range of their timestamps. This helps to spot clients with wrong clocks.
+// The keys of spansBySkew should be ClockSkewOK, ClockSkewFuture or ClockSkewStale,
+// any other key is reported as ClockSkewOther.
+// The breakdown is only recorded when all the spans are accepted, since the spans
+// refused by a PartialError cannot be attributed to their range.
+func (rec *Receiver) EndTracesOpWithSkew(
+	receiverCtx context.Context,
+	format string,
+	spansBySkew map[string]int,
+	err error,
+) {
+	numReceivedSpans := 0
+	for _, numSpans := range spansBySkew {
+		numReceivedSpans += numSpans
+	}
+
+	if allAccepted(numReceivedSpans, err) && rec.levelFor(component.DataTypeTraces) != configtelemetry.LevelNone {
+		for skew, numSpans := range spansBySkew {
+			rec.recordClockSkew(receiverCtx, clockSkewRange(skew), numSpans)
+		}
+	}
+
+	rec.endOp(receiverCtx, format, numReceivedSpans, err, component.DataTypeTraces)
+}
+
+// EndTracesOpBySampled completes the receive operation that was started with
+// StartTracesOp, additionally breaking down the accepted spans by whether the sampled
+// flag was set in their trace flags. This helps to find out the fraction of the spans
+// that were already sampled by the clients. Since it requires the receiver to inspect
+// every span, it is opt-in: receivers have to explicitly call it instead of EndTracesOp.
+func (rec *Receiver) EndTracesOpBySampled(
+	receiverCtx context.Context,
+	format string,
+	numSampledSpans int,
+	numUnsampledSpans int,
+	err error,
+) {
+	if allAccepted(numSampledSpans+numUnsampledSpans, err) && rec.levelFor(component.DataTypeTraces) != configtelemetry.LevelNone {
+		rec.recordSampled(receiverCtx, true, numSampledSpans)
+		rec.recordSampled(receiverCtx, false, numUnsampledSpans)
+	}
+
+	rec.endOp(receiverCtx, format, numSampledSpans+numUnsampledSpans, err, component.DataTypeTraces)
+}
+
+// EndTracesOpConverted completes the receive operation that was started with
+// StartTracesOp for spans received in fromFormat and converted to toFormat, e.g. from
+// zipkin to OTLP, additionally counting the accepted spans by both formats. This helps to
+// track the migration of the clients to the native format of the pipeline.
+// The spans are not counted as converted when both formats are the same.
+func (rec *Receiver) EndTracesOpConverted(
+	receiverCtx context.Context,
+	fromFormat string,
+	toFormat string,
+	numReceivedSpans int,
+	err error,
+) {
+	if fromFormat != toFormat && rec.levelFor(component.DataTypeTraces) != configtelemetry.LevelNone {
+		if numAccepted, _, _ := toAcceptedRefused(numReceivedSpans, err); numAccepted > 0 {
+			rec.recordConversion(receiverCtx, fromFormat, toFormat, numAccepted)
+		}
+	}
+
+	rec.endOp(receiverCtx, fromFormat, numReceivedSpans, err, component.DataTypeTraces)
+}
+
+// EndTracesOpForTenant completes the receive operation that was started with
+// StartTracesOp for spans belonging to the given tenant, additionally breaking down the
+// accepted and refused spans by tenant when RecordTenants is enabled in the
+// ReceiverSettings. Otherwise, or when the tenant is empty, it is the same as EndTracesOp.
+// Beware that every tenant adds new time series to the metrics, so the tenants should
+// come from a small and bounded set, never from unvalidated client input.
+func (rec *Receiver) EndTracesOpForTenant(
+	receiverCtx context.Context,
+	format string,
+	numReceivedSpans int,
+	tenant string,
+	err error,
+) {
+	if rec.recordTenants && tenant != "" && rec.levelFor(component.DataTypeTraces) != configtelemetry.LevelNone {
+		numAccepted, numRefused, _ := toAcceptedRefused(numReceivedSpans, err)
+		rec.recordTenant(receiverCtx, tenant, numAccepted, numRefused)
+	}
+
+	rec.endOp(receiverCtx, format, numReceivedSpans, err, component.DataTypeTraces)
+}
+
+// EndTracesOpWithVolume completes the receive operation that was started with StartTracesOp,
+// additionally breaking down the accepted spans by the value of the resource attribute set in
+// ReceiverSettings.VolumeAttributeKey, read from the resources of itemsByResource. The spans of
+// the resources without the attribute are reported as VolumeAttributeUnset. The breakdown is
+// only recorded when the operation succeeded, since the spans refused by a PartialError cannot
+// be attributed to their resources.
+func (rec *Receiver) EndTracesOpWithVolume(
+	receiverCtx context.Context,
+	format string,
+	itemsByResource []ResourceItems,
+	err error,
+) {
+	rec.endOpWithVolume(receiverCtx, format, itemsByResource, err, component.DataTypeTraces)
+}
+
+// EndTracesOpWithHTTPStatus completes the receive operation that was started with
+// StartTracesOp for spans received over HTTP, additionally breaking down the refused
+// spans by the HTTP status code returned to the client, e.g. 400, 413, 429 or 503.
+// This allows correlating the errors seen by the clients with the state of the receiver.
+// The status code is ignored when the operation succeeded, and when it is not a valid
+// HTTP status code, in which case it is the same as EndTracesOp.
+func (rec *Receiver) EndTracesOpWithHTTPStatus(
+	receiverCtx context.Context,
+	format string,
+	numReceivedSpans int,
+	httpStatus int,
+	err error,
+) {
+	if err != nil && httpStatus >= 100 && httpStatus <= 599 {
+		span := trace.SpanFromContext(receiverCtx)
+		if span.IsRecording() {
+			span.SetAttributes(attribute.Int(obsmetrics.HTTPStatusCodeKey, httpStatus))
+		}
+		if rec.levelFor(component.DataTypeTraces) != configtelemetry.LevelNone {
+			if _, numRefused, _ := toAcceptedRefused(numReceivedSpans, err); numRefused > 0 {
+				rec.recordRefusedStatusCode(receiverCtx, strconv.Itoa(httpStatus), numRefused)
+			}
+		}
+	}
+
+	rec.endOp(receiverCtx, format, numReceivedSpans, err, component.DataTypeTraces)
+}
+
+// EndTracesOpWithProtoVersion completes the receive operation that was started with
+// StartTracesOp for spans deserialized from the given version of the protocol schema,
+// e.g. when the receiver migrates the data of older clients to the current pdata, additionally
+// counting the accepted spans by version. This helps to track the upgrades of the clients.
+// The version should be a coarse one, e.g. "v0.19", rather than a full build identifier: only
+// the first 10 distinct versions are reported, any further one is reported as ProtoVersionOther.
+// When the version is empty it is the same as EndTracesOp.
+func (rec *Receiver) EndTracesOpWithProtoVersion(
+	receiverCtx context.Context,
+	format string,
+	version string,
+	numReceivedSpans int,
+	err error,
+) {
+	if version != "" && rec.levelFor(component.DataTypeTraces) != configtelemetry.LevelNone {
+		if numAccepted, _, _ := toAcceptedRefused(numReceivedSpans, err); numAccepted > 0 {
+			rec.recordProtoVersion(receiverCtx, rec.boundedProtoVersion(version), numAccepted)
+		}
+	}
+
+	rec.endOp(receiverCtx, format, numReceivedSpans, err, component.DataTypeTraces)
+}
+
 // StartLogsOp is called when a request is received from a client.
 // The returned context should be used in other calls to the obsreport functions
 // dealing with the same receive operation.
 func (rec *Receiver) StartLogsOp(operationCtx context.Context) context.Context {
-	return rec.startOp(operationCtx, obsmetrics.ReceiverLogsOperationSuffix)
+	return rec.startOp(operationCtx, obsmetrics.ReceiverLogsOperationSuffix, component.DataTypeLogs)
+}
+
+// StartLogsOpWithHandle is like StartLogsOp but it also returns an OpHandle,
+// which can be passed to EndLogsOp instead of the returned context, see OpHandle.
+func (rec *Receiver) StartLogsOpWithHandle(operationCtx context.Context) (context.Context, OpHandle) {
+	return newOpHandle(rec.StartLogsOp(operationCtx))
 }
 
 // EndLogsOp completes the receive operation that was started with
@@ -195,11 +1227,52 @@ func (rec *Receiver) EndLogsOp(
 	rec.endOp(receiverCtx, format, numReceivedLogRecords, err, component.DataTypeLogs)
 }
 
+// EndLogsOpWeighted completes the receive operation that was started with
+// StartLogsOp, additionally recording the size in bytes of the received log
+// records when they are accepted. Since log records can vary widely in size,
+// this gives a more accurate picture of the throughput than the count alone.
+// When only part of the log records are accepted, e.g. with a PartialError, the
+// size is counted in proportion to them.
+func (rec *Receiver) EndLogsOpWeighted(
+	receiverCtx context.Context,
+	format string,
+	numReceivedLogRecords int,
+	weightBytes int,
+	err error,
+) {
+	if rec.levelFor(component.DataTypeLogs) != configtelemetry.LevelNone {
+		if numAccepted, _, _ := toAcceptedRefused(numReceivedLogRecords, err); numAccepted > 0 {
+			acceptedBytes, _ := splitAcceptedRefused(weightBytes, numReceivedLogRecords, numAccepted)
+			rec.recordLogsWeight(receiverCtx, acceptedBytes)
+		}
+	}
+
+	rec.endOp(receiverCtx, format, numReceivedLogRecords, err, component.DataTypeLogs)
+}
+
+// EndLogsOpWithVolume completes the receive operation that was started with StartLogsOp,
+// additionally breaking down the accepted log records by the value of the resource attribute
+// set in ReceiverSettings.VolumeAttributeKey, like EndTracesOpWithVolume.
+func (rec *Receiver) EndLogsOpWithVolume(
+	receiverCtx context.Context,
+	format string,
+	itemsByResource []ResourceItems,
+	err error,
+) {
+	rec.endOpWithVolume(receiverCtx, format, itemsByResource, err, component.DataTypeLogs)
+}
+
 // StartMetricsOp is called when a request is received from a client.
 // The returned context should be used in other calls to the obsreport functions
 // dealing with the same receive operation.
 func (rec *Receiver) StartMetricsOp(operationCtx context.Context) context.Context {
-	return rec.startOp(operationCtx, obsmetrics.ReceiverMetricsOperationSuffix)
+	return rec.startOp(operationCtx, obsmetrics.ReceiverMetricsOperationSuffix, component.DataTypeMetrics)
+}
+
+// StartMetricsOpWithHandle is like StartMetricsOp but it also returns an OpHandle,
+// which can be passed to EndMetricsOp instead of the returned context, see OpHandle.
+func (rec *Receiver) StartMetricsOpWithHandle(operationCtx context.Context) (context.Context, OpHandle) {
+	return newOpHandle(rec.StartMetricsOp(operationCtx))
 }
 
 // EndMetricsOp completes the receive operation that was started with
@@ -213,19 +1286,75 @@ func (rec *Receiver) EndMetricsOp(
 	rec.endOp(receiverCtx, format, numReceivedPoints, err, component.DataTypeMetrics)
 }
 
+// EndMetricsOpWithVolume completes the receive operation that was started with StartMetricsOp,
+// additionally breaking down the accepted metric points by the value of the resource attribute
+// set in ReceiverSettings.VolumeAttributeKey, like EndTracesOpWithVolume.
+func (rec *Receiver) EndMetricsOpWithVolume(
+	receiverCtx context.Context,
+	format string,
+	itemsByResource []ResourceItems,
+	err error,
+) {
+	rec.endOpWithVolume(receiverCtx, format, itemsByResource, err, component.DataTypeMetrics)
+}
+
+func (rec *Receiver) endOpWithVolume(
+	receiverCtx context.Context,
+	format string,
+	itemsByResource []ResourceItems,
+	err error,
+	dataType component.DataType,
+) {
+	numReceivedItems := 0
+	for _, ri := range itemsByResource {
+		numReceivedItems += ri.NumItems
+	}
+	if rec.volumeKey != "" && allAccepted(numReceivedItems, err) && rec.levelFor(dataType) != configtelemetry.LevelNone {
+		rec.recordVolume(receiverCtx, dataType, itemsByResource)
+	}
+
+	rec.endOp(receiverCtx, format, numReceivedItems, err, dataType)
+}
+
+// recordDeadlineRemaining records the time remaining until the deadline of the context
+// of an operation, if any. An expired deadline is recorded as no time remaining.
+func (rec *Receiver) recordDeadlineRemaining(ctx context.Context) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return
+	}
+	remaining := float64(deadline.Sub(rec.now())) / float64(time.Millisecond)
+	if remaining < 0 {
+		remaining = 0
+	}
+	if rec.useOtelForMetrics {
+		// The OpenTelemetry SDK drops the measurements made with a done context, which
+		// would hide the expired deadlines, so the context of the operation is not used.
+		rec.deadlineRemainingHistogram.Record(context.Background(), remaining, withAttrs(rec.otelAttrs)...)
+	} else {
+		stats.Record(ctx, obsmetrics.ReceiverDeadlineRemaining.M(remaining))
+	}
+}
+
 // startOp creates the span used to trace the operation. Returning
 // the updated context with the created span.
-func (rec *Receiver) startOp(receiverCtx context.Context, operationSuffix string) context.Context {
-	ctx, _ := tag.New(receiverCtx, rec.mutators...)
+func (rec *Receiver) startOp(receiverCtx context.Context, operationSuffix string, dataType component.DataType) context.Context {
+	if rec.overhead.enabled() {
+		defer rec.overhead.record(time.Now())
+	}
+	ctx := rec.interner.newContext(receiverCtx)
+	if rec.recordDeadline && rec.level.Load() != configtelemetry.LevelNone {
+		rec.recordDeadlineRemaining(ctx)
+	}
 	var span trace.Span
 	spanName := rec.spanNamePrefix + operationSuffix
 	if !rec.longLivedCtx {
-		ctx, span = rec.tracer.Start(ctx, spanName)
+		ctx, span = startSpan(ctx, rec.tracer, rec.spanMinDuration, spanName)
 	} else {
 		// Since the receiverCtx is long lived do not use it to start the span.
 		// This way this trace ends when the EndTracesOp is called.
 		// Here is safe to ignore the returned context since it is not used below.
-		_, span = rec.tracer.Start(context.Background(), spanName, trace.WithLinks(trace.Link{
+		_, span = startSpan(context.Background(), rec.tracer, rec.spanMinDuration, spanName, trace.WithLinks(trace.Link{
 			SpanContext: trace.SpanContextFromContext(receiverCtx),
 		}))
 
@@ -235,6 +1364,21 @@ func (rec *Receiver) startOp(receiverCtx context.Context, operationSuffix string
 	if rec.transport != "" {
 		span.SetAttributes(attribute.String(obsmetrics.TransportKey, rec.transport))
 	}
+	if len(rec.baggageKeys) > 0 && span.IsRecording() {
+		bag := baggage.FromContext(receiverCtx)
+		for _, key := range rec.baggageKeys {
+			if member := bag.Member(key); member.Key() != "" {
+				span.SetAttributes(attribute.String(key, member.Value()))
+			}
+		}
+	}
+	if rec.levelFor(dataType) == configtelemetry.LevelDetailed {
+		// Only needed to record the first byte latency, which is a detailed metric.
+		ctx = context.WithValue(ctx, receiveOpStartKey{}, receiveOpStart{time: time.Now(), dataType: dataType})
+	}
+	if rec.recordPipeline && rec.level.Load() != configtelemetry.LevelNone {
+		ctx = context.WithValue(ctx, pipelineStartKey{}, rec.now())
+	}
 	return ctx
 }
 
@@ -246,17 +1390,23 @@ func (rec *Receiver) endOp(
 	err error,
 	dataType component.DataType,
 ) {
-	numAccepted := numReceivedItems
-	numRefused := 0
-	if err != nil {
-		numAccepted = 0
-		numRefused = numReceivedItems
+	if rec.overhead.enabled() {
+		defer rec.overhead.record(time.Now())
 	}
+	numAccepted, numRefused, err := toAcceptedRefused(numReceivedItems, err)
 
 	span := trace.SpanFromContext(receiverCtx)
 
-	if rec.level != configtelemetry.LevelNone {
-		rec.recordMetrics(receiverCtx, dataType, numAccepted, numRefused)
+	if rec.levelFor(dataType) != configtelemetry.LevelNone {
+		rec.recorder.RecordReceived(receiverCtx, dataType, int64(numAccepted), int64(numRefused))
+	}
+	if rec.acceptedItems != nil && rec.levelFor(dataType) == configtelemetry.LevelDetailed {
+		rec.recordItemsPerCore(receiverCtx, dataType, numAccepted)
+	}
+	// Empty batches are otherwise indistinguishable from no operation at all in the counters.
+	if numReceivedItems == 0 && err == nil && rec.ocMeasures.emptyBatches != nil &&
+		rec.levelFor(dataType) == configtelemetry.LevelDetailed {
+		rec.recordEmptyBatch(receiverCtx)
 	}
 
 	// end span according to errors
@@ -279,53 +1429,248 @@ func (rec *Receiver) endOp(
 			attribute.Int64(acceptedItemsKey, int64(numAccepted)),
 			attribute.Int64(refusedItemsKey, int64(numRefused)),
 		)
-		recordError(span, err)
+		recordError(span, err, rec.statusMapper)
 	}
 	span.End()
 }
 
-func (rec *Receiver) recordMetrics(receiverCtx context.Context, dataType component.DataType, numAccepted, numRefused int) {
+// levelFor returns the metrics level to use for the given signal.
+func (rec *Receiver) levelFor(dataType component.DataType) configtelemetry.Level {
+	return rec.signalLevels.levelFor(dataType, rec.level.Load())
+}
+
+func (rec *Receiver) recordWithBackend(receiverCtx context.Context, dataType component.DataType, numAccepted, numRefused int64) {
 	if rec.useOtelForMetrics {
-		rec.recordWithOtel(receiverCtx, dataType, numAccepted, numRefused)
+		rec.recordWithOtel(dataType, numAccepted, numRefused)
 	} else {
 		rec.recordWithOC(receiverCtx, dataType, numAccepted, numRefused)
 	}
 }
 
-func (rec *Receiver) recordWithOtel(receiverCtx context.Context, dataType component.DataType, numAccepted, numRefused int) {
-	var acceptedMeasure, refusedMeasure instrument.Int64Counter
+func (rec *Receiver) recordWithOtel(dataType component.DataType, numAccepted, numRefused int64) {
+	rec.itemCounters.add(dataType, numAccepted, numRefused)
+}
+
+func (rec *Receiver) recordWithOC(receiverCtx context.Context, dataType component.DataType, numAccepted, numRefused int64) {
+	var acceptedMeasure, refusedMeasure *stats.Int64Measure
 	switch dataType {
 	case component.DataTypeTraces:
-		acceptedMeasure = rec.acceptedSpansCounter
-		refusedMeasure = rec.refusedSpansCounter
+		acceptedMeasure = rec.ocMeasures.acceptedSpans
+		refusedMeasure = rec.ocMeasures.refusedSpans
 	case component.DataTypeMetrics:
-		acceptedMeasure = rec.acceptedMetricPointsCounter
-		refusedMeasure = rec.refusedMetricPointsCounter
+		acceptedMeasure = rec.ocMeasures.acceptedMetricPoints
+		refusedMeasure = rec.ocMeasures.refusedMetricPoints
 	case component.DataTypeLogs:
-		acceptedMeasure = rec.acceptedLogRecordsCounter
-		refusedMeasure = rec.refusedLogRecordsCounter
+		acceptedMeasure = rec.ocMeasures.acceptedLogRecords
+		refusedMeasure = rec.ocMeasures.refusedLogRecords
 	}
 
-	acceptedMeasure.Add(receiverCtx, int64(numAccepted), rec.otelAttrs...)
-	refusedMeasure.Add(receiverCtx, int64(numRefused), rec.otelAttrs...)
+	stats.Record(
+		receiverCtx,
+		acceptedMeasure.M(numAccepted),
+		refusedMeasure.M(numRefused))
 }
 
-func (rec *Receiver) recordWithOC(receiverCtx context.Context, dataType component.DataType, numAccepted, numRefused int) {
-	var acceptedMeasure, refusedMeasure *stats.Int64Measure
+// recordItemsPerCore adds the accepted items to the total of the data type. With OpenTelemetry
+// the gauge divides the total when the metrics are collected, so it follows the changes of
+// GOMAXPROCS, with OpenCensus it is divided on each operation.
+func (rec *Receiver) recordItemsPerCore(receiverCtx context.Context, dataType component.DataType, numAccepted int) {
+	rec.acceptedItemsMu.Lock()
+	accepted := rec.acceptedItems[dataType] + int64(numAccepted)
+	rec.acceptedItems[dataType] = accepted
+	rec.acceptedItemsMu.Unlock()
+	if !rec.useOtelForMetrics {
+		ia := rec.interner.with(obsmetrics.TagKeySignal, string(dataType))
+		_ = stats.RecordWithTags(receiverCtx, ia.mutators, rec.ocMeasures.itemsPerCore.M(itemsPerCore(accepted, rec.gomaxprocs())))
+	}
+}
+
+// itemsPerCore divides the number of items by the number of cores, counting at least one.
+func itemsPerCore(items int64, procs int) float64 {
+	if procs < 1 {
+		procs = 1
+	}
+	return float64(items) / float64(procs)
+}
+
+// currentGOMAXPROCS returns the number of cores the collector can run on simultaneously.
+func currentGOMAXPROCS() int {
+	return runtime.GOMAXPROCS(0)
+}
+
+func (rec *Receiver) recordSpanDetails(receiverCtx context.Context, numAcceptedEvents, numRefusedEvents, numAcceptedLinks, numRefusedLinks int) {
+	if rec.useOtelForMetrics {
+		rec.acceptedSpanEventsCounter.Add(receiverCtx, int64(numAcceptedEvents), withAttrs(rec.otelAttrs)...)
+		rec.refusedSpanEventsCounter.Add(receiverCtx, int64(numRefusedEvents), withAttrs(rec.otelAttrs)...)
+		rec.acceptedSpanLinksCounter.Add(receiverCtx, int64(numAcceptedLinks), withAttrs(rec.otelAttrs)...)
+		rec.refusedSpanLinksCounter.Add(receiverCtx, int64(numRefusedLinks), withAttrs(rec.otelAttrs)...)
+	} else {
+		stats.Record(
+			receiverCtx,
+			rec.ocMeasures.acceptedSpanEvents.M(int64(numAcceptedEvents)),
+			rec.ocMeasures.refusedSpanEvents.M(int64(numRefusedEvents)),
+			rec.ocMeasures.acceptedSpanLinks.M(int64(numAcceptedLinks)),
+			rec.ocMeasures.refusedSpanLinks.M(int64(numRefusedLinks)))
+	}
+}
+
+func (rec *Receiver) recordStructure(receiverCtx context.Context, numAcceptedResources, numAcceptedScopes int) {
+	if rec.useOtelForMetrics {
+		rec.acceptedResourcesCounter.Add(receiverCtx, int64(numAcceptedResources), withAttrs(rec.otelAttrs)...)
+		rec.acceptedScopesCounter.Add(receiverCtx, int64(numAcceptedScopes), withAttrs(rec.otelAttrs)...)
+	} else {
+		stats.Record(
+			receiverCtx,
+			obsmetrics.ReceiverAcceptedResources.M(int64(numAcceptedResources)),
+			obsmetrics.ReceiverAcceptedScopes.M(int64(numAcceptedScopes)))
+	}
+}
+
+func (rec *Receiver) recordAttributesPerSpan(receiverCtx context.Context, attributesPerSpan float64) {
+	if rec.useOtelForMetrics {
+		rec.attributesPerSpanHistogram.Record(receiverCtx, attributesPerSpan, withAttrs(rec.otelAttrs)...)
+	} else {
+		stats.Record(receiverCtx, obsmetrics.ReceiverAttributesPerSpan.M(attributesPerSpan))
+	}
+}
+
+func (rec *Receiver) recordEmptyBatch(receiverCtx context.Context) {
+	if rec.useOtelForMetrics {
+		rec.emptyBatchesCounter.Add(receiverCtx, 1, withAttrs(rec.otelAttrs)...)
+	} else {
+		stats.Record(receiverCtx, rec.ocMeasures.emptyBatches.M(1))
+	}
+}
+
+func (rec *Receiver) recordLogsWeight(receiverCtx context.Context, weightBytes int) {
+	if rec.useOtelForMetrics {
+		rec.acceptedLogRecordBytesCounter.Add(receiverCtx, int64(weightBytes), withAttrs(rec.otelAttrs)...)
+	} else {
+		stats.Record(receiverCtx, obsmetrics.ReceiverAcceptedLogRecordBytes.M(int64(weightBytes)))
+	}
+}
+
+func (rec *Receiver) recordClockSkew(receiverCtx context.Context, skew string, numAccepted int) {
+	skewAttrs := rec.interner.with(obsmetrics.TagKeyClockSkew, skew)
+	if rec.useOtelForMetrics {
+		rec.acceptedSpansByClockSkewCounter.Add(receiverCtx, int64(numAccepted), skewAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(receiverCtx, skewAttrs.mutators, rec.ocMeasures.spansByClockSkew.M(int64(numAccepted)))
+	}
+}
+
+func (rec *Receiver) recordSampled(receiverCtx context.Context, sampled bool, numAccepted int) {
+	sampledAttrs := rec.interner.with(obsmetrics.TagKeySampled, strconv.FormatBool(sampled))
+	if rec.useOtelForMetrics {
+		rec.acceptedSpansBySampledCounter.Add(receiverCtx, int64(numAccepted), sampledAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(receiverCtx, sampledAttrs.mutators, obsmetrics.ReceiverAcceptedSpansBySampled.M(int64(numAccepted)))
+	}
+}
+
+func (rec *Receiver) recordConversion(receiverCtx context.Context, fromFormat, toFormat string, numAccepted int) {
+	if rec.useOtelForMetrics {
+		rec.convertedSpansCounter.Add(receiverCtx, int64(numAccepted), withAttrs(rec.otelAttrs,
+			attribute.String(obsmetrics.FromFormatKey, fromFormat),
+			attribute.String(obsmetrics.ToFormatKey, toFormat))...)
+	} else {
+		_ = stats.RecordWithTags(
+			receiverCtx,
+			[]tag.Mutator{
+				tag.Upsert(obsmetrics.TagKeyFromFormat, fromFormat, tag.WithTTL(tag.TTLNoPropagation)),
+				tag.Upsert(obsmetrics.TagKeyToFormat, toFormat, tag.WithTTL(tag.TTLNoPropagation)),
+			},
+			obsmetrics.ReceiverConvertedSpans.M(int64(numAccepted)))
+	}
+}
+
+func (rec *Receiver) recordTenant(receiverCtx context.Context, tenant string, numAccepted, numRefused int) {
+	tenantAttrs := rec.interner.with(obsmetrics.TagKeyTenant, tenant)
+	if rec.useOtelForMetrics {
+		rec.acceptedSpansByTenantCounter.Add(receiverCtx, int64(numAccepted), tenantAttrs.attrs()...)
+		rec.refusedSpansByTenantCounter.Add(receiverCtx, int64(numRefused), tenantAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(
+			receiverCtx,
+			tenantAttrs.mutators,
+			obsmetrics.ReceiverAcceptedSpansByTenant.M(int64(numAccepted)),
+			obsmetrics.ReceiverRefusedSpansByTenant.M(int64(numRefused)))
+	}
+}
+
+func (rec *Receiver) recordVolume(receiverCtx context.Context, dataType component.DataType, itemsByResource []ResourceItems) {
+	var counter *lazyInt64Counter
+	var measure *stats.Int64Measure
 	switch dataType {
 	case component.DataTypeTraces:
-		acceptedMeasure = obsmetrics.ReceiverAcceptedSpans
-		refusedMeasure = obsmetrics.ReceiverRefusedSpans
+		counter, measure = rec.acceptedSpansByVolumeCounter, obsmetrics.ReceiverAcceptedSpansByVolume
 	case component.DataTypeMetrics:
-		acceptedMeasure = obsmetrics.ReceiverAcceptedMetricPoints
-		refusedMeasure = obsmetrics.ReceiverRefusedMetricPoints
+		counter, measure = rec.acceptedMetricPointsByVolumeCounter, obsmetrics.ReceiverAcceptedMetricPointsByVolume
 	case component.DataTypeLogs:
-		acceptedMeasure = obsmetrics.ReceiverAcceptedLogRecords
-		refusedMeasure = obsmetrics.ReceiverRefusedLogRecords
+		counter, measure = rec.acceptedLogRecordsByVolumeCounter, obsmetrics.ReceiverAcceptedLogRecordsByVolume
 	}
 
-	stats.Record(
-		receiverCtx,
-		acceptedMeasure.M(int64(numAccepted)),
-		refusedMeasure.M(int64(numRefused)))
+	for _, ri := range itemsByResource {
+		value := VolumeAttributeUnset
+		if v, ok := ri.Resource.Attributes().Get(rec.volumeKey); ok {
+			value = v.AsString()
+		}
+		volumeAttrs := rec.interner.with(obsmetrics.TagKeyVolume, value)
+		if rec.useOtelForMetrics {
+			counter.Add(receiverCtx, int64(ri.NumItems), volumeAttrs.attrs()...)
+		} else {
+			_ = stats.RecordWithTags(receiverCtx, volumeAttrs.mutators, measure.M(int64(ri.NumItems)))
+		}
+	}
+}
+
+func (rec *Receiver) recordRefusedStatusCode(receiverCtx context.Context, statusCode string, numRefused int) {
+	statusCodeAttrs := rec.interner.with(obsmetrics.TagKeyHTTPStatusCode, statusCode)
+	if rec.useOtelForMetrics {
+		rec.refusedSpansByStatusCodeCounter.Add(receiverCtx, int64(numRefused), statusCodeAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(receiverCtx, statusCodeAttrs.mutators, obsmetrics.ReceiverRefusedSpansByStatusCode.M(int64(numRefused)))
+	}
+}
+
+func (rec *Receiver) recordProtoVersion(receiverCtx context.Context, version string, numAccepted int) {
+	versionAttrs := rec.interner.with(obsmetrics.TagKeyProtoVersion, version)
+	if rec.useOtelForMetrics {
+		rec.acceptedSpansByProtoVersionCounter.Add(receiverCtx, int64(numAccepted), versionAttrs.attrs()...)
+	} else {
+		_ = stats.RecordWithTags(receiverCtx, versionAttrs.mutators, obsmetrics.ReceiverAcceptedSpansByProtoVersion.M(int64(numAccepted)))
+	}
+}
+
+// boundedProtoVersion returns the version if it was already reported or if fewer than
+// maxProtoVersions versions were, ProtoVersionOther otherwise.
+func (rec *Receiver) boundedProtoVersion(version string) string {
+	rec.protoVersionsMu.Lock()
+	defer rec.protoVersionsMu.Unlock()
+	if _, ok := rec.protoVersions[version]; ok {
+		return version
+	}
+	if len(rec.protoVersions) >= maxProtoVersions {
+		return ProtoVersionOther
+	}
+	rec.protoVersions[version] = struct{}{}
+	return version
+}
+
+func streamCloseReason(reason string) string {
+	switch reason {
+	case StreamCloseEOF, StreamCloseError, StreamCloseShutdown, StreamCloseIdleTimeout:
+		return reason
+	default:
+		return StreamCloseOther
+	}
+}
+
+func clockSkewRange(skew string) string {
+	switch skew {
+	case ClockSkewOK, ClockSkewFuture, ClockSkewStale:
+		return skew
+	default:
+		return ClockSkewOther
+	}
 }
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreport // import "go.opentelemetry.io/collector/obsreport"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Recorder records the item counters of the operations of a component, e.g. to forward
+// them to a custom telemetry system instead of OpenCensus or OpenTelemetry. A Recorder is
+// set in the settings of a single component, so it knows which component the counts are
+// for, and only the methods matching the kind of the component are called: a connector
+// calls both RecordReceived and RecordSent. The methods are not called when the metrics
+// level of the data type is configtelemetry.LevelNone.
+// The other metrics of the components, e.g. the detailed ones, are not affected.
+// Additional recorders can be added to a component with AddRecorder, e.g. to record
+// the counters with both the default backend and a new one during a migration.
+type Recorder interface {
+	// RecordReceived records the items accepted and refused in a receive operation.
+	RecordReceived(ctx context.Context, dataType component.DataType, accepted, refused int64)
+	// RecordProcessed records the items accepted, refused and dropped by a processor.
+	RecordProcessed(ctx context.Context, dataType component.DataType, accepted, refused, dropped int64)
+	// RecordSent records the items sent and failed to send in an export operation.
+	RecordSent(ctx context.Context, dataType component.DataType, sent, failed int64)
+	// RecordScraped records the metric points scraped and errored in a scrape operation.
+	RecordScraped(ctx context.Context, scraped, errored int64)
+}
+
+// backendRecorder is the default Recorder, recording the counters with OpenTelemetry when
+// the telemetry.useOtelForInternalMetrics feature gate is enabled or with OpenCensus otherwise.
+// It is created for a single component and only holds the helper matching its kind.
+type backendRecorder struct {
+	receiver  *Receiver
+	processor *Processor
+	exporter  *Exporter
+	scraper   *Scraper
+}
+
+var _ Recorder = backendRecorder{}
+
+func (br backendRecorder) RecordReceived(ctx context.Context, dataType component.DataType, accepted, refused int64) {
+	br.receiver.recordWithBackend(ctx, dataType, accepted, refused)
+}
+
+func (br backendRecorder) RecordProcessed(_ context.Context, dataType component.DataType, accepted, refused, dropped int64) {
+	br.processor.recordWithBackend(dataType, accepted, refused, dropped)
+}
+
+func (br backendRecorder) RecordSent(ctx context.Context, dataType component.DataType, sent, failed int64) {
+	br.exporter.recordWithBackend(ctx, dataType, sent, failed)
+}
+
+func (br backendRecorder) RecordScraped(ctx context.Context, scraped, errored int64) {
+	br.scraper.recordWithBackend(ctx, scraped, errored)
+}
+
+// RecorderID identifies a Recorder added to a helper with AddRecorder, to remove it
+// with RemoveRecorder. The zero value never identifies a Recorder.
+type RecorderID uint64
+
+// addedRecorder is a Recorder added to a fanoutRecorder, with its ID.
+type addedRecorder struct {
+	id RecorderID
+	Recorder
+}
+
+// fanoutRecorder records the counters with the primary Recorder of a component, i.e. the
+// one of its settings or the backendRecorder, and with the recorders added to it since.
+// The added recorders are copied on write, so that recording does not need a lock. They
+// are identified by the IDs returned by add rather than by value, since a Recorder may
+// not be comparable.
+type fanoutRecorder struct {
+	primary Recorder
+
+	mu     sync.Mutex
+	lastID RecorderID
+	added  atomic.Pointer[[]addedRecorder]
+}
+
+var _ Recorder = (*fanoutRecorder)(nil)
+
+func newFanoutRecorder(primary Recorder) *fanoutRecorder {
+	return &fanoutRecorder{primary: primary}
+}
+
+// add adds the recorder and returns the ID to remove it with.
+func (fr *fanoutRecorder) add(r Recorder) RecorderID {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	var recorders []addedRecorder
+	if added := fr.added.Load(); added != nil {
+		recorders = *added
+	}
+	fr.lastID++
+	recorders = append(recorders[:len(recorders):len(recorders)], addedRecorder{id: fr.lastID, Recorder: r})
+	fr.added.Store(&recorders)
+	return fr.lastID
+}
+
+// remove removes the recorder with the given ID, it is a no-op if there is none.
+func (fr *fanoutRecorder) remove(id RecorderID) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	added := fr.added.Load()
+	if added == nil {
+		return
+	}
+	recorders := make([]addedRecorder, 0, len(*added))
+	for _, existing := range *added {
+		if existing.id != id {
+			recorders = append(recorders, existing)
+		}
+	}
+	fr.added.Store(&recorders)
+}
+
+func (fr *fanoutRecorder) recorders() []addedRecorder {
+	if added := fr.added.Load(); added != nil {
+		return *added
+	}
+	return nil
+}
+
+func (fr *fanoutRecorder) RecordReceived(ctx context.Context, dataType component.DataType, accepted, refused int64) {
+	fr.primary.RecordReceived(ctx, dataType, accepted, refused)
+	for _, r := range fr.recorders() {
+		r.RecordReceived(ctx, dataType, accepted, refused)
+	}
+}
+
+func (fr *fanoutRecorder) RecordProcessed(ctx context.Context, dataType component.DataType, accepted, refused, dropped int64) {
+	fr.primary.RecordProcessed(ctx, dataType, accepted, refused, dropped)
+	for _, r := range fr.recorders() {
+		r.RecordProcessed(ctx, dataType, accepted, refused, dropped)
+	}
+}
+
+func (fr *fanoutRecorder) RecordSent(ctx context.Context, dataType component.DataType, sent, failed int64) {
+	fr.primary.RecordSent(ctx, dataType, sent, failed)
+	for _, r := range fr.recorders() {
+		r.RecordSent(ctx, dataType, sent, failed)
+	}
+}
+
+func (fr *fanoutRecorder) RecordScraped(ctx context.Context, scraped, errored int64) {
+	fr.primary.RecordScraped(ctx, scraped, errored)
+	for _, r := range fr.recorders() {
+		r.RecordScraped(ctx, scraped, errored)
+	}
+}
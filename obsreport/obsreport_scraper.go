@@ -17,6 +17,7 @@ package obsreport // import "go.opentelemetry.io/collector/obsreport"
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
@@ -39,13 +40,21 @@ var (
 	scraperScope = scopeName + nameSep + scraperName
 )
 
+// MetricNameOther is the metric name reported by RecordMetricScrape for any metric
+// not listed in ScraperSettings.MetricNames.
+const MetricNameOther = "other"
+
 // Scraper is a helper to add observability to a component.Scraper.
 type Scraper struct {
-	level      configtelemetry.Level
-	receiverID component.ID
-	scraper    component.ID
-	mutators   []tag.Mutator
-	tracer     trace.Tracer
+	level           *atomicLevel
+	receiverID      component.ID
+	scraper         component.ID
+	statusMapper    StatusMapper
+	spanMinDuration time.Duration
+	recorder        *fanoutRecorder
+	mutators        []tag.Mutator
+	tracer          trace.Tracer
+	metricNames     map[string]struct{}
 
 	logger *zap.Logger
 
@@ -53,6 +62,10 @@ type Scraper struct {
 	otelAttrs            []attribute.KeyValue
 	scrapedMetricsPoints instrument.Int64Counter
 	erroredMetricsPoints instrument.Int64Counter
+	cacheHits            instrument.Int64Counter
+	cacheMisses          instrument.Int64Counter
+	metricScrapes        instrument.Int64Counter
+	metricScrapeErrors   instrument.Int64Counter
 }
 
 // ScraperSettings are settings for creating a Scraper.
@@ -60,6 +73,23 @@ type ScraperSettings struct {
 	ReceiverID             component.ID
 	Scraper                component.ID
 	ReceiverCreateSettings receiver.CreateSettings
+	// StatusMapper is used to set the status of the operation spans from the
+	// returned errors. If nil, any error sets the status to codes.Error.
+	StatusMapper StatusMapper
+	// MetricNaming is the strategy used to build the metric names, defaults to MetricNamingDefault.
+	MetricNaming MetricNaming
+	// InstanceID is added as the collector.instance.id tag to all the metrics, see ReceiverSettings.
+	InstanceID string
+	// SpanMinDuration, when positive, only keeps the spans of the operations that took at
+	// least this long or failed, to reduce the volume of traces. The spans are deferred
+	// until the operation ends, so the spans started during it are children of its parent.
+	SpanMinDuration time.Duration
+	// Recorder records the item counters of the operations instead of OpenCensus or
+	// OpenTelemetry, see Recorder. If nil, they are recorded like the other metrics.
+	Recorder Recorder
+	// MetricNames lists the names of the metrics that RecordMetricScrape reports individually,
+	// the others are reported as MetricNameOther.
+	MetricNames []string
 }
 
 // NewScraper creates a new Scraper.
@@ -69,13 +99,16 @@ func NewScraper(cfg ScraperSettings) (*Scraper, error) {
 
 func newScraper(cfg ScraperSettings, useOtel bool) (*Scraper, error) {
 	scraper := &Scraper{
-		level:      cfg.ReceiverCreateSettings.TelemetrySettings.MetricsLevel,
-		receiverID: cfg.ReceiverID,
-		scraper:    cfg.Scraper,
+		level:           newAtomicLevel(cfg.ReceiverCreateSettings.TelemetrySettings.MetricsLevel),
+		receiverID:      cfg.ReceiverID,
+		scraper:         cfg.Scraper,
+		statusMapper:    cfg.StatusMapper,
+		spanMinDuration: cfg.SpanMinDuration,
 		mutators: []tag.Mutator{
 			tag.Upsert(obsmetrics.TagKeyReceiver, cfg.ReceiverID.String(), tag.WithTTL(tag.TTLNoPropagation)),
 			tag.Upsert(obsmetrics.TagKeyScraper, cfg.Scraper.String(), tag.WithTTL(tag.TTLNoPropagation))},
-		tracer: cfg.ReceiverCreateSettings.TracerProvider.Tracer(cfg.Scraper.String()),
+		tracer:      cfg.ReceiverCreateSettings.TracerProvider.Tracer(cfg.Scraper.String()),
+		metricNames: make(map[string]struct{}, len(cfg.MetricNames)),
 
 		logger:            cfg.ReceiverCreateSettings.Logger,
 		useOtelForMetrics: useOtel,
@@ -84,6 +117,17 @@ func newScraper(cfg ScraperSettings, useOtel bool) (*Scraper, error) {
 			attribute.String(obsmetrics.ScraperKey, cfg.Scraper.String()),
 		},
 	}
+	instanceMutators, instanceAttrs := instanceIDTags(cfg.InstanceID)
+	scraper.mutators = append(scraper.mutators, instanceMutators...)
+	scraper.otelAttrs = append(scraper.otelAttrs, instanceAttrs...)
+	for _, name := range cfg.MetricNames {
+		scraper.metricNames[name] = struct{}{}
+	}
+	primary := cfg.Recorder
+	if primary == nil {
+		primary = backendRecorder{scraper: scraper}
+	}
+	scraper.recorder = newFanoutRecorder(primary)
 
 	if err := scraper.createOtelMetrics(cfg); err != nil {
 		return nil, err
@@ -92,31 +136,76 @@ func newScraper(cfg ScraperSettings, useOtel bool) (*Scraper, error) {
 	return scraper, nil
 }
 
+// SetLevel changes the metrics level of the scraper while it is running, see Receiver.SetLevel.
+func (s *Scraper) SetLevel(level configtelemetry.Level) {
+	s.level.Store(level)
+}
+
 func (s *Scraper) createOtelMetrics(cfg ScraperSettings) error {
 	if !s.useOtelForMetrics {
 		return nil
 	}
 	meter := cfg.ReceiverCreateSettings.MeterProvider.Meter(scraperScope)
+	metricPrefix := cfg.MetricNaming.metricPrefix(obsmetrics.ScraperKey)
 
 	var errors, err error
 
 	s.scrapedMetricsPoints, err = meter.Int64Counter(
-		obsmetrics.ScraperPrefix+obsmetrics.ScrapedMetricPointsKey,
+		metricPrefix+obsmetrics.ScrapedMetricPointsKey,
 		instrument.WithDescription("Number of metric points successfully scraped."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitMetricPoints),
 	)
 	errors = multierr.Append(errors, err)
 
 	s.erroredMetricsPoints, err = meter.Int64Counter(
-		obsmetrics.ScraperPrefix+obsmetrics.ErroredMetricPointsKey,
+		metricPrefix+obsmetrics.ErroredMetricPointsKey,
 		instrument.WithDescription("Number of metric points that were unable to be scraped."),
-		instrument.WithUnit("1"),
+		instrument.WithUnit(obsmetrics.UnitMetricPoints),
+	)
+	errors = multierr.Append(errors, err)
+
+	s.cacheHits, err = meter.Int64Counter(
+		metricPrefix+obsmetrics.CacheHitsKey,
+		instrument.WithDescription("Number of lookups served from the cache of the scraper."),
+		instrument.WithUnit(obsmetrics.UnitLookups),
+	)
+	errors = multierr.Append(errors, err)
+
+	s.cacheMisses, err = meter.Int64Counter(
+		metricPrefix+obsmetrics.CacheMissesKey,
+		instrument.WithDescription("Number of lookups not found in the cache of the scraper."),
+		instrument.WithUnit(obsmetrics.UnitLookups),
+	)
+	errors = multierr.Append(errors, err)
+
+	s.metricScrapes, err = meter.Int64Counter(
+		metricPrefix+obsmetrics.MetricScrapesKey,
+		instrument.WithDescription("Number of successful scrapes of the metrics, by metric name."),
+		instrument.WithUnit(obsmetrics.UnitScrapes),
+	)
+	errors = multierr.Append(errors, err)
+
+	s.metricScrapeErrors, err = meter.Int64Counter(
+		metricPrefix+obsmetrics.MetricScrapeErrorsKey,
+		instrument.WithDescription("Number of failed scrapes of the metrics, by metric name."),
+		instrument.WithUnit(obsmetrics.UnitScrapes),
 	)
 	errors = multierr.Append(errors, err)
 
 	return errors
 }
 
+// AddRecorder adds a Recorder which the item counters of the Scraper are also recorded
+// with, see Receiver.AddRecorder.
+func (s *Scraper) AddRecorder(r Recorder) RecorderID {
+	return s.recorder.add(r)
+}
+
+// RemoveRecorder removes the Recorder added with AddRecorder which returned the given ID.
+func (s *Scraper) RemoveRecorder(id RecorderID) {
+	s.recorder.remove(id)
+}
+
 // StartMetricsOp is called when a scrape operation is started. The
 // returned context should be used in other calls to the obsreport functions
 // dealing with the same scrape operation.
@@ -124,7 +213,7 @@ func (s *Scraper) StartMetricsOp(ctx context.Context) context.Context {
 	ctx, _ = tag.New(ctx, s.mutators...)
 
 	spanName := obsmetrics.ScraperPrefix + s.receiverID.String() + obsmetrics.NameSep + s.scraper.String() + obsmetrics.ScraperMetricsOperationSuffix
-	ctx, _ = s.tracer.Start(ctx, spanName)
+	ctx, _ = startSpan(ctx, s.tracer, s.spanMinDuration, spanName)
 	return ctx
 }
 
@@ -138,9 +227,13 @@ func (s *Scraper) EndMetricsOp(
 	numErroredMetrics := 0
 	if err != nil {
 		var partialErr scrapererror.PartialScrapeError
-		if errors.As(err, &partialErr) {
+		var multiPartialErr scrapererror.MultiPartialScrapeError
+		switch {
+		case errors.As(err, &multiPartialErr):
+			numErroredMetrics = multiPartialErr.Failed()
+		case errors.As(err, &partialErr):
 			numErroredMetrics = partialErr.Failed
-		} else {
+		default:
 			numErroredMetrics = numScrapedMetrics
 			numScrapedMetrics = 0
 		}
@@ -148,8 +241,8 @@ func (s *Scraper) EndMetricsOp(
 
 	span := trace.SpanFromContext(scraperCtx)
 
-	if s.level != configtelemetry.LevelNone {
-		s.recordMetrics(scraperCtx, numScrapedMetrics, numErroredMetrics)
+	if s.level.Load() != configtelemetry.LevelNone {
+		s.recorder.RecordScraped(scraperCtx, int64(numScrapedMetrics), int64(numErroredMetrics))
 	}
 
 	// end span according to errors
@@ -159,20 +252,69 @@ func (s *Scraper) EndMetricsOp(
 			attribute.Int64(obsmetrics.ScrapedMetricPointsKey, int64(numScrapedMetrics)),
 			attribute.Int64(obsmetrics.ErroredMetricPointsKey, int64(numErroredMetrics)),
 		)
-		recordError(span, err)
+		recordError(span, err, s.statusMapper)
 	}
 
 	span.End()
 }
 
-func (s *Scraper) recordMetrics(scraperCtx context.Context, numScrapedMetrics, numErroredMetrics int) {
+func (s *Scraper) recordWithBackend(scraperCtx context.Context, numScrapedMetrics, numErroredMetrics int64) {
 	if s.useOtelForMetrics {
-		s.scrapedMetricsPoints.Add(scraperCtx, int64(numScrapedMetrics), s.otelAttrs...)
-		s.erroredMetricsPoints.Add(scraperCtx, int64(numErroredMetrics), s.otelAttrs...)
+		s.scrapedMetricsPoints.Add(scraperCtx, numScrapedMetrics, withAttrs(s.otelAttrs)...)
+		s.erroredMetricsPoints.Add(scraperCtx, numErroredMetrics, withAttrs(s.otelAttrs)...)
 	} else { // OC for metrics
 		stats.Record(
 			scraperCtx,
-			obsmetrics.ScraperScrapedMetricPoints.M(int64(numScrapedMetrics)),
-			obsmetrics.ScraperErroredMetricPoints.M(int64(numErroredMetrics)))
+			obsmetrics.ScraperScrapedMetricPoints.M(numScrapedMetrics),
+			obsmetrics.ScraperErroredMetricPoints.M(numErroredMetrics))
+	}
+}
+
+// RecordCache reports a lookup in the cache kept by the scraper between scrapes, e.g. of
+// metric definitions, counting it as a hit when the entry was found or as a miss otherwise.
+// Comparing both counters helps to size the cache.
+func (s *Scraper) RecordCache(ctx context.Context, hit bool) {
+	if s.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	if s.useOtelForMetrics {
+		if hit {
+			s.cacheHits.Add(ctx, 1, withAttrs(s.otelAttrs)...)
+		} else {
+			s.cacheMisses.Add(ctx, 1, withAttrs(s.otelAttrs)...)
+		}
+		return
+	}
+	measure := obsmetrics.ScraperCacheMisses
+	if hit {
+		measure = obsmetrics.ScraperCacheHits
+	}
+	_ = stats.RecordWithTags(ctx, s.mutators, measure.M(1))
+}
+
+// RecordMetricScrape reports a scrape of the metric with the given name, counting it as
+// an error when err is not nil. The metric name must be one of ScraperSettings.MetricNames,
+// any other name is reported as MetricNameOther.
+func (s *Scraper) RecordMetricScrape(ctx context.Context, metricName string, err error) {
+	if s.level.Load() == configtelemetry.LevelNone {
+		return
+	}
+	if _, ok := s.metricNames[metricName]; !ok {
+		metricName = MetricNameOther
+	}
+	if s.useOtelForMetrics {
+		counter := s.metricScrapes
+		if err != nil {
+			counter = s.metricScrapeErrors
+		}
+		counter.Add(ctx, 1, withAttrs(s.otelAttrs, attribute.String(obsmetrics.MetricNameKey, metricName))...)
+		return
+	}
+	measure := obsmetrics.ScraperMetricScrapes
+	if err != nil {
+		measure = obsmetrics.ScraperMetricScrapeErrors
 	}
+	mutators := append(s.mutators[:len(s.mutators):len(s.mutators)],
+		tag.Upsert(obsmetrics.TagKeyMetricName, metricName, tag.WithTTL(tag.TTLNoPropagation)))
+	_ = stats.RecordWithTags(ctx, mutators, measure.M(1))
 }
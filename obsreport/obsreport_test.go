@@ -16,17 +16,42 @@ package obsreport
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
 )
 
@@ -40,6 +65,7 @@ var (
 	scraperID   = component.NewID("fakeScraper")
 	processorID = component.NewID("fakeProcessor")
 	exporterID  = component.NewID("fakeExporter")
+	connectorID = component.NewID("fakeConnector")
 
 	errFake        = errors.New("errFake")
 	partialErrFake = scrapererror.NewPartialScrapeError(errFake, 1)
@@ -115,375 +141,4408 @@ func TestReceiveTraceDataOp(t *testing.T) {
 	})
 }
 
-func TestReceiveLogsOp(t *testing.T) {
-	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
-		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
-		defer parentSpan.End()
+func TestMetricNaming(t *testing.T) {
+	tests := []struct {
+		name          string
+		naming        MetricNaming
+		wantReceiver  string
+		wantProcessor string
+	}{
+		{
+			name:          "default",
+			naming:        MetricNamingDefault,
+			wantReceiver:  "receiver/accepted_spans",
+			wantProcessor: "processor/accepted_spans",
+		},
+		{
+			name:          "openmetrics",
+			naming:        MetricNamingOpenMetrics,
+			wantReceiver:  "receiver_accepted_spans",
+			wantProcessor: "processor_accepted_spans",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
 
-		params := []testParams{
-			{items: 13, err: errFake},
-			{items: 42, err: nil},
-		}
-		for i, param := range params {
+			recSet := receivertest.NewNopCreateSettings()
+			recSet.MeterProvider = mp
+			recSet.MetricsLevel = configtelemetry.LevelBasic
 			rec, err := newReceiver(ReceiverSettings{
 				ReceiverID:             receiverID,
 				Transport:              transport,
-				ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
-			}, useOtel)
+				ReceiverCreateSettings: recSet,
+				MetricNaming:           tt.naming,
+			}, true)
 			require.NoError(t, err)
+			ctx := rec.StartTracesOp(context.Background())
+			rec.EndTracesOp(ctx, format, 7, nil)
 
-			ctx := rec.StartLogsOp(parentCtx)
-			assert.NotNil(t, ctx)
-			rec.EndLogsOp(ctx, format, params[i].items, param.err)
-		}
+			procSet := processortest.NewNopCreateSettings()
+			procSet.MeterProvider = mp
+			procSet.MetricsLevel = configtelemetry.LevelBasic
+			proc, err := newProcessor(ProcessorSettings{
+				ProcessorID:             processorID,
+				ProcessorCreateSettings: procSet,
+				MetricNaming:            tt.naming,
+			}, true)
+			require.NoError(t, err)
+			proc.TracesAccepted(context.Background(), 7)
 
-		spans := tt.SpanRecorder.Ended()
-		require.Equal(t, len(params), len(spans))
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			var names []string
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					names = append(names, m.Name)
+				}
+			}
+			assert.Contains(t, names, tt.wantReceiver)
+			assert.Contains(t, names, tt.wantProcessor)
+		})
+	}
+}
 
-		var acceptedLogRecords, refusedLogRecords int
-		for i, span := range spans {
-			assert.Equal(t, "receiver/"+receiverID.String()+"/LogsReceived", span.Name())
-			switch {
-			case params[i].err == nil:
-				acceptedLogRecords += params[i].items
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedLogRecordsKey, Value: attribute.Int64Value(int64(params[i].items))})
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedLogRecordsKey, Value: attribute.Int64Value(0)})
-				assert.Equal(t, codes.Unset, span.Status().Code)
-			case errors.Is(params[i].err, errFake):
-				refusedLogRecords += params[i].items
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedLogRecordsKey, Value: attribute.Int64Value(0)})
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedLogRecordsKey, Value: attribute.Int64Value(int64(params[i].items))})
-				assert.Equal(t, codes.Error, span.Status().Code)
-				assert.Equal(t, params[i].err.Error(), span.Status().Description)
-			default:
-				t.Fatalf("unexpected param: %v", params[i])
+func TestInstanceID(t *testing.T) {
+	const instanceID = "instance-1"
+
+	t.Run("otel", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+
+		recSet := receivertest.NewNopCreateSettings()
+		recSet.MeterProvider = mp
+		recSet.MetricsLevel = configtelemetry.LevelBasic
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: recSet,
+			InstanceID:             instanceID,
+		}, true)
+		require.NoError(t, err)
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 7, nil)
+
+		procSet := processortest.NewNopCreateSettings()
+		procSet.MeterProvider = mp
+		procSet.MetricsLevel = configtelemetry.LevelBasic
+		proc, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: procSet,
+		}, true)
+		require.NoError(t, err)
+		proc.TracesAccepted(context.Background(), 7)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		instanceIDs := map[string]string{}
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				sum, ok := m.Data.(metricdata.Sum[int64])
+				require.True(t, ok, m.Name)
+				require.Len(t, sum.DataPoints, 1, m.Name)
+				v, _ := sum.DataPoints[0].Attributes.Value(obsmetrics.CollectorInstanceIDKey)
+				instanceIDs[m.Name] = v.AsString()
 			}
 		}
-		require.NoError(t, tt.CheckReceiverLogs(transport, int64(acceptedLogRecords), int64(refusedLogRecords)))
+		// The processor did not opt in to the tag.
+		assert.Equal(t, instanceID, instanceIDs["receiver/accepted_spans"])
+		assert.Equal(t, "", instanceIDs["processor/accepted_spans"])
+	})
+
+	t.Run("opencensus", func(t *testing.T) {
+		tt, err := obsreporttest.SetupTelemetry(receiverID)
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+		// The views are registered with the collector instance tag before recording.
+		views := obsreportconfig.WithInstanceIDTag(obsreportconfig.AllViews(configtelemetry.LevelNormal))
+		view.Unregister(views...)
+		require.NoError(t, view.Register(views...))
+
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			InstanceID:             instanceID,
+		}, false)
+		require.NoError(t, err)
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 7, nil)
+
+		rows, err := view.RetrieveData(obsmetrics.ReceiverAcceptedSpans.Name())
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Contains(t, rows[0].Tags, tag.Tag{Key: obsmetrics.TagKeyCollectorInstanceID, Value: instanceID})
 	})
 }
 
-func TestReceiveMetricsOp(t *testing.T) {
-	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
-		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
-		defer parentSpan.End()
+func TestNewComponent(t *testing.T) {
+	tests := []struct {
+		kind       component.Kind
+		record     func(c *Component)
+		wantPrefix string
+	}{
+		{
+			kind: component.KindReceiver,
+			record: func(c *Component) {
+				ctx := c.Receiver().StartTracesOp(context.Background())
+				c.Receiver().EndTracesOp(ctx, format, 7, nil)
+			},
+			wantPrefix: "receiver/",
+		},
+		{
+			kind: component.KindProcessor,
+			record: func(c *Component) {
+				c.Processor().TracesAccepted(context.Background(), 7)
+			},
+			wantPrefix: "processor/",
+		},
+		{
+			kind: component.KindExporter,
+			record: func(c *Component) {
+				ctx := c.Exporter().StartTracesOp(context.Background())
+				c.Exporter().EndTracesOp(ctx, 7, nil)
+			},
+			wantPrefix: "exporter/",
+		},
+		{
+			kind: component.KindConnector,
+			record: func(c *Component) {
+				ctx := c.Receiver().StartTracesOp(context.Background())
+				c.Receiver().EndTracesOp(ctx, format, 7, nil)
+				ctx = c.Exporter().StartTracesOp(context.Background())
+				c.Exporter().EndTracesOp(ctx, 7, nil)
+			},
+			wantPrefix: "connector/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantPrefix, func(t *testing.T) {
+			reader := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+			t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
 
-		params := []testParams{
-			{items: 23, err: errFake},
-			{items: 29, err: nil},
-		}
-		for i, param := range params {
-			rec, err := newReceiver(ReceiverSettings{
-				ReceiverID:             receiverID,
-				Transport:              transport,
-				ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
-			}, useOtel)
+			set := componenttest.NewNopTelemetrySettings()
+			set.MeterProvider = mp
+			set.MetricsLevel = configtelemetry.LevelBasic
+			c, err := newComponent(tt.kind, receiverID, ComponentSettings{
+				TelemetrySettings: set,
+				Transport:         transport,
+			}, true)
 			require.NoError(t, err)
+			assert.Equal(t, tt.kind, c.Kind())
+			assert.Equal(t, tt.kind == component.KindProcessor, c.Processor() != nil)
+			assert.Equal(t, tt.kind == component.KindConnector, c.Connector() != nil)
+			tt.record(c)
 
-			ctx := rec.StartMetricsOp(parentCtx)
-			assert.NotNil(t, ctx)
-			rec.EndMetricsOp(ctx, format, params[i].items, param.err)
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, reader.Collect(context.Background(), &rm))
+			var names []string
+			for _, sm := range rm.ScopeMetrics {
+				for _, m := range sm.Metrics {
+					names = append(names, m.Name)
+					assert.True(t, strings.HasPrefix(m.Name, tt.wantPrefix), m.Name)
+				}
+			}
+			assert.NotEmpty(t, names)
+		})
+	}
+
+	_, err := NewComponent(component.KindExtension, receiverID, ComponentSettings{
+		TelemetrySettings: componenttest.NewNopTelemetrySettings(),
+	})
+	assert.Error(t, err)
+}
+
+func TestComponentRecordSaturation(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		c, err := newComponent(component.KindExporter, exporterID, ComponentSettings{TelemetrySettings: tt.TelemetrySettings}, useOtel)
+		require.NoError(t, err)
+
+		c.RecordSaturation(context.Background(), 0.25)
+		require.NoError(t, tt.CheckSaturation(component.KindExporter, 0.25))
+		// The gauge reflects the last recorded value.
+		c.RecordSaturation(context.Background(), 0.75)
+		require.NoError(t, tt.CheckSaturation(component.KindExporter, 0.75))
+
+		// The values outside of [0, 1] are clamped and NaN is ignored.
+		c.RecordSaturation(context.Background(), 1.5)
+		require.NoError(t, tt.CheckSaturation(component.KindExporter, 1))
+		c.RecordSaturation(context.Background(), math.NaN())
+		require.NoError(t, tt.CheckSaturation(component.KindExporter, 1))
+		c.RecordSaturation(context.Background(), -0.5)
+		require.NoError(t, tt.CheckSaturation(component.KindExporter, 0))
+	})
+}
+
+func TestComponentRecordConcurrency(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		c, err := newComponent(component.KindProcessor, processorID, ComponentSettings{TelemetrySettings: tt.TelemetrySettings}, useOtel)
+		require.NoError(t, err)
+
+		// Nothing is reported until the concurrency is recorded.
+		require.Error(t, tt.CheckConcurrency(component.KindProcessor, 0))
+
+		c.RecordConcurrency(context.Background(), 4)
+		require.NoError(t, tt.CheckConcurrency(component.KindProcessor, 4))
+		// The gauge reflects the configuration after a reconfiguration.
+		c.RecordConcurrency(context.Background(), 8)
+		require.NoError(t, tt.CheckConcurrency(component.KindProcessor, 8))
+
+		// Negative values are ignored.
+		c.RecordConcurrency(context.Background(), -1)
+		require.NoError(t, tt.CheckConcurrency(component.KindProcessor, 8))
+	})
+}
+
+func TestComponentRecordCounter(t *testing.T) {
+	t.Run("opentelemetry", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+
+		set := componenttest.NewNopTelemetrySettings()
+		set.MeterProvider = mp
+		set.MetricsLevel = configtelemetry.LevelBasic
+		c, err := newComponent(component.KindProcessor, processorID, ComponentSettings{
+			TelemetrySettings: set,
+			Counters:          []CounterSettings{{Name: "cache_evictions", Unit: "{evictions}", AttributeKeys: []attribute.Key{"cache"}}},
+		}, true)
+		require.NoError(t, err)
+		c.RecordCounter(context.Background(), "cache_evictions", 3, attribute.String("cache", "spans"))
+		c.RecordCounter(context.Background(), "cache_evictions", 4, attribute.String("cache", "spans"))
+		c.RecordCounter(context.Background(), "cache_evictions", 5, attribute.String("cache", "metrics"), attribute.String("undeclared", "ignored"))
+		c.RecordCounter(context.Background(), "undeclared", 1)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+		require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+		m := rm.ScopeMetrics[0].Metrics[0]
+		assert.Equal(t, "processor/cache_evictions", m.Name)
+		assert.Equal(t, "{evictions}", m.Unit)
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		values := map[string]int64{}
+		for _, dp := range sum.DataPoints {
+			proc, _ := dp.Attributes.Value(obsmetrics.ProcessorKey)
+			assert.Equal(t, processorID.String(), proc.AsString())
+			cache, _ := dp.Attributes.Value("cache")
+			values[cache.AsString()] = dp.Value
+			assert.False(t, dp.Attributes.HasValue("undeclared"))
 		}
+		assert.Equal(t, map[string]int64{"spans": 7, "metrics": 5}, values)
+	})
 
-		spans := tt.SpanRecorder.Ended()
-		require.Equal(t, len(params), len(spans))
+	t.Run("opencensus", func(t *testing.T) {
+		set := componenttest.NewNopTelemetrySettings()
+		set.MetricsLevel = configtelemetry.LevelBasic
+		counters := []CounterSettings{{Name: "reconnects", Unit: "{reconnects}", AttributeKeys: []attribute.Key{"reason"}}}
+		c, err := newComponent(component.KindExporter, exporterID, ComponentSettings{TelemetrySettings: set, Counters: counters}, false)
+		require.NoError(t, err)
+		// The view is registered with the component, and shared by the components declaring the same counter.
+		v := view.Find("exporter/reconnects")
+		require.NotNil(t, v)
+		assert.Equal(t, "{reconnects}", v.Measure.Unit())
+		assert.Equal(t, []tag.Key{obsmetrics.TagKeyExporter, tag.MustNewKey("reason")}, v.TagKeys)
+		other, err := newComponent(component.KindExporter, exporterID, ComponentSettings{TelemetrySettings: set, Counters: counters}, false)
+		require.NoError(t, err)
 
-		var acceptedMetricPoints, refusedMetricPoints int
-		for i, span := range spans {
-			assert.Equal(t, "receiver/"+receiverID.String()+"/MetricsReceived", span.Name())
-			switch {
-			case params[i].err == nil:
-				acceptedMetricPoints += params[i].items
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedMetricPointsKey, Value: attribute.Int64Value(0)})
-				assert.Equal(t, codes.Unset, span.Status().Code)
-			case errors.Is(params[i].err, errFake):
-				refusedMetricPoints += params[i].items
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedMetricPointsKey, Value: attribute.Int64Value(0)})
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
-				assert.Equal(t, codes.Error, span.Status().Code)
-				assert.Equal(t, params[i].err.Error(), span.Status().Description)
-			default:
-				t.Fatalf("unexpected param: %v", params[i])
-			}
+		c.RecordCounter(context.Background(), "reconnects", 2, attribute.String("reason", "timeout"))
+		c.RecordCounter(context.Background(), "reconnects", 1, attribute.String("reason", "timeout"))
+
+		rows, err := view.RetrieveData("exporter/reconnects")
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Contains(t, rows[0].Tags, tag.Tag{Key: obsmetrics.TagKeyExporter, Value: exporterID.String()})
+		assert.Contains(t, rows[0].Tags, tag.Tag{Key: tag.MustNewKey("reason"), Value: "timeout"})
+		assert.Equal(t, float64(3), rows[0].Data.(*view.SumData).Value)
+
+		// The view is unregistered once the last component using it is shut down.
+		require.NoError(t, c.Shutdown(context.Background()))
+		require.NoError(t, c.Shutdown(context.Background()))
+		assert.NotNil(t, view.Find("exporter/reconnects"))
+		require.NoError(t, other.Shutdown(context.Background()))
+		assert.Nil(t, view.Find("exporter/reconnects"))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		set := componenttest.NewNopTelemetrySettings()
+		for _, counters := range [][]CounterSettings{
+			{{Unit: "{connections}"}},
+			{{Name: "dropped_connections"}},
+			{{Name: "dropped_connections", Unit: "{connections}"}, {Name: "dropped_connections", Unit: "{connections}"}},
+		} {
+			_, err := newComponent(component.KindReceiver, receiverID, ComponentSettings{TelemetrySettings: set, Counters: counters}, false)
+			assert.Error(t, err)
+			assert.Nil(t, view.Find("receiver/dropped_connections"))
 		}
+	})
 
-		require.NoError(t, tt.CheckReceiverMetrics(transport, int64(acceptedMetricPoints), int64(refusedMetricPoints)))
+	t.Run("none", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+
+		set := componenttest.NewNopTelemetrySettings()
+		set.MeterProvider = mp
+		set.MetricsLevel = configtelemetry.LevelNone
+		c, err := newComponent(component.KindReceiver, receiverID, ComponentSettings{
+			TelemetrySettings: set,
+			Counters:          []CounterSettings{{Name: "dropped_connections", Unit: "{connections}"}},
+		}, true)
+		require.NoError(t, err)
+		c.RecordCounter(context.Background(), "dropped_connections", 1)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		assert.Empty(t, rm.ScopeMetrics)
 	})
 }
 
-func TestScrapeMetricsDataOp(t *testing.T) {
-	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
-		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
-		defer parentSpan.End()
+func TestMetricUnits(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
 
-		params := []testParams{
-			{items: 23, err: partialErrFake},
-			{items: 29, err: errFake},
-			{items: 15, err: nil},
-		}
-		for i := range params {
-			scrp, err := newScraper(ScraperSettings{
-				ReceiverID:             receiverID,
-				Scraper:                scraperID,
-				ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
-			}, useOtel)
-			require.NoError(t, err)
-			ctx := scrp.StartMetricsOp(parentCtx)
-			assert.NotNil(t, ctx)
-			scrp.EndMetricsOp(ctx, params[i].items, params[i].err)
+	recSet := receivertest.NewNopCreateSettings()
+	recSet.MeterProvider = mp
+	recSet.MetricsLevel = configtelemetry.LevelDetailed
+	rec, err := newReceiver(ReceiverSettings{
+		ReceiverID:             receiverID,
+		Transport:              transport,
+		ReceiverCreateSettings: recSet,
+	}, true)
+	require.NoError(t, err)
+	ctx := rec.StartTracesOp(context.Background())
+	rec.RecordFirstByte(ctx)
+	rec.EndTracesOp(ctx, format, 7, nil)
+	ctx = rec.StartLogsOp(context.Background())
+	rec.EndLogsOpWeighted(ctx, format, 7, 1024, nil)
+
+	procSet := processortest.NewNopCreateSettings()
+	procSet.MeterProvider = mp
+	procSet.MetricsLevel = configtelemetry.LevelDetailed
+	proc, err := newProcessor(ProcessorSettings{
+		ProcessorID:             processorID,
+		ProcessorCreateSettings: procSet,
+	}, true)
+	require.NoError(t, err)
+	proc.MetricsAccepted(context.Background(), 7)
+	proc.RecordFlushReason(context.Background(), FlushReasonSize)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	units := map[string]string{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			units[m.Name] = m.Unit
 		}
+	}
+	assert.Equal(t, map[string]string{
+		"receiver/accepted_spans":            "{spans}",
+		"receiver/refused_spans":             "{spans}",
+		"receiver/accepted_log_records":      "{records}",
+		"receiver/refused_log_records":       "{records}",
+		"receiver/accepted_log_record_bytes": "By",
+		"receiver/first_byte_latency":        "ms",
+		"processor/accepted_metric_points":   "{datapoints}",
+		"processor/refused_metric_points":    "{datapoints}",
+		"processor/dropped_metric_points":    "{datapoints}",
+		"processor/flush_by_reason":          "{flushes}",
+		"processor/timeout_flush_ratio":      "{ratio}",
+		"obsreport/overhead_ns":              "ns",
+	}, units)
+}
 
-		spans := tt.SpanRecorder.Ended()
-		require.Equal(t, len(params), len(spans))
+func TestOptionalInstrumentsCreatedLazily(t *testing.T) {
+	mp := &instrumentsMeterProvider{MeterProvider: sdkmetric.NewMeterProvider()}
 
-		var scrapedMetricPoints, erroredMetricPoints int
-		for i, span := range spans {
-			assert.Equal(t, "scraper/"+receiverID.String()+"/"+scraperID.String()+"/MetricsScraped", span.Name())
-			switch {
-			case params[i].err == nil:
-				scrapedMetricPoints += params[i].items
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(0)})
-				assert.Equal(t, codes.Unset, span.Status().Code)
-			case errors.Is(params[i].err, errFake):
-				erroredMetricPoints += params[i].items
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(0)})
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
-				assert.Equal(t, codes.Error, span.Status().Code)
-				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+	recSet := receivertest.NewNopCreateSettings()
+	recSet.MeterProvider = mp
+	recSet.MetricsLevel = configtelemetry.LevelNormal
+	rec, err := newReceiver(ReceiverSettings{
+		ReceiverID:             receiverID,
+		Transport:              transport,
+		ReceiverCreateSettings: recSet,
+	}, true)
+	require.NoError(t, err)
 
-			case errors.Is(params[i].err, partialErrFake):
-				scrapedMetricPoints += params[i].items
-				erroredMetricPoints++
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(1)})
-				assert.Equal(t, codes.Error, span.Status().Code)
-				assert.Equal(t, params[i].err.Error(), span.Status().Description)
-			default:
-				t.Fatalf("unexpected err param: %v", params[i].err)
+	procSet := processortest.NewNopCreateSettings()
+	procSet.MeterProvider = mp
+	procSet.MetricsLevel = configtelemetry.LevelNormal
+	proc, err := newProcessor(ProcessorSettings{
+		ProcessorID:             processorID,
+		ProcessorCreateSettings: procSet,
+	}, true)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"receiver/accepted_spans", "receiver/refused_spans",
+		"receiver/accepted_metric_points", "receiver/refused_metric_points",
+		"receiver/accepted_log_records", "receiver/refused_log_records",
+		"processor/accepted_spans", "processor/refused_spans", "processor/dropped_spans",
+		"processor/accepted_metric_points", "processor/refused_metric_points", "processor/dropped_metric_points",
+		"processor/accepted_log_records", "processor/refused_log_records", "processor/dropped_log_records",
+		"processor/timeout_flush_ratio", "processor/effective_sample_ratio",
+	}, mp.names())
+
+	rec.RecordKeepalive(context.Background())
+	rec.RecordKeepalive(context.Background())
+	proc.TracesDeduplicated(context.Background(), 1)
+	proc.LogsDeduplicated(context.Background(), 1)
+	proc.RecordOutOfOrder(context.Background(), component.DataTypeMetrics, 1)
+	// Unknown signals do not create any instrument.
+	proc.RecordOutOfOrder(context.Background(), component.DataType("profiles"), 1)
+
+	created := mp.names()
+	assert.Len(t, created, 21)
+	assert.Contains(t, created, "receiver/keepalives")
+	assert.Contains(t, created, "processor/deduplicated_spans")
+	assert.Contains(t, created, "processor/deduplicated_log_records")
+	assert.Contains(t, created, "processor/out_of_order_metric_points")
+}
+
+// instrumentsMeterProvider records the names of the instruments created by its meters.
+type instrumentsMeterProvider struct {
+	metric.MeterProvider
+
+	mu      sync.Mutex
+	created []string
+}
+
+func (mp *instrumentsMeterProvider) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	return instrumentsMeter{Meter: mp.MeterProvider.Meter(name, opts...), mp: mp}
+}
+
+func (mp *instrumentsMeterProvider) add(name string) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	mp.created = append(mp.created, name)
+}
+
+func (mp *instrumentsMeterProvider) names() []string {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	return append([]string(nil), mp.created...)
+}
+
+type instrumentsMeter struct {
+	metric.Meter
+	mp *instrumentsMeterProvider
+}
+
+func (m instrumentsMeter) Int64Counter(name string, opts ...instrument.Int64Option) (instrument.Int64Counter, error) {
+	m.mp.add(name)
+	return m.Meter.Int64Counter(name, opts...)
+}
+
+func (m instrumentsMeter) Int64UpDownCounter(name string, opts ...instrument.Int64Option) (instrument.Int64UpDownCounter, error) {
+	m.mp.add(name)
+	return m.Meter.Int64UpDownCounter(name, opts...)
+}
+
+func (m instrumentsMeter) Int64Histogram(name string, opts ...instrument.Int64Option) (instrument.Int64Histogram, error) {
+	m.mp.add(name)
+	return m.Meter.Int64Histogram(name, opts...)
+}
+
+func (m instrumentsMeter) Float64Histogram(name string, opts ...instrument.Float64Option) (instrument.Float64Histogram, error) {
+	m.mp.add(name)
+	return m.Meter.Float64Histogram(name, opts...)
+}
+
+func (m instrumentsMeter) Float64ObservableGauge(name string, opts ...instrument.Float64ObserverOption) (instrument.Float64ObservableGauge, error) {
+	m.mp.add(name)
+	return m.Meter.Float64ObservableGauge(name, opts...)
+}
+
+func TestAttrsInterner(t *testing.T) {
+	attrs := []attribute.KeyValue{attribute.String(obsmetrics.ExporterKey, exporterID.String())}
+	mutators := []tag.Mutator{tag.Upsert(obsmetrics.TagKeyExporter, exporterID.String(), tag.WithTTL(tag.TTLNoPropagation))}
+	ai := newAttrsInterner(attrs, mutators)
+
+	ready := ai.with(obsmetrics.TagKeyState, ConnectionStateReady)
+	assert.Equal(t, withAttrs(attrs, attribute.String(obsmetrics.StateKey, ConnectionStateReady)), ready.kvs)
+	require.Len(t, ready.mutators, 2)
+	again := ai.with(obsmetrics.TagKeyState, ConnectionStateReady)
+	assert.Same(t, &ready.kvs[0], &again.kvs[0])
+	assert.Same(t, &ready.mutators[0], &again.mutators[0])
+	assert.Len(t, attrs, 1, "the attributes of the component must not be modified")
+
+	ctx, err := tag.New(context.Background(), ready.mutators...)
+	require.NoError(t, err)
+	v, ok := tag.FromContext(ctx).Value(obsmetrics.TagKeyState)
+	assert.True(t, ok)
+	assert.Equal(t, ConnectionStateReady, v)
+
+	pair := ai.with2(obsmetrics.TagKeySignal, string(component.DataTypeTraces), obsmetrics.TagKeyState, ConnectionStateReady)
+	assert.Equal(t, withAttrs(attrs,
+		attribute.String(obsmetrics.SignalKey, string(component.DataTypeTraces)),
+		attribute.String(obsmetrics.StateKey, ConnectionStateReady)), pair.kvs)
+	require.Len(t, pair.mutators, 3)
+	again = ai.with2(obsmetrics.TagKeySignal, string(component.DataTypeTraces), obsmetrics.TagKeyState, ConnectionStateReady)
+	assert.Same(t, &pair.kvs[0], &again.kvs[0])
+
+	// The cache is capped, the values past the cap are built on every call.
+	for i := 0; i < maxInternedAttrs+10; i++ {
+		ia := ai.with(obsmetrics.TagKeyState, strconv.Itoa(i))
+		assert.Equal(t, strconv.Itoa(i), ia.kvs[len(ia.kvs)-1].Value.AsString())
+	}
+	assert.Len(t, ai.cache, maxInternedAttrs)
+
+	// The tag map of the component is reused when the context has no tags.
+	tagged := ai.newContext(context.Background())
+	v, ok = tag.FromContext(tagged).Value(obsmetrics.TagKeyExporter)
+	assert.True(t, ok)
+	assert.Equal(t, exporterID.String(), v)
+	assert.Same(t, ai.tagMap, tag.FromContext(tagged))
+	parentCtx, err := tag.New(context.Background(), tag.Upsert(obsmetrics.TagKeyState, ConnectionStateIdle))
+	require.NoError(t, err)
+	tagged = ai.newContext(parentCtx)
+	_, ok = tag.FromContext(tagged).Value(obsmetrics.TagKeyState)
+	assert.True(t, ok, "the tags of the caller context must be kept")
+}
+
+func TestObsreportOverhead(t *testing.T) {
+	t.Run("otel", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+
+		recSet := receivertest.NewNopCreateSettings()
+		recSet.MeterProvider = mp
+		recSet.MetricsLevel = configtelemetry.LevelDetailed
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: recSet,
+		}, true)
+		require.NoError(t, err)
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 7, nil)
+
+		expSet := exportertest.NewNopCreateSettings()
+		expSet.MeterProvider = mp
+		expSet.MetricsLevel = configtelemetry.LevelDetailed
+		exp, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: expSet,
+		}, true)
+		require.NoError(t, err)
+		ctx = exp.StartTracesOp(context.Background())
+		exp.EndTracesOp(ctx, 7, nil)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		overheads := map[string]int64{}
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name != "obsreport/overhead_ns" {
+					continue
+				}
+				sum, ok := m.Data.(metricdata.Sum[int64])
+				require.True(t, ok)
+				for _, dp := range sum.DataPoints {
+					kind, _ := dp.Attributes.Value(obsmetrics.ComponentKindKey)
+					overheads[kind.AsString()] += dp.Value
+				}
 			}
 		}
+		assert.Positive(t, overheads[obsmetrics.ReceiverKey])
+		assert.Positive(t, overheads[obsmetrics.ExporterKey])
+	})
+
+	t.Run("opencensus", func(t *testing.T) {
+		tt, err := obsreporttest.SetupTelemetry(receiverID)
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+		recSet := tt.ToReceiverCreateSettings()
+		recSet.MetricsLevel = configtelemetry.LevelDetailed
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: recSet,
+		}, false)
+		require.NoError(t, err)
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 7, nil)
+
+		rows, err := view.RetrieveData(obsmetrics.ObsreportOverhead.Name())
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, []tag.Tag{{Key: obsmetrics.TagKeyComponentKind, Value: obsmetrics.ReceiverKey}}, rows[0].Tags)
+		sum, ok := rows[0].Data.(*view.SumData)
+		require.True(t, ok)
+		assert.Positive(t, sum.Value)
+	})
+
+	t.Run("not detailed", func(t *testing.T) {
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+
+		recSet := receivertest.NewNopCreateSettings()
+		recSet.MeterProvider = mp
+		recSet.MetricsLevel = configtelemetry.LevelNormal
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: recSet,
+		}, true)
+		require.NoError(t, err)
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 7, nil)
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				assert.NotEqual(t, "obsreport/overhead_ns", m.Name)
+			}
+		}
+	})
+}
+
+func TestOtelCountersConsistency(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+
+	recSet := receivertest.NewNopCreateSettings()
+	recSet.MeterProvider = mp
+	recSet.MetricsLevel = configtelemetry.LevelBasic
+	rec, err := newReceiver(ReceiverSettings{
+		ReceiverID:             receiverID,
+		Transport:              transport,
+		ReceiverCreateSettings: recSet,
+	}, true)
+	require.NoError(t, err)
+
+	expSet := exportertest.NewNopCreateSettings()
+	expSet.MeterProvider = mp
+	expSet.MetricsLevel = configtelemetry.LevelBasic
+	exp, err := newExporter(ExporterSettings{
+		ExporterID:             exporterID,
+		ExporterCreateSettings: expSet,
+	}, true)
+	require.NoError(t, err)
+
+	// Every operation accepts (or sends) and refuses (or fails to send) one span:
+	// the collections made while recording never see a counter going backwards,
+	// and once the operations are done both counters have the same value.
+	partialErr := PartialError{Accepted: 1, Refused: 1, Err: errFake}
+	const numWorkers, numOps = 4, 500
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numOps; j++ {
+				ctx := rec.StartTracesOp(context.Background())
+				rec.EndTracesOp(ctx, format, 2, partialErr)
+				ctx = exp.StartTracesOp(context.Background())
+				exp.EndTracesOp(ctx, 2, partialErr)
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	collect := func() map[string]int64 {
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		values := map[string]int64{}
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if sum, ok := m.Data.(metricdata.Sum[int64]); ok && len(sum.DataPoints) == 1 {
+					values[m.Name] = sum.DataPoints[0].Value
+				}
+			}
+		}
+		return values
+	}
+	previous := map[string]int64{}
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		values := collect()
+		for name, value := range values {
+			require.GreaterOrEqual(t, value, previous[name], name)
+		}
+		previous = values
+	}
+
+	values := collect()
+	assert.Equal(t, int64(numWorkers*numOps), values["receiver/accepted_spans"])
+	assert.Equal(t, int64(numWorkers*numOps), values["receiver/refused_spans"])
+	assert.Equal(t, int64(numWorkers*numOps), values["exporter/sent_spans"])
+	assert.Equal(t, int64(numWorkers*numOps), values["exporter/send_failed_spans"])
+}
+
+func TestOtelSharedAttrsConcurrentUse(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+
+	recSet := receivertest.NewNopCreateSettings()
+	recSet.MeterProvider = mp
+	recSet.MetricsLevel = configtelemetry.LevelBasic
+	rec, err := newReceiver(ReceiverSettings{
+		ReceiverID:             receiverID,
+		Transport:              transport,
+		ReceiverCreateSettings: recSet,
+		ValidationFields:       []string{"trace_id"},
+	}, true)
+	require.NoError(t, err)
+
+	expSet := exportertest.NewNopCreateSettings()
+	expSet.MeterProvider = mp
+	expSet.MetricsLevel = configtelemetry.LevelBasic
+	exp, err := newExporter(ExporterSettings{
+		ExporterID:             exporterID,
+		ExporterCreateSettings: expSet,
+	}, true)
+	require.NoError(t, err)
+
+	// The SDK sorts the attributes it is given in place, so this fails with -race if the
+	// interned or component attributes are passed to the instruments without a copy.
+	const numWorkers, numOps = 4, 100
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numOps; j++ {
+				rec.RecordValidationError(context.Background(), "trace_id")
+				rec.RecordStreamClose(context.Background(), StreamCloseEOF)
+				rec.RecordAuthFailure(context.Background())
+				exp.EndTracesOpWithCode(context.Background(), 1, "UNAVAILABLE", errFake)
+				exp.RecordConnectionState(context.Background(), ConnectionStateReady)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	values := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && len(sum.DataPoints) == 1 {
+				values[m.Name] = sum.DataPoints[0].Value
+			}
+		}
+	}
+	assert.Equal(t, int64(numWorkers*numOps), values["receiver/validation_errors"])
+	assert.Equal(t, int64(numWorkers*numOps), values["receiver/stream_closes"])
+	assert.Equal(t, int64(numWorkers*numOps), values["receiver/auth_failures"])
+}
+
+func TestFlush(t *testing.T) {
+	exp := &countingMetricExporter{}
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exp, sdkmetric.WithInterval(time.Hour))))
+	t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+
+	set := receivertest.NewNopCreateSettings()
+	set.MeterProvider = mp
+	set.MetricsLevel = configtelemetry.LevelBasic
+	rec, err := newReceiver(ReceiverSettings{
+		ReceiverID:             receiverID,
+		Transport:              transport,
+		ReceiverCreateSettings: set,
+	}, true)
+	require.NoError(t, err)
+	ctx := rec.StartTracesOp(context.Background())
+	rec.EndTracesOp(ctx, format, 7, nil)
+
+	require.NoError(t, Flush(context.Background(), mp))
+	assert.Equal(t, 1, exp.exports)
+
+	// The no-op MeterProvider has nothing to flush.
+	require.NoError(t, Flush(context.Background(), metric.NewNoopMeterProvider()))
+}
+
+// countingMetricExporter is a sdkmetric.Exporter counting the exports.
+type countingMetricExporter struct {
+	exports int
+}
+
+func (e *countingMetricExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return sdkmetric.DefaultTemporalitySelector(kind)
+}
+
+func (e *countingMetricExporter) Aggregation(kind sdkmetric.InstrumentKind) aggregation.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+func (e *countingMetricExporter) Export(context.Context, metricdata.ResourceMetrics) error {
+	e.exports++
+	return nil
+}
+
+func (e *countingMetricExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (e *countingMetricExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestReceiveTraceDataOpReset(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		for _, param := range []testParams{{items: 13, err: errFake}, {items: 42, err: nil}} {
+			require.NoError(t, tt.Reset(context.Background()))
+
+			rec, err := newReceiver(ReceiverSettings{
+				ReceiverID:             receiverID,
+				Transport:              transport,
+				ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			}, useOtel)
+			require.NoError(t, err)
+			ctx := rec.StartTracesOp(context.Background())
+			rec.EndTracesOp(ctx, format, param.items, param.err)
+
+			require.Len(t, tt.SpanRecorder.Ended(), 1)
+			if param.err != nil {
+				require.NoError(t, tt.CheckReceiverTraces(transport, 0, int64(param.items)))
+			} else {
+				require.NoError(t, tt.CheckReceiverTraces(transport, int64(param.items), 0))
+			}
+		}
+	})
+}
+
+func TestReceiveOpSignalLevels(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		tt.MetricsLevel = configtelemetry.LevelNone
+		normalLevel := configtelemetry.LevelNormal
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			SignalLevels:           SignalLevels{Metrics: &normalLevel},
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 7, nil)
+		ctx = rec.StartMetricsOp(context.Background())
+		rec.EndMetricsOp(ctx, format, 13, nil)
+		ctx = rec.StartLogsOp(context.Background())
+		rec.EndLogsOp(ctx, format, 17, nil)
+
+		require.Error(t, tt.CheckReceiverTraces(transport, 7, 0))
+		require.NoError(t, tt.CheckReceiverMetrics(transport, 13, 0))
+		require.Error(t, tt.CheckReceiverLogs(transport, 17, 0))
+	})
+}
+
+func TestReceiveTraceDataOpDetailed(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
+
+		params := []struct {
+			spans  int
+			events int
+			links  int
+			err    error
+		}{
+			{spans: 13, events: 31, links: 3, err: errFake},
+			{spans: 42, events: 97, links: 7, err: nil},
+		}
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		for _, param := range params {
+			ctx := rec.StartTracesOp(parentCtx)
+			assert.NotNil(t, ctx)
+			rec.EndTracesOpDetailed(ctx, format, param.spans, param.events, param.links, param.err)
+		}
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params), len(spans))
+
+		var acceptedSpans, refusedSpans, acceptedEvents, refusedEvents, acceptedLinks, refusedLinks int
+		for i, span := range spans {
+			if params[i].err == nil {
+				acceptedSpans += params[i].spans
+				acceptedEvents += params[i].events
+				acceptedLinks += params[i].links
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSpanEventsKey, Value: attribute.Int64Value(int64(params[i].events))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSpanLinksKey, Value: attribute.Int64Value(int64(params[i].links))})
+			} else {
+				refusedSpans += params[i].spans
+				refusedEvents += params[i].events
+				refusedLinks += params[i].links
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpanEventsKey, Value: attribute.Int64Value(int64(params[i].events))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpanLinksKey, Value: attribute.Int64Value(int64(params[i].links))})
+			}
+		}
+		require.NoError(t, tt.CheckReceiverTraces(transport, int64(acceptedSpans), int64(refusedSpans)))
+		require.NoError(t, tt.CheckReceiverTracesDetailed(transport, int64(acceptedEvents), int64(refusedEvents), int64(acceptedLinks), int64(refusedLinks)))
+
+		// The events and links of a partially accepted batch are split like the spans.
+		ctx := rec.StartTracesOp(parentCtx)
+		rec.EndTracesOpDetailed(ctx, format, 10, 20, 5, PartialError{Accepted: 6, Refused: 4, Err: errFake})
+		spans = tt.SpanRecorder.Ended()
+		require.Contains(t, spans[len(spans)-1].Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSpanEventsKey, Value: attribute.Int64Value(12)})
+		require.Contains(t, spans[len(spans)-1].Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpanEventsKey, Value: attribute.Int64Value(8)})
+		require.NoError(t, tt.CheckReceiverTraces(transport, int64(acceptedSpans+6), int64(refusedSpans+4)))
+		require.NoError(t, tt.CheckReceiverTracesDetailed(transport, int64(acceptedEvents+12), int64(refusedEvents+8), int64(acceptedLinks+3), int64(refusedLinks+2)))
+	})
+}
+
+func TestReceiveTraceDataOpWithStructure(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		tt.MetricsLevel = configtelemetry.LevelDetailed
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithStructure(ctx, format, 2, 5, 37, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithStructure(ctx, format, 1, 3, 11, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithStructure(ctx, format, 4, 4, 19, errFake)
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 48, 19))
+		require.NoError(t, tt.CheckReceiverTracesStructure(transport, 3, 8))
+	})
+}
+
+func TestReceiverDetailedMetricsNotRecorded(t *testing.T) {
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("service.namespace", "team-a")
+	tests := []struct {
+		name     string
+		settings ReceiverSettings
+		// record records the data, checking the metrics still recorded at the normal level.
+		record func(t *testing.T, tt *obsreporttest.TestTelemetry, rec *Receiver)
+		check  func(tt *obsreporttest.TestTelemetry) error
+	}{
+		{
+			name:     "items_per_core",
+			settings: ReceiverSettings{RecordItemsPerCore: true},
+			record: func(t *testing.T, tt *obsreporttest.TestTelemetry, rec *Receiver) {
+				ctx := rec.StartTracesOp(context.Background())
+				rec.EndTracesOp(ctx, format, 10, nil)
+				assert.Nil(t, rec.itemsPerCoreGauge)
+			},
+			check: func(tt *obsreporttest.TestTelemetry) error {
+				return tt.CheckReceiverItemsPerCore(transport, component.DataTypeTraces, 10)
+			},
+		},
+		{
+			name: "structure",
+			record: func(t *testing.T, tt *obsreporttest.TestTelemetry, rec *Receiver) {
+				ctx := rec.StartTracesOp(context.Background())
+				rec.EndTracesOpWithStructure(ctx, format, 2, 5, 37, nil)
+				require.NoError(t, tt.CheckReceiverTraces(transport, 37, 0))
+			},
+			check: func(tt *obsreporttest.TestTelemetry) error {
+				return tt.CheckReceiverTracesStructure(transport, 2, 5)
+			},
+		},
+		{
+			name: "attributes_per_span",
+			record: func(t *testing.T, tt *obsreporttest.TestTelemetry, rec *Receiver) {
+				ctx := rec.StartTracesOp(context.Background())
+				rec.EndTracesOpWithAttrStats(ctx, format, 4, 10, nil)
+				require.NoError(t, tt.CheckReceiverTraces(transport, 4, 0))
+			},
+			check: func(tt *obsreporttest.TestTelemetry) error {
+				return tt.CheckReceiverAttributesPerSpan(transport, 1, 2.5)
+			},
+		},
+		{
+			name: "tenant",
+			record: func(t *testing.T, tt *obsreporttest.TestTelemetry, rec *Receiver) {
+				ctx := rec.StartTracesOp(context.Background())
+				rec.EndTracesOpForTenant(ctx, format, 13, "tenant-a", nil)
+				require.NoError(t, tt.CheckReceiverTraces(transport, 13, 0))
+			},
+			check: func(tt *obsreporttest.TestTelemetry) error {
+				return tt.CheckReceiverTracesForTenant(transport, "tenant-a", 13, 0)
+			},
+		},
+		{
+			name: "empty_batches",
+			record: func(t *testing.T, tt *obsreporttest.TestTelemetry, rec *Receiver) {
+				ctx := rec.StartTracesOp(context.Background())
+				rec.EndTracesOp(ctx, format, 0, nil)
+				require.NoError(t, tt.CheckReceiverTraces(transport, 0, 0))
+			},
+			check: func(tt *obsreporttest.TestTelemetry) error {
+				return tt.CheckReceiverEmptyBatches(transport, 1)
+			},
+		},
+		{
+			name:     "distinct_traces",
+			settings: ReceiverSettings{EstimateDistinctTraces: true},
+			record: func(t *testing.T, tt *obsreporttest.TestTelemetry, rec *Receiver) {
+				rec.ObserveTraceID(context.Background(), pcommon.TraceID{1})
+			},
+			check: func(tt *obsreporttest.TestTelemetry) error {
+				return tt.CheckReceiverDistinctTraces(transport, 1)
+			},
+		},
+		{
+			name:     "distinct_resources",
+			settings: ReceiverSettings{EstimateDistinctResources: true},
+			record: func(t *testing.T, tt *obsreporttest.TestTelemetry, rec *Receiver) {
+				rec.RecordResource(context.Background(), pcommon.NewResource())
+			},
+			check: func(tt *obsreporttest.TestTelemetry) error {
+				return tt.CheckReceiverDistinctResources(transport, 1)
+			},
+		},
+		{
+			name: "volume",
+			record: func(t *testing.T, tt *obsreporttest.TestTelemetry, rec *Receiver) {
+				ctx := rec.StartTracesOp(context.Background())
+				rec.EndTracesOpWithVolume(ctx, format, []ResourceItems{{Resource: res, NumItems: 7}}, nil)
+				require.NoError(t, tt.CheckReceiverTraces(transport, 7, 0))
+				require.Error(t, tt.CheckReceiverTracesByVolume(transport, VolumeAttributeUnset, 7))
+			},
+			check: func(tt *obsreporttest.TestTelemetry) error {
+				return tt.CheckReceiverTracesByVolume(transport, "team-a", 7)
+			},
+		},
+		{
+			name: "deadline_remaining",
+			record: func(t *testing.T, tt *obsreporttest.TestTelemetry, rec *Receiver) {
+				deadlineCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+				defer cancel()
+				ctx := rec.StartTracesOp(deadlineCtx)
+				rec.EndTracesOp(ctx, format, 7, nil)
+				require.NoError(t, tt.CheckReceiverTraces(transport, 7, 0))
+			},
+			check: func(tt *obsreporttest.TestTelemetry) error {
+				return tt.CheckReceiverDeadlineRemaining(transport, 1, 0)
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+				set := tc.settings
+				set.ReceiverID = receiverID
+				set.Transport = transport
+				set.ReceiverCreateSettings = tt.ToReceiverCreateSettings()
+				rec, err := newReceiver(set, useOtel)
+				require.NoError(t, err)
+
+				tc.record(t, &tt, rec)
+				require.Error(t, tc.check(&tt))
+			})
+		})
+	}
+}
+
+func TestReceiverEventCounters(t *testing.T) {
+	tests := []struct {
+		name   string
+		record func(rec *Receiver, ctx context.Context)
+		check  func(tt *obsreporttest.TestTelemetry, protocol string, value int64) error
+	}{
+		{
+			name:   "schema_mismatches",
+			record: (*Receiver).RecordSchemaMismatch,
+			check:  (*obsreporttest.TestTelemetry).CheckReceiverSchemaMismatches,
+		},
+		{
+			name:   "auth_failures",
+			record: (*Receiver).RecordAuthFailure,
+			check:  (*obsreporttest.TestTelemetry).CheckReceiverAuthFailures,
+		},
+		{
+			name:   "read_errors",
+			record: (*Receiver).RecordReadError,
+			check:  (*obsreporttest.TestTelemetry).CheckReceiverReadErrors,
+		},
+		{
+			name:   "keepalives",
+			record: (*Receiver).RecordKeepalive,
+			check:  (*obsreporttest.TestTelemetry).CheckReceiverKeepalives,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+				rec, err := newReceiver(ReceiverSettings{
+					ReceiverID:             receiverID,
+					Transport:              transport,
+					ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+				}, useOtel)
+				require.NoError(t, err)
+
+				ctx := rec.StartTracesOp(context.Background())
+				tc.record(rec, ctx)
+				tc.record(rec, ctx)
+				rec.EndTracesOp(ctx, format, 7, nil)
+				tc.record(rec, context.Background())
+
+				require.NoError(t, tc.check(&tt, transport, 3))
+				// The events are not counted as refused items.
+				require.NoError(t, tt.CheckReceiverTraces(transport, 7, 0))
+			})
+		})
+	}
+}
+
+func TestReceiverValidationError(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			ValidationFields:       []string{"trace_id", "span.name"},
+		}, useOtel)
+		require.NoError(t, err)
+
+		rec.RecordValidationError(context.Background(), "trace_id")
+		rec.RecordValidationError(context.Background(), "trace_id")
+		rec.RecordValidationError(context.Background(), "trace_id")
+		rec.RecordValidationError(context.Background(), "span.name")
+		rec.RecordValidationError(context.Background(), "resource.service.name")
+		rec.RecordValidationError(context.Background(), "unbounded-field-from-client")
+
+		require.NoError(t, tt.CheckReceiverValidationErrors(transport, "trace_id", 3))
+		require.NoError(t, tt.CheckReceiverValidationErrors(transport, "span.name", 1))
+		require.NoError(t, tt.CheckReceiverValidationErrors(transport, ValidationFieldOther, 2))
+		require.Error(t, tt.CheckReceiverValidationErrors(transport, "resource.service.name", 1))
+	})
+}
+
+func TestReceiverStreamClose(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		reasons := map[string]int{
+			StreamCloseEOF:         4,
+			StreamCloseError:       3,
+			StreamCloseShutdown:    2,
+			StreamCloseIdleTimeout: 1,
+		}
+		for reason, n := range reasons {
+			for i := 0; i < n; i++ {
+				rec.RecordStreamClose(context.Background(), reason)
+			}
+		}
+		rec.RecordStreamClose(context.Background(), "unbounded-reason-from-client")
+
+		for reason, n := range reasons {
+			require.NoError(t, tt.CheckReceiverStreamCloses(transport, reason, int64(n)), reason)
+		}
+		require.NoError(t, tt.CheckReceiverStreamCloses(transport, StreamCloseOther, 1))
+		require.Error(t, tt.CheckReceiverStreamCloses(transport, "unbounded-reason-from-client", 1))
+	})
+}
+
+func TestReceiverConnections(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		rec.RecordConnection(context.Background(), true)
+		rec.RecordConnection(context.Background(), true)
+		rec.RecordConnection(context.Background(), false)
+		rec.RecordConnection(context.Background(), true)
+		require.NoError(t, tt.CheckReceiverConnections(transport, 3, 1, 3))
+
+		// Reused connections are already counted as active.
+		rec.RecordConnection(context.Background(), false)
+		rec.RecordConnectionClosed(context.Background())
+		rec.RecordConnectionClosed(context.Background())
+		require.NoError(t, tt.CheckReceiverConnections(transport, 3, 2, 1))
+	})
+}
+
+func TestHyperLogLog(t *testing.T) {
+	for _, distinct := range []int{10, 1000, 100000} {
+		t.Run(strconv.Itoa(distinct), func(t *testing.T) {
+			h := &hyperLogLog{}
+			for i := 0; i < distinct; i++ {
+				attrs := pcommon.NewMap()
+				attrs.PutStr("service.name", "svc-"+strconv.Itoa(i))
+				attrs.PutInt("shard", int64(i%7))
+				// Adding the same set of attributes again does not change the estimate.
+				h.add(hashAttributes(attrs))
+				h.add(hashAttributes(attrs))
+			}
+			assert.InEpsilon(t, distinct, h.estimate(), 0.05)
+
+			h.reset()
+			assert.Equal(t, int64(0), h.estimate())
+		})
+	}
+}
+
+func TestHashAttributesIgnoresOrder(t *testing.T) {
+	attrs := pcommon.NewMap()
+	attrs.PutStr("a", "1")
+	attrs.PutStr("b", "2")
+	reversed := pcommon.NewMap()
+	reversed.PutStr("b", "2")
+	reversed.PutStr("a", "1")
+	swapped := pcommon.NewMap()
+	swapped.PutStr("a", "2")
+	swapped.PutStr("b", "1")
+
+	assert.Equal(t, hashAttributes(attrs), hashAttributes(reversed))
+	assert.NotEqual(t, hashAttributes(attrs), hashAttributes(swapped))
+}
+
+func TestReceiverDistinctResources(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		set := tt.ToReceiverCreateSettings()
+		set.MetricsLevel = configtelemetry.LevelDetailed
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:                receiverID,
+			Transport:                 transport,
+			ReceiverCreateSettings:    set,
+			EstimateDistinctResources: true,
+		}, useOtel)
+		require.NoError(t, err)
+		now := time.Unix(1000, 0)
+		rec.now = func() time.Time { return now }
+
+		resource := func(name string) pcommon.Resource {
+			res := pcommon.NewResource()
+			res.Attributes().PutStr("service.name", name)
+			return res
+		}
+		for _, name := range []string{"a", "b", "c", "a", "b"} {
+			rec.RecordResource(context.Background(), resource(name))
+		}
+		require.NoError(t, tt.CheckReceiverDistinctResources(transport, 3))
+
+		// The estimate starts over in the next window.
+		now = now.Add(distinctResourcesWindow)
+		rec.RecordResource(context.Background(), resource("a"))
+		require.NoError(t, tt.CheckReceiverDistinctResources(transport, 1))
+	})
+}
+
+func TestReceiverItemsPerCore(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		set := tt.ToReceiverCreateSettings()
+		set.MetricsLevel = configtelemetry.LevelDetailed
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: set,
+			RecordItemsPerCore:     true,
+		}, useOtel)
+		require.NoError(t, err)
+		procs := 4
+		rec.gomaxprocs = func() int { return procs }
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 10, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 6, nil)
+		ctx = rec.StartMetricsOp(context.Background())
+		rec.EndMetricsOp(ctx, format, 3, nil)
+		// The refused items are not counted.
+		ctx = rec.StartLogsOp(context.Background())
+		rec.EndLogsOp(ctx, format, 5, errFake)
+
+		require.NoError(t, tt.CheckReceiverItemsPerCore(transport, component.DataTypeTraces, 4))
+		require.NoError(t, tt.CheckReceiverItemsPerCore(transport, component.DataTypeMetrics, 0.75))
+		require.NoError(t, tt.CheckReceiverItemsPerCore(transport, component.DataTypeLogs, 0))
+
+		procs = 8
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 8, nil)
+		require.NoError(t, tt.CheckReceiverItemsPerCore(transport, component.DataTypeTraces, 3))
+	})
+}
+
+func TestItemsPerCore(t *testing.T) {
+	tests := []struct {
+		name  string
+		items int64
+		procs int
+		want  float64
+	}{
+		{name: "no items", items: 0, procs: 4, want: 0},
+		{name: "single core", items: 7, procs: 1, want: 7},
+		{name: "even", items: 12, procs: 4, want: 3},
+		{name: "fraction", items: 3, procs: 4, want: 0.75},
+		{name: "no cores", items: 5, procs: 0, want: 5},
+		{name: "negative cores", items: 5, procs: -2, want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, itemsPerCore(tt.items, tt.procs))
+		})
+	}
+}
+
+func TestReceiverDistinctTraces(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		set := tt.ToReceiverCreateSettings()
+		set.MetricsLevel = configtelemetry.LevelDetailed
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: set,
+			EstimateDistinctTraces: true,
+		}, useOtel)
+		require.NoError(t, err)
+		now := time.Unix(1000, 0)
+		rec.now = func() time.Time { return now }
+
+		const distinct = 1000
+		for i := 0; i < distinct; i++ {
+			var id pcommon.TraceID
+			binary.BigEndian.PutUint64(id[8:], uint64(i))
+			// The spans of a trace may be received in several batches.
+			rec.ObserveTraceID(context.Background(), id)
+			rec.ObserveTraceID(context.Background(), id)
+		}
+		estimate := rec.distinctTraces.last
+		assert.InEpsilon(t, distinct, estimate, 0.05)
+		require.NoError(t, tt.CheckReceiverDistinctTraces(transport, estimate))
+
+		// The estimate starts over in the next window.
+		now = now.Add(distinctTracesWindow)
+		rec.ObserveTraceID(context.Background(), pcommon.TraceID{1})
+		require.NoError(t, tt.CheckReceiverDistinctTraces(transport, 1))
+	})
+}
+
+func TestReceiveEmptyBatch(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		tt.MetricsLevel = configtelemetry.LevelDetailed
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 0, nil)
+		ctx = rec.StartLogsOp(context.Background())
+		rec.EndLogsOp(ctx, format, 0, nil)
+		// Neither the failed nor the non-empty operations are counted.
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 0, errFake)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 7, nil)
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 7, 0))
+		require.NoError(t, tt.CheckReceiverEmptyBatches(transport, 2))
+	})
+}
+
+func TestReceiveTraceDataOpWithAttrStats(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		tt.MetricsLevel = configtelemetry.LevelDetailed
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithAttrStats(ctx, format, 4, 10, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithAttrStats(ctx, format, 2, 80, nil)
+		// Neither the failed nor the empty operations are recorded.
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithAttrStats(ctx, format, 3, 300, errFake)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithAttrStats(ctx, format, 0, 0, nil)
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 6, 3))
+		require.NoError(t, tt.CheckReceiverAttributesPerSpan(transport, 2, 2.5+40))
+	})
+}
+
+func TestReceiveParseDuration(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.RecordParseDuration(ctx, "protobuf", 2*time.Millisecond)
+		rec.EndTracesOp(ctx, "protobuf", 7, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.RecordParseDuration(ctx, "protobuf", 1500*time.Microsecond)
+		rec.EndTracesOp(ctx, "protobuf", 7, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.RecordParseDuration(ctx, "json", 5*time.Millisecond)
+		rec.EndTracesOp(ctx, "json", 7, nil)
+
+		require.NoError(t, tt.CheckReceiverParseDuration(transport, "protobuf", 2, 3.5))
+		require.NoError(t, tt.CheckReceiverParseDuration(transport, "json", 1, 5))
+	})
+}
+
+func TestReceiveTraceDataOpFirstByte(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		tt.MetricsLevel = configtelemetry.LevelDetailed
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.RecordFirstByte(ctx)
+		rec.EndTracesOp(ctx, format, 7, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.RecordFirstByte(ctx)
+		rec.EndTracesOp(ctx, format, 3, errFake)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, 2, len(spans))
+		for _, span := range spans {
+			require.Len(t, span.Events(), 1)
+			assert.Equal(t, firstByteEventName, span.Events()[0].Name)
+			assert.False(t, span.Events()[0].Time.After(span.EndTime()))
+		}
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 7, 3))
+		require.NoError(t, tt.CheckReceiverFirstByteLatency(transport, 2))
+	})
+}
+
+func TestReceiveOpFirstByteSignalLevels(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		detailedLevel := configtelemetry.LevelDetailed
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			SignalLevels:           SignalLevels{Traces: &detailedLevel},
+		}, useOtel)
+		require.NoError(t, err)
+
+		// Only the traces are at the detailed level.
+		ctx := rec.StartTracesOp(context.Background())
+		rec.RecordFirstByte(ctx)
+		rec.EndTracesOp(ctx, format, 7, nil)
+		ctx = rec.StartLogsOp(context.Background())
+		rec.RecordFirstByte(ctx)
+		rec.EndLogsOp(ctx, format, 3, nil)
+
+		require.NoError(t, tt.CheckReceiverFirstByteLatency(transport, 1))
+	})
+}
+
+func TestReceiverDeadlineRemaining(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:              receiverID,
+			Transport:               transport,
+			ReceiverCreateSettings:  tt.ToReceiverCreateSettings(),
+			RecordDeadlineRemaining: true,
+		}, useOtel)
+		require.NoError(t, err)
+		now := time.Unix(1000, 0)
+		rec.now = func() time.Time { return now }
+
+		deadlineCtx, cancel := context.WithDeadline(context.Background(), now.Add(250*time.Millisecond))
+		defer cancel()
+		ctx := rec.StartTracesOp(deadlineCtx)
+		rec.EndTracesOp(ctx, format, 7, nil)
+		// An expired deadline is recorded as no time remaining.
+		expiredCtx, cancel := context.WithDeadline(context.Background(), now.Add(-time.Second))
+		defer cancel()
+		ctx = rec.StartTracesOp(expiredCtx)
+		rec.EndTracesOp(ctx, format, 3, nil)
+		// The operations without a deadline are not recorded.
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 5, nil)
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 15, 0))
+		require.NoError(t, tt.CheckReceiverDeadlineRemaining(transport, 2, 250))
+	})
+}
+
+func TestReceiveTraceDataOpStatusMapper(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			StatusMapper: func(err error) (codes.Code, string) {
+				if errors.Is(err, context.Canceled) {
+					return codes.Unset, ""
+				}
+				return codes.Error, err.Error()
+			},
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 7, context.Canceled)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 3, errFake)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, 2, len(spans))
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+		assert.Equal(t, errFake.Error(), spans[1].Status().Description)
+
+		// The status mapping only affects the spans, the items are still refused.
+		require.NoError(t, tt.CheckReceiverTraces(transport, 0, 10))
+	})
+}
+
+func TestReceiveTraceDataOpWithSkew(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithSkew(ctx, format, map[string]int{ClockSkewOK: 31, ClockSkewFuture: 7, ClockSkewStale: 3}, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithSkew(ctx, format, map[string]int{ClockSkewOK: 5, "unexpected": 2}, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithSkew(ctx, format, map[string]int{ClockSkewStale: 11}, errFake)
+		// A PartialError refusing no span is a success, otherwise the spans cannot be attributed.
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithSkew(ctx, format, map[string]int{ClockSkewOK: 4}, PartialError{Accepted: 4})
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithSkew(ctx, format, map[string]int{ClockSkewFuture: 6}, PartialError{Accepted: 5, Refused: 1})
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 57, 12))
+		require.NoError(t, tt.CheckReceiverTracesBySkew(transport, ClockSkewOK, 40))
+		require.NoError(t, tt.CheckReceiverTracesBySkew(transport, ClockSkewFuture, 7))
+		require.NoError(t, tt.CheckReceiverTracesBySkew(transport, ClockSkewStale, 3))
+		require.NoError(t, tt.CheckReceiverTracesBySkew(transport, ClockSkewOther, 2))
+	})
+}
+
+func TestReceiveTraceDataOpBySampled(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOpBySampled(ctx, format, 13, 7, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpBySampled(ctx, format, 5, 0, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpBySampled(ctx, format, 3, 2, errFake)
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 25, 5))
+		// The refused spans are not broken down.
+		require.NoError(t, tt.CheckReceiverTracesBySampled(transport, 18, 7))
+	})
+}
+
+func TestReceiveTraceDataOpConverted(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOpConverted(ctx, "zipkin", "otlp", 13, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpConverted(ctx, "zipkin", "otlp", 6, PartialError{Accepted: 4, Refused: 2, Err: errFake})
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpConverted(ctx, "otlp", "otlp", 7, nil)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 3)
+		assert.Contains(t, spans[0].Attributes(), attribute.String(obsmetrics.FormatKey, "zipkin"))
+		assert.Contains(t, spans[2].Attributes(), attribute.String(obsmetrics.FormatKey, "otlp"))
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 24, 2))
+		require.NoError(t, tt.CheckReceiverTracesConverted(transport, "zipkin", "otlp", 17))
+		// Natively received spans are not converted.
+		require.Error(t, tt.CheckReceiverTracesConverted(transport, "otlp", "otlp", 7))
+	})
+}
+
+func TestReceiveTraceDataOpForTenant(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			RecordTenants:          true,
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOpForTenant(ctx, format, 13, "tenant-a", nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpForTenant(ctx, format, 5, "tenant-a", errFake)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpForTenant(ctx, format, 9, "tenant-b", PartialError{Accepted: 6, Refused: 3, Err: errFake})
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 19, 8))
+		require.NoError(t, tt.CheckReceiverTracesForTenant(transport, "tenant-a", 13, 5))
+		require.NoError(t, tt.CheckReceiverTracesForTenant(transport, "tenant-b", 6, 3))
+	})
+}
+
+func TestReceiveOpWithVolume(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			VolumeAttributeKey:     "service.namespace",
+		}, useOtel)
+		require.NoError(t, err)
+		resource := func(namespace string) pcommon.Resource {
+			res := pcommon.NewResource()
+			res.Attributes().PutStr("service.name", "svc")
+			if namespace != "" {
+				res.Attributes().PutStr("service.namespace", namespace)
+			}
+			return res
+		}
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithVolume(ctx, format, []ResourceItems{
+			{Resource: resource("team-a"), NumItems: 7},
+			{Resource: resource("team-b"), NumItems: 2},
+			{Resource: resource("team-a"), NumItems: 4},
+			{Resource: resource(""), NumItems: 3},
+		}, nil)
+		// The items of failed operations are not broken down.
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithVolume(ctx, format, []ResourceItems{{Resource: resource("team-b"), NumItems: 5}}, errFake)
+		ctx = rec.StartMetricsOp(context.Background())
+		rec.EndMetricsOpWithVolume(ctx, format, []ResourceItems{{Resource: resource("team-a"), NumItems: 13}}, nil)
+		ctx = rec.StartLogsOp(context.Background())
+		rec.EndLogsOpWithVolume(ctx, format, []ResourceItems{{Resource: resource("team-b"), NumItems: 17}}, nil)
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 16, 5))
+		require.NoError(t, tt.CheckReceiverTracesByVolume(transport, "team-a", 11))
+		require.NoError(t, tt.CheckReceiverTracesByVolume(transport, "team-b", 2))
+		require.NoError(t, tt.CheckReceiverTracesByVolume(transport, VolumeAttributeUnset, 3))
+		require.NoError(t, tt.CheckReceiverMetrics(transport, 13, 0))
+		require.NoError(t, tt.CheckReceiverMetricsByVolume(transport, "team-a", 13))
+		require.NoError(t, tt.CheckReceiverLogs(transport, 17, 0))
+		require.NoError(t, tt.CheckReceiverLogsByVolume(transport, "team-b", 17))
+	})
+}
+
+func TestReceiveTraceDataOpWithHTTPStatus(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithHTTPStatus(ctx, format, 13, http.StatusOK, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithHTTPStatus(ctx, format, 5, http.StatusTooManyRequests, errFake)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithHTTPStatus(ctx, format, 4, http.StatusTooManyRequests, errFake)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithHTTPStatus(ctx, format, 9, http.StatusServiceUnavailable, PartialError{Accepted: 6, Refused: 3, Err: errFake})
+		// Invalid status codes are not recorded.
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithHTTPStatus(ctx, format, 2, 0, errFake)
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 19, 14))
+		require.NoError(t, tt.CheckReceiverTracesRefusedByHTTPStatus(transport, http.StatusTooManyRequests, 9))
+		require.NoError(t, tt.CheckReceiverTracesRefusedByHTTPStatus(transport, http.StatusServiceUnavailable, 3))
+		require.Error(t, tt.CheckReceiverTracesRefusedByHTTPStatus(transport, http.StatusOK, 13))
+		require.Error(t, tt.CheckReceiverTracesRefusedByHTTPStatus(transport, 0, 2))
+	})
+}
+
+func TestReceiveTraceDataOpWithProtoVersion(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithProtoVersion(ctx, format, "v0.19", 7, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithProtoVersion(ctx, format, "v0.19", 3, PartialError{Accepted: 2, Refused: 1, Err: errFake})
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithProtoVersion(ctx, format, "v1.0", 11, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithProtoVersion(ctx, format, "v1.0", 4, errFake)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithProtoVersion(ctx, format, "", 5, nil)
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 25, 5))
+		require.NoError(t, tt.CheckReceiverTracesByProtoVersion(transport, "v0.19", 9))
+		require.NoError(t, tt.CheckReceiverTracesByProtoVersion(transport, "v1.0", 11))
+	})
+}
+
+func TestReceiveTraceDataOpWithProtoVersionBounded(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		for i := 0; i < maxProtoVersions+2; i++ {
+			ctx := rec.StartTracesOp(context.Background())
+			rec.EndTracesOpWithProtoVersion(ctx, format, fmt.Sprintf("v0.%d", i), 1, nil)
+		}
+		// The versions already reported are still reported once the limit is reached.
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithProtoVersion(ctx, format, "v0.0", 1, nil)
+
+		require.NoError(t, tt.CheckReceiverTracesByProtoVersion(transport, "v0.0", 2))
+		require.NoError(t, tt.CheckReceiverTracesByProtoVersion(transport, fmt.Sprintf("v0.%d", maxProtoVersions-1), 1))
+		require.NoError(t, tt.CheckReceiverTracesByProtoVersion(transport, ProtoVersionOther, 2))
+		require.Error(t, tt.CheckReceiverTracesByProtoVersion(transport, fmt.Sprintf("v0.%d", maxProtoVersions), 1))
+	})
+}
+
+func TestReceiveTraceDataOpSpanMinDuration(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
+
+		newRec := func(minDuration time.Duration) *Receiver {
+			rec, err := newReceiver(ReceiverSettings{
+				ReceiverID:             receiverID,
+				Transport:              transport,
+				ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+				SpanMinDuration:        minDuration,
+			}, useOtel)
+			require.NoError(t, err)
+			return rec
+		}
+
+		// Fast successful operations are not traced.
+		fast := newRec(time.Hour)
+		ctx := fast.StartTracesOp(parentCtx)
+		assert.Equal(t, parentSpan.SpanContext(), trace.SpanContextFromContext(ctx))
+		fast.EndTracesOp(ctx, format, 7, nil)
+		assert.Empty(t, tt.SpanRecorder.Ended())
+
+		// Failed operations are traced regardless of their duration.
+		ctx = fast.StartTracesOp(parentCtx)
+		fast.EndTracesOp(ctx, format, 5, errFake)
+
+		slow := newRec(time.Millisecond)
+		ctx = slow.StartTracesOp(parentCtx)
+		start := time.Now()
+		time.Sleep(5 * time.Millisecond)
+		slow.EndTracesOp(ctx, format, 3, nil)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 2)
+		for _, span := range spans {
+			assert.Equal(t, "receiver/"+receiverID.String()+"/TraceDataReceived", span.Name())
+			assert.Equal(t, parentSpan.SpanContext().SpanID(), span.Parent().SpanID())
+			assert.Contains(t, span.Attributes(), attribute.String(obsmetrics.TransportKey, transport))
+		}
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Contains(t, spans[0].Attributes(), attribute.Int64(obsmetrics.RefusedSpansKey, 5))
+		assert.Equal(t, codes.Unset, spans[1].Status().Code)
+		assert.Contains(t, spans[1].Attributes(), attribute.Int64(obsmetrics.AcceptedSpansKey, 3))
+		assert.False(t, spans[1].StartTime().After(start))
+		assert.GreaterOrEqual(t, spans[1].EndTime().Sub(spans[1].StartTime()), 5*time.Millisecond)
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 10, 5))
+	})
+}
+
+func TestReceiveTraceDataOpPartialError(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		const batchSize = 20
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		partialErr := PartialError{Accepted: 12, Refused: 8, Err: errFake}
+		assert.Equal(t, batchSize, partialErr.Accepted+partialErr.Refused)
+		assert.ErrorIs(t, partialErr, errFake)
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, batchSize, partialErr)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, 1, len(spans))
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSpansKey, Value: attribute.Int64Value(12)})
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpansKey, Value: attribute.Int64Value(8)})
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, errFake.Error(), spans[0].Status().Description)
+
+		require.NoError(t, tt.CheckReceiverTraces(transport, 12, 8))
+	})
+}
+
+func TestToAcceptedRefused(t *testing.T) {
+	tests := []struct {
+		name         string
+		numItems     int
+		err          error
+		wantAccepted int
+		wantRefused  int
+		wantErr      error
+	}{
+		{name: "success", numItems: 5, wantAccepted: 5},
+		{name: "error", numItems: 5, err: errFake, wantRefused: 5, wantErr: errFake},
+		{name: "partial", numItems: 5, err: PartialError{Accepted: 3, Refused: 2, Err: errFake}, wantAccepted: 3, wantRefused: 2, wantErr: errFake},
+		{name: "too many refused", numItems: 5, err: PartialError{Accepted: 3, Refused: 7, Err: errFake}, wantRefused: 5, wantErr: errFake},
+		{name: "too many accepted", numItems: 5, err: PartialError{Accepted: 9, Refused: 2, Err: errFake}, wantAccepted: 3, wantRefused: 2, wantErr: errFake},
+		{name: "negative", numItems: 5, err: PartialError{Accepted: -1, Refused: -1, Err: errFake}, wantErr: errFake},
+		{name: "all accepted", numItems: 5, err: PartialError{Accepted: 5}, wantAccepted: 5},
+		{name: "refused without error", numItems: 5, err: PartialError{Accepted: 4, Refused: 1}, wantAccepted: 4, wantRefused: 1, wantErr: PartialError{Accepted: 4, Refused: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accepted, refused, err := toAcceptedRefused(tt.numItems, tt.err)
+			assert.Equal(t, tt.wantAccepted, accepted)
+			assert.Equal(t, tt.wantRefused, refused)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestReceiveTraceDataOpRefusedWithoutError(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 10, PartialError{Accepted: 7, Refused: 3})
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, 1, len(spans))
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		require.NoError(t, tt.CheckReceiverTraces(transport, 7, 3))
+	})
+}
+
+func TestReceiveLogsOpWeighted(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := rec.StartLogsOp(context.Background())
+		rec.EndLogsOpWeighted(ctx, format, 3, 1<<20, nil)
+		ctx = rec.StartLogsOp(context.Background())
+		rec.EndLogsOpWeighted(ctx, format, 5, 512, nil)
+		ctx = rec.StartLogsOp(context.Background())
+		rec.EndLogsOpWeighted(ctx, format, 7, 2048, errFake)
+		// Only the size of the accepted log records is counted.
+		ctx = rec.StartLogsOp(context.Background())
+		rec.EndLogsOpWeighted(ctx, format, 4, 4096, PartialError{Accepted: 3, Refused: 1, Err: errFake})
+
+		require.Equal(t, 4, len(tt.SpanRecorder.Ended()))
+		require.NoError(t, tt.CheckReceiverLogs(transport, 11, 8))
+		require.NoError(t, tt.CheckReceiverLogsWeight(transport, 1<<20+512+3072))
+	})
+}
+
+func TestReceiveLogsOp(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
+
+		params := []testParams{
+			{items: 13, err: errFake},
+			{items: 42, err: nil},
+		}
+		for i, param := range params {
+			rec, err := newReceiver(ReceiverSettings{
+				ReceiverID:             receiverID,
+				Transport:              transport,
+				ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			}, useOtel)
+			require.NoError(t, err)
+
+			ctx := rec.StartLogsOp(parentCtx)
+			assert.NotNil(t, ctx)
+			rec.EndLogsOp(ctx, format, params[i].items, param.err)
+		}
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params), len(spans))
+
+		var acceptedLogRecords, refusedLogRecords int
+		for i, span := range spans {
+			assert.Equal(t, "receiver/"+receiverID.String()+"/LogsReceived", span.Name())
+			switch {
+			case params[i].err == nil:
+				acceptedLogRecords += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedLogRecordsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedLogRecordsKey, Value: attribute.Int64Value(0)})
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			case errors.Is(params[i].err, errFake):
+				refusedLogRecords += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedLogRecordsKey, Value: attribute.Int64Value(0)})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedLogRecordsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+			default:
+				t.Fatalf("unexpected param: %v", params[i])
+			}
+		}
+		require.NoError(t, tt.CheckReceiverLogs(transport, int64(acceptedLogRecords), int64(refusedLogRecords)))
+	})
+}
+
+func TestReceiveMetricsOp(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
+
+		params := []testParams{
+			{items: 23, err: errFake},
+			{items: 29, err: nil},
+		}
+		for i, param := range params {
+			rec, err := newReceiver(ReceiverSettings{
+				ReceiverID:             receiverID,
+				Transport:              transport,
+				ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			}, useOtel)
+			require.NoError(t, err)
+
+			ctx := rec.StartMetricsOp(parentCtx)
+			assert.NotNil(t, ctx)
+			rec.EndMetricsOp(ctx, format, params[i].items, param.err)
+		}
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params), len(spans))
+
+		var acceptedMetricPoints, refusedMetricPoints int
+		for i, span := range spans {
+			assert.Equal(t, "receiver/"+receiverID.String()+"/MetricsReceived", span.Name())
+			switch {
+			case params[i].err == nil:
+				acceptedMetricPoints += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedMetricPointsKey, Value: attribute.Int64Value(0)})
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			case errors.Is(params[i].err, errFake):
+				refusedMetricPoints += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedMetricPointsKey, Value: attribute.Int64Value(0)})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+			default:
+				t.Fatalf("unexpected param: %v", params[i])
+			}
+		}
+
+		require.NoError(t, tt.CheckReceiverMetrics(transport, int64(acceptedMetricPoints), int64(refusedMetricPoints)))
+	})
+}
+
+func TestScrapeMetricsDataOp(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
+
+		params := []testParams{
+			{items: 23, err: partialErrFake},
+			{items: 29, err: errFake},
+			{items: 15, err: nil},
+		}
+		for i := range params {
+			scrp, err := newScraper(ScraperSettings{
+				ReceiverID:             receiverID,
+				Scraper:                scraperID,
+				ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			}, useOtel)
+			require.NoError(t, err)
+			ctx := scrp.StartMetricsOp(parentCtx)
+			assert.NotNil(t, ctx)
+			scrp.EndMetricsOp(ctx, params[i].items, params[i].err)
+		}
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params), len(spans))
+
+		var scrapedMetricPoints, erroredMetricPoints int
+		for i, span := range spans {
+			assert.Equal(t, "scraper/"+receiverID.String()+"/"+scraperID.String()+"/MetricsScraped", span.Name())
+			switch {
+			case params[i].err == nil:
+				scrapedMetricPoints += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(0)})
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			case errors.Is(params[i].err, errFake):
+				erroredMetricPoints += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(0)})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+
+			case errors.Is(params[i].err, partialErrFake):
+				scrapedMetricPoints += params[i].items
+				erroredMetricPoints++
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(1)})
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+			default:
+				t.Fatalf("unexpected err param: %v", params[i].err)
+			}
+		}
+
+		require.NoError(t, obsreporttest.CheckScraperMetrics(tt, receiverID, scraperID, int64(scrapedMetricPoints), int64(erroredMetricPoints)))
+	})
+}
+
+func TestScrapeMetricsDataOpMultiPartial(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		scrp, err := newScraper(ScraperSettings{
+			ReceiverID:             receiverID,
+			Scraper:                scraperID,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		multiPartialErr := scrapererror.NewMultiPartialScrapeError(
+			[]error{errFake, errors.New("errFake2")},
+			map[string]int{"target1": 3, "target2": 5})
+		ctx := scrp.StartMetricsOp(context.Background())
+		scrp.EndMetricsOp(ctx, 17, multiPartialErr)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, 1, len(spans))
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(17)})
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(8)})
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+
+		require.NoError(t, obsreporttest.CheckScraperMetrics(tt, receiverID, scraperID, 17, 8))
+	})
+}
+
+func TestScraperCache(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		scrp, err := newScraper(ScraperSettings{
+			ReceiverID:             receiverID,
+			Scraper:                scraperID,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := scrp.StartMetricsOp(context.Background())
+		scrp.RecordCache(ctx, false)
+		scrp.RecordCache(ctx, false)
+		scrp.RecordCache(ctx, true)
+		scrp.EndMetricsOp(ctx, 3, nil)
+		// The lookups may also happen outside of a scrape operation.
+		scrp.RecordCache(context.Background(), true)
+		scrp.RecordCache(context.Background(), true)
+
+		require.NoError(t, obsreporttest.CheckScraperCache(tt, receiverID, scraperID, 3, 2))
+	})
+}
+
+func TestScraperMetricScrapes(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		scrp, err := newScraper(ScraperSettings{
+			ReceiverID:             receiverID,
+			Scraper:                scraperID,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			MetricNames:            []string{"system.cpu.time", "system.memory.usage"},
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := scrp.StartMetricsOp(context.Background())
+		scrp.RecordMetricScrape(ctx, "system.cpu.time", nil)
+		scrp.RecordMetricScrape(ctx, "system.cpu.time", errFake)
+		scrp.RecordMetricScrape(ctx, "system.memory.usage", nil)
+		scrp.RecordMetricScrape(ctx, "system.memory.usage", errFake)
+		scrp.RecordMetricScrape(ctx, "system.memory.usage", errFake)
+		scrp.RecordMetricScrape(ctx, "system.disk.io", nil)
+		scrp.RecordMetricScrape(ctx, "system.network.io", errFake)
+		scrp.RecordMetricScrape(ctx, "system.network.io", errFake)
+		scrp.EndMetricsOp(ctx, 8, nil)
+
+		require.NoError(t, obsreporttest.CheckScraperMetricScrapes(tt, receiverID, scraperID, "system.cpu.time", 1, 1))
+		require.NoError(t, obsreporttest.CheckScraperMetricScrapes(tt, receiverID, scraperID, "system.memory.usage", 1, 2))
+		require.NoError(t, obsreporttest.CheckScraperMetricScrapes(tt, receiverID, scraperID, MetricNameOther, 1, 2))
+		// The metrics not in the allowlist do not get their own series.
+		require.Error(t, obsreporttest.CheckScraperMetricScrapes(tt, receiverID, scraperID, "system.disk.io", 1, 0))
+	})
+}
+
+func TestExportTraceDataOp(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
+
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		params := []testParams{
+			{items: 22, err: nil},
+			{items: 14, err: errFake},
+		}
+		for i := range params {
+			ctx := obsrep.StartTracesOp(parentCtx)
+			assert.NotNil(t, ctx)
+			obsrep.EndTracesOp(ctx, params[i].items, params[i].err)
+		}
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params), len(spans))
+
+		var sentSpans, failedToSendSpans int
+		for i, span := range spans {
+			assert.Equal(t, "exporter/"+exporterID.String()+"/traces", span.Name())
+			switch {
+			case params[i].err == nil:
+				sentSpans += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(0)})
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			case errors.Is(params[i].err, errFake):
+				failedToSendSpans += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentSpansKey, Value: attribute.Int64Value(0)})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+			default:
+				t.Fatalf("unexpected error: %v", params[i].err)
+			}
+		}
+
+		require.NoError(t, tt.CheckExporterTraces(int64(sentSpans), int64(failedToSendSpans)))
+	})
+}
+
+func TestExportTraceDataOpWithHandle(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		reqCtx, cancel := context.WithCancel(context.Background())
+		ctx, handle := obsrep.StartTracesOpWithHandle(reqCtx)
+		assert.Equal(t, trace.SpanFromContext(ctx), trace.SpanFromContext(handle))
+		// The request is done before the export completes asynchronously.
+		cancel()
+		require.Error(t, ctx.Err())
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			assert.NoError(t, handle.Err())
+			obsrep.EndTracesOp(handle, 9, nil)
+		}()
+		<-done
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "exporter/"+exporterID.String()+"/traces", spans[0].Name())
+		assert.Contains(t, spans[0].Attributes(), attribute.Int64(obsmetrics.SentSpansKey, 9))
+		require.NoError(t, tt.CheckExporterTraces(9, 0))
+	})
+}
+
+func TestExportTraceDataOpWithChunkEvents(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOpWithChunkEvents(context.Background(), true)
+		obsrep.AddChunkEvent(ctx, 100)
+		obsrep.AddChunkEvent(ctx, 100)
+		obsrep.AddChunkEvent(ctx, 42)
+		obsrep.EndTracesOp(ctx, 242, nil)
+		// Without the flag, or without the chunk events variant, no event is added.
+		ctx = obsrep.StartTracesOpWithChunkEvents(context.Background(), false)
+		obsrep.AddChunkEvent(ctx, 5)
+		obsrep.EndTracesOp(ctx, 5, nil)
+		ctx = obsrep.StartTracesOp(context.Background())
+		obsrep.AddChunkEvent(ctx, 5)
+		obsrep.EndTracesOp(ctx, 5, nil)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 3)
+		events := spans[0].Events()
+		require.Len(t, events, 3)
+		for i, wantItems := range []int{100, 100, 42} {
+			assert.Equal(t, chunkEventName, events[i].Name)
+			assert.Equal(t, []attribute.KeyValue{
+				attribute.Int(chunkIndexKey, i),
+				attribute.Int(chunkItemsKey, wantItems),
+			}, events[i].Attributes)
+		}
+		assert.Empty(t, spans[1].Events())
+		assert.Empty(t, spans[2].Events())
+		require.NoError(t, tt.CheckExporterTraces(252, 0))
+	})
+}
+
+func TestReceiveTraceDataOpWithHandle(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		reqCtx, cancel := context.WithCancel(context.Background())
+		_, handle := rec.StartTracesOpWithHandle(reqCtx)
+		cancel()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			rec.EndTracesOp(handle, format, 5, errFake)
+		}()
+		<-done
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		require.NoError(t, tt.CheckReceiverTraces(transport, 0, 5))
+	})
+}
+
+func TestExportMetricsOp(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
+
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		params := []testParams{
+			{items: 17, err: nil},
+			{items: 23, err: errFake},
+		}
+		for i := range params {
+			ctx := obsrep.StartMetricsOp(parentCtx)
+			assert.NotNil(t, ctx)
+
+			obsrep.EndMetricsOp(ctx, params[i].items, params[i].err)
+		}
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params), len(spans))
+
+		var sentMetricPoints, failedToSendMetricPoints int
+		for i, span := range spans {
+			assert.Equal(t, "exporter/"+exporterID.String()+"/metrics", span.Name())
+			switch {
+			case params[i].err == nil:
+				sentMetricPoints += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendMetricPointsKey, Value: attribute.Int64Value(0)})
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			case errors.Is(params[i].err, errFake):
+				failedToSendMetricPoints += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentMetricPointsKey, Value: attribute.Int64Value(0)})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+			default:
+				t.Fatalf("unexpected error: %v", params[i].err)
+			}
+		}
+
+		require.NoError(t, tt.CheckExporterMetrics(int64(sentMetricPoints), int64(failedToSendMetricPoints)))
+	})
+}
+
+func TestExportLogsOp(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
+
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		params := []testParams{
+			{items: 17, err: nil},
+			{items: 23, err: errFake},
+		}
+		for i := range params {
+			ctx := obsrep.StartLogsOp(parentCtx)
+			assert.NotNil(t, ctx)
+
+			obsrep.EndLogsOp(ctx, params[i].items, params[i].err)
+		}
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params), len(spans))
+
+		var sentLogRecords, failedToSendLogRecords int
+		for i, span := range spans {
+			assert.Equal(t, "exporter/"+exporterID.String()+"/logs", span.Name())
+			switch {
+			case params[i].err == nil:
+				sentLogRecords += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentLogRecordsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendLogRecordsKey, Value: attribute.Int64Value(0)})
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			case errors.Is(params[i].err, errFake):
+				failedToSendLogRecords += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentLogRecordsKey, Value: attribute.Int64Value(0)})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendLogRecordsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+			default:
+				t.Fatalf("unexpected error: %v", params[i].err)
+			}
+		}
+
+		require.NoError(t, tt.CheckExporterLogs(int64(sentLogRecords), int64(failedToSendLogRecords)))
+	})
+}
+
+func TestExporterConnectionState(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := context.Background()
+		obsrep.RecordConnectionState(ctx, ConnectionStateConnecting)
+		obsrep.RecordConnectionState(ctx, ConnectionStateReady)
+		obsrep.RecordConnectionState(ctx, ConnectionStateReady)
+		obsrep.RecordConnectionState(ctx, ConnectionStateTransientFailure)
+		obsrep.RecordConnectionState(ctx, ConnectionStateConnecting)
+		obsrep.RecordConnectionState(ctx, ConnectionStateReady)
+		obsrep.RecordConnectionState(ctx, "unknown")
+
+		require.NoError(t, tt.CheckExporterConnectionState(ConnectionStateConnecting, 0, 2))
+		require.NoError(t, tt.CheckExporterConnectionState(ConnectionStateTransientFailure, 0, 1))
+		require.NoError(t, tt.CheckExporterConnectionState(ConnectionStateReady, 1, 2))
+		require.Error(t, tt.CheckExporterConnectionState("unknown", 1, 1))
+	})
+}
+
+func TestExporterBackpressure(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		now := time.Unix(1000, 0)
+		obsrep.now = func() time.Time { return now }
+
+		ctx := context.Background()
+		obsrep.RecordBackpressure(ctx, true)
+		now = now.Add(250 * time.Millisecond)
+		// Reporting the current state again does not restart the measurement.
+		obsrep.RecordBackpressure(ctx, true)
+		now = now.Add(250 * time.Millisecond)
+		obsrep.RecordBackpressure(ctx, false)
+		require.NoError(t, tt.CheckExporterBackpressure(0, 500))
+
+		now = now.Add(time.Second)
+		obsrep.RecordBackpressure(ctx, false)
+		obsrep.RecordBackpressure(ctx, true)
+		now = now.Add(100 * time.Millisecond)
+		obsrep.RecordBackpressure(ctx, false)
+		require.NoError(t, tt.CheckExporterBackpressure(0, 600))
+
+		// The time spent under backpressure is only added once it ends.
+		obsrep.RecordBackpressure(ctx, true)
+		now = now.Add(time.Second)
+		require.NoError(t, tt.CheckExporterBackpressure(1, 600))
+	})
+}
+
+func TestExporterPersistentQueueSize(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		// Exporters without a persistent queue never report its size.
+		require.Error(t, tt.CheckExporterPersistentQueueSize(0, 0))
+
+		obsrep.RecordPersistentQueueSize(context.Background(), 100, 4096)
+		require.NoError(t, tt.CheckExporterPersistentQueueSize(100, 4096))
+		obsrep.RecordPersistentQueueSize(context.Background(), 150, 6144)
+		obsrep.RecordPersistentQueueSize(context.Background(), 30, 1024)
+		require.NoError(t, tt.CheckExporterPersistentQueueSize(30, 1024))
+	})
+}
+
+func TestRecordBuildInfo(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		require.NoError(t, recordBuildInfo(tt.TelemetrySettings, "v0.75.0", "3a9f1c2", "", useOtel))
+		require.NoError(t, tt.CheckCollectorInfo("v0.75.0", "3a9f1c2"))
+		require.Error(t, tt.CheckCollectorInfo("v0.74.0", "3a9f1c2"))
+	})
+}
+
+func TestInternalTelemetryOverflow(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		it := NewInternalTelemetry()
+		require.NoError(t, it.register(tt.TelemetrySettings, useOtel))
+
+		blocking := &blockingMetricExporter{started: make(chan struct{}, 3), release: make(chan struct{})}
+		exp := it.WrapExporter(blocking, 1)
+		t.Cleanup(func() { require.NoError(t, exp.Shutdown(context.Background())) })
+
+		// The first collection is being exported, the second one fills the queue and
+		// the third one overflows it.
+		require.NoError(t, exp.Export(context.Background(), resourceMetrics(2)))
+		<-blocking.started
+		require.NoError(t, exp.Export(context.Background(), resourceMetrics(3)))
+		require.NoError(t, exp.Export(context.Background(), resourceMetrics(4)))
+		assert.Equal(t, int64(5), it.pending.Load())
+		assert.Equal(t, int64(4), it.dropped.Load())
+
+		if useOtel {
+			require.NoError(t, tt.CheckPendingInternalTelemetry(5))
+			require.NoError(t, tt.CheckDroppedInternalTelemetry(4))
+		} else {
+			// Without OpenTelemetry, the internal metrics cannot be pushed to exporters.
+			require.Error(t, tt.CheckPendingInternalTelemetry(5))
+			require.Error(t, tt.CheckDroppedInternalTelemetry(4))
+		}
+
+		// The data points of a failed export are dropped too.
+		blocking.err = errFake
+		close(blocking.release)
+		require.NoError(t, exp.ForceFlush(context.Background()))
+		assert.Equal(t, int64(0), it.pending.Load())
+		assert.Equal(t, int64(9), it.dropped.Load())
+	})
+}
+
+func TestInternalTelemetryShutdown(t *testing.T) {
+	it := NewInternalTelemetry()
+	blocking := &blockingMetricExporter{started: make(chan struct{}, 2), release: make(chan struct{})}
+	close(blocking.release)
+	exp := it.WrapExporter(blocking, 2)
+
+	require.NoError(t, exp.Export(context.Background(), resourceMetrics(1)))
+	require.NoError(t, exp.Export(context.Background(), resourceMetrics(2)))
+	// The queued metrics are exported before shutting down.
+	require.NoError(t, exp.Shutdown(context.Background()))
+	assert.Equal(t, int64(3), blocking.exported.Load())
+	assert.Equal(t, int64(0), it.pending.Load())
+	assert.Equal(t, int64(0), it.dropped.Load())
+
+	require.Error(t, exp.Export(context.Background(), resourceMetrics(1)))
+	require.NoError(t, exp.ForceFlush(context.Background()))
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
+
+// resourceMetrics returns internal metrics with the given number of data points.
+func resourceMetrics(dataPoints int) metricdata.ResourceMetrics {
+	return metricdata.ResourceMetrics{ScopeMetrics: []metricdata.ScopeMetrics{{
+		Metrics: []metricdata.Metrics{{
+			Name: "receiver/accepted_spans",
+			Data: metricdata.Sum[int64]{DataPoints: make([]metricdata.DataPoint[int64], dataPoints)},
+		}},
+	}}}
+}
+
+// blockingMetricExporter is a sdkmetric.Exporter signaling started when an export starts,
+// then blocking it until release is closed. It fails to export with err if set.
+type blockingMetricExporter struct {
+	started  chan struct{}
+	release  chan struct{}
+	err      error
+	exported atomic.Int64
+}
+
+func (*blockingMetricExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return sdkmetric.DefaultTemporalitySelector(kind)
+}
+
+func (*blockingMetricExporter) Aggregation(kind sdkmetric.InstrumentKind) aggregation.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+func (be *blockingMetricExporter) Export(_ context.Context, rm metricdata.ResourceMetrics) error {
+	be.started <- struct{}{}
+	<-be.release
+	if be.err != nil {
+		return be.err
+	}
+	be.exported.Add(int64(countDataPoints(rm)))
+	return nil
+}
+
+func (*blockingMetricExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (*blockingMetricExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestExporterSends(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+			Compression:            "zstd",
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 7, nil)
+		ctx = obsrep.StartMetricsOp(context.Background())
+		obsrep.EndMetricsOp(ctx, 11, errFake)
+		ctx = obsrep.StartLogsOp(context.Background())
+		obsrep.EndLogsOp(ctx, 13, nil)
+
+		require.NoError(t, tt.CheckExporterSends("zstd", 3))
+		require.Error(t, tt.CheckExporterSends("gzip", 3))
+	})
+}
+
+func TestExporterNoSendsWithoutCompression(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 7, nil)
+
+		require.NoError(t, tt.CheckExporterTraces(7, 0))
+		require.Error(t, tt.CheckExporterSends("", 1))
+	})
+}
+
+func TestExporterSignalCounters(t *testing.T) {
+	type checkFunc func(tt *obsreporttest.TestTelemetry, value int64) error
+	tests := []struct {
+		name   string
+		record func(exp *Exporter, ctx context.Context, signal component.DataType, numItems int)
+		checks map[component.DataType]checkFunc
+	}{
+		{
+			name:   "retries_exhausted",
+			record: (*Exporter).RecordRetriesExhausted,
+			checks: map[component.DataType]checkFunc{
+				component.DataTypeTraces:  (*obsreporttest.TestTelemetry).CheckExporterTracesRetriesExhausted,
+				component.DataTypeMetrics: (*obsreporttest.TestTelemetry).CheckExporterMetricsRetriesExhausted,
+				component.DataTypeLogs:    (*obsreporttest.TestTelemetry).CheckExporterLogsRetriesExhausted,
+			},
+		},
+		{
+			name: "partial_warnings",
+			// The warnings are counted by response, so numItems responses are reported.
+			record: func(exp *Exporter, ctx context.Context, signal component.DataType, numItems int) {
+				for i := 0; i < numItems; i++ {
+					exp.RecordPartialWarning(ctx, signal)
+				}
+			},
+			checks: map[component.DataType]checkFunc{
+				component.DataTypeTraces:  (*obsreporttest.TestTelemetry).CheckExporterTracesPartialWarnings,
+				component.DataTypeMetrics: (*obsreporttest.TestTelemetry).CheckExporterMetricsPartialWarnings,
+				component.DataTypeLogs:    (*obsreporttest.TestTelemetry).CheckExporterLogsPartialWarnings,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+				obsrep, err := newExporter(ExporterSettings{
+					ExporterID:             exporterID,
+					ExporterCreateSettings: tt.ToExporterCreateSettings(),
+				}, useOtel)
+				require.NoError(t, err)
+				tc.record(obsrep, context.Background(), component.DataTypeTraces, 7)
+				tc.record(obsrep, context.Background(), component.DataTypeTraces, 3)
+				tc.record(obsrep, context.Background(), component.DataTypeMetrics, 1)
+				tc.record(obsrep, context.Background(), component.DataTypeLogs, 13)
+				tc.record(obsrep, context.Background(), component.DataType("profiles"), 5)
+
+				require.NoError(t, tc.checks[component.DataTypeTraces](&tt, 10))
+				require.NoError(t, tc.checks[component.DataTypeMetrics](&tt, 1))
+				require.NoError(t, tc.checks[component.DataTypeLogs](&tt, 13))
+				// The items are not counted as failed to send by themselves.
+				require.Error(t, tt.CheckExporterTraces(0, 10))
+			})
+		})
+	}
+}
+
+func TestPipelineLatency(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			RecordPipelineStart:    true,
+		}, useOtel)
+		require.NoError(t, err)
+		exp, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+			RecordPipelineLatency:  true,
+		}, useOtel)
+		require.NoError(t, err)
+		start := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+		rec.now = func() time.Time { return start }
+
+		pipeline := func(latency time.Duration, err error) {
+			ctx := rec.StartTracesOp(context.Background())
+			exp.now = func() time.Time { return start.Add(latency) }
+			expCtx := exp.StartTracesOp(ctx)
+			exp.EndTracesOp(expCtx, 1, err)
+			rec.EndTracesOp(ctx, format, 1, err)
+		}
+		pipeline(250*time.Millisecond, nil)
+		pipeline(1500*time.Millisecond, nil)
+		// The failed exports are not recorded.
+		pipeline(time.Second, errFake)
+		// Nor the ones without a start time in their context.
+		ctx := exp.StartTracesOp(context.Background())
+		exp.EndTracesOp(ctx, 1, nil)
+
+		require.NoError(t, tt.CheckPipelineLatency(2, 1750))
+	})
+}
+
+func TestPipelineLatencyNotRecorded(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			RecordPipelineStart:    true,
+		}, useOtel)
+		require.NoError(t, err)
+		exp, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := exp.StartTracesOp(rec.StartTracesOp(context.Background()))
+		exp.EndTracesOp(ctx, 1, nil)
+
+		require.NoError(t, tt.CheckExporterTraces(1, 0))
+		require.Error(t, tt.CheckPipelineLatency(1, 0))
+	})
+}
+
+func TestExporterAckLatency(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 10, nil)
+		// The acknowledgments are received after the export operations ended.
+		obsrep.RecordAckLatency(context.Background(), 40*time.Millisecond)
+		obsrep.RecordAckLatency(context.Background(), 1500*time.Millisecond)
+
+		require.NoError(t, tt.CheckExporterAckLatency(2, 1540))
+	})
+}
+
+func TestExportTraceDataOpQueued(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+			RecordQueueWait:        true,
+		}, useOtel)
+		require.NoError(t, err)
+		now := time.Unix(1000, 0)
+		obsrep.now = func() time.Time { return now }
+
+		ctx := obsrep.StartTracesOpQueued(context.Background(), now.Add(-250*time.Millisecond))
+		now = now.Add(40 * time.Millisecond)
+		obsrep.EndTracesOp(ctx, 10, nil)
+		// An enqueue time after the start of the operation is recorded as no wait.
+		ctx = obsrep.StartTracesOpQueued(context.Background(), now.Add(time.Second))
+		now = now.Add(15 * time.Millisecond)
+		obsrep.EndTracesOp(ctx, 5, errFake)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Contains(t, spans[0].Attributes(), attribute.Float64(queueWaitKey, 250))
+		assert.Contains(t, spans[0].Attributes(), attribute.Float64(sendTimeKey, 40))
+		assert.Contains(t, spans[1].Attributes(), attribute.Float64(queueWaitKey, 0))
+		assert.Contains(t, spans[1].Attributes(), attribute.Float64(sendTimeKey, 15))
+		require.NoError(t, tt.CheckExporterQueueWait(2, 250))
+		require.NoError(t, tt.CheckExporterTraces(10, 5))
+	})
+}
+
+func TestExportTraceDataOpQueuedNoHistogram(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOpQueued(context.Background(), time.Now().Add(-time.Second))
+		obsrep.EndTracesOp(ctx, 10, nil)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 1)
+		var keys []attribute.Key
+		for _, kv := range spans[0].Attributes() {
+			keys = append(keys, kv.Key)
+		}
+		assert.Contains(t, keys, attribute.Key(queueWaitKey))
+		assert.Contains(t, keys, attribute.Key(sendTimeKey))
+		require.Error(t, tt.CheckExporterQueueWait(1, 0))
+	})
+}
+
+func TestExporterOldestQueuedAge(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		obsrep.RecordOldestQueuedAge(context.Background(), 3*time.Second)
+		require.NoError(t, tt.CheckExporterOldestQueuedAge(3000))
+
+		// The gauge reflects the last recorded age, even if it decreased.
+		obsrep.RecordOldestQueuedAge(context.Background(), 12*time.Second)
+		obsrep.RecordOldestQueuedAge(context.Background(), 250*time.Millisecond)
+		require.NoError(t, tt.CheckExporterOldestQueuedAge(250))
+	})
+}
+
+func TestExporterSendDuration(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 10, nil)
+		ctx = obsrep.StartMetricsOp(context.Background())
+		obsrep.EndMetricsOp(ctx, 10, errFake)
+		ctx = obsrep.StartLogsOp(context.Background())
+		obsrep.EndLogsOp(ctx, 10, nil)
+		// A partial success is a success.
+		ctx = obsrep.StartLogsOp(context.Background())
+		obsrep.EndLogsOpPartial(ctx, 10, 4, errFake)
+		ctx = obsrep.StartLogsOp(context.Background())
+		obsrep.EndLogsOpPartial(ctx, 10, 10, errFake)
+
+		require.NoError(t, tt.CheckExporterSendDuration(OutcomeSuccess, 3))
+		require.NoError(t, tt.CheckExporterSendDuration(OutcomeFailure, 2))
+	})
+}
+
+func TestExporterTLSErrors(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		obsrep.RecordTLSError(context.Background(), TLSErrorHandshake)
+		obsrep.RecordTLSError(context.Background(), TLSErrorCertExpired)
+		obsrep.RecordTLSError(context.Background(), TLSErrorHandshake)
+		obsrep.RecordTLSError(context.Background(), "unknown")
+
+		require.NoError(t, tt.CheckExporterTLSErrors(TLSErrorHandshake, 2))
+		require.NoError(t, tt.CheckExporterTLSErrors(TLSErrorCertExpired, 1))
+		require.NoError(t, tt.CheckExporterTLSErrors(TLSErrorOther, 1))
+		require.Error(t, tt.CheckExporterTLSErrors(TLSErrorVerify, 1))
+		require.Error(t, tt.CheckExporterTLSErrors("unknown", 1))
+	})
+}
+
+func TestExporterRequests(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		// Every request is counted once, whatever the number of items it held.
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 100, nil)
+		ctx = obsrep.StartMetricsOp(context.Background())
+		obsrep.EndMetricsOp(ctx, 1, nil)
+		ctx = obsrep.StartLogsOp(context.Background())
+		obsrep.EndLogsOp(ctx, 0, nil)
+		ctx = obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 50, errFake)
+		// A partial success is a success.
+		ctx = obsrep.StartLogsOp(context.Background())
+		obsrep.EndLogsOpPartial(ctx, 10, 4, errFake)
+
+		require.NoError(t, tt.CheckExporterRequests(OutcomeSuccess, 4))
+		require.NoError(t, tt.CheckExporterRequests(OutcomeFailure, 1))
+	})
+}
+
+func TestConnectorNoSendDuration(t *testing.T) {
+	testTelemetry(t, connectorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		set := tt.ToConnectorCreateSettings()
+		set.MetricsLevel = configtelemetry.LevelDetailed
+		conn, err := newConnector(ConnectorSettings{
+			ConnectorID:             connectorID,
+			ConnectorCreateSettings: set,
+		}, useOtel)
+		require.NoError(t, err)
+
+		// The context of the receive operation holds its start time.
+		recvCtx := conn.Receiver().StartTracesOp(context.Background())
+		ctx := conn.Exporter().StartTracesOp(recvCtx)
+		conn.Exporter().EndTracesOp(ctx, 7, nil)
+		conn.Receiver().EndTracesOp(recvCtx, "", 7, nil)
+
+		require.NoError(t, tt.CheckConnectorTraces(7, 0, 7, 0))
+		require.Error(t, tt.CheckExporterSendDuration(OutcomeSuccess, 1))
+		require.Error(t, tt.CheckExporterRequests(OutcomeSuccess, 1))
+	})
+}
+
+func TestExporterBatchSizes(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		set := tt.ToExporterCreateSettings()
+		set.MetricsLevel = configtelemetry.LevelDetailed
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: set,
+			RecordBatchSizes:       true,
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 100, nil)
+		ctx = obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 20, errFake)
+		ctx = obsrep.StartLogsOp(context.Background())
+		obsrep.EndLogsOpPartial(ctx, 30, 10, nil)
+
+		require.NoError(t, tt.CheckExporterBatchSizes(component.DataTypeTraces, 2, 120))
+		require.NoError(t, tt.CheckExporterBatchSizes(component.DataTypeLogs, 1, 30))
+		require.Error(t, tt.CheckExporterBatchSizes(component.DataTypeMetrics, 0, 0))
+	})
+}
+
+func TestExporterBatchSizesNotDetailed(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+			RecordBatchSizes:       true,
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 100, nil)
+		require.NoError(t, tt.CheckExporterTraces(100, 0))
+		require.Error(t, tt.CheckExporterBatchSizes(component.DataTypeTraces, 1, 100))
+	})
+}
+
+func TestExportTraceDataOpSpanMinDuration(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+			SpanMinDuration:        time.Hour,
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 7, nil)
+		ctx = obsrep.StartMetricsOp(context.Background())
+		obsrep.EndMetricsOp(ctx, 5, errFake)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "exporter/"+exporterID.String()+"/metrics", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, errFake.Error(), spans[0].Status().Description)
+		require.NoError(t, tt.CheckExporterTraces(7, 0))
+	})
+}
+
+func TestExportTraceDataOpWithCode(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		params := []struct {
+			items int
+			code  string
+			err   error
+		}{
+			{items: 5, code: "429", err: errFake},
+			{items: 7, code: "503", err: errFake},
+			{items: 11, code: "429", err: errFake},
+			{items: 13, code: "14", err: errFake},
+			{items: 17, code: "unknown", err: errFake},
+			{items: 19, code: "200", err: nil},
+		}
+		for _, param := range params {
+			ctx := obsrep.StartTracesOp(context.Background())
+			obsrep.EndTracesOpWithCode(ctx, param.items, param.code, param.err)
+		}
+
+		require.Equal(t, len(params), len(tt.SpanRecorder.Ended()))
+		require.NoError(t, tt.CheckExporterTraces(19, 53))
+		require.NoError(t, tt.CheckExporterTracesFailedByCode(obsmetrics.HTTPStatusCodeKey, "429", 16))
+		require.NoError(t, tt.CheckExporterTracesFailedByCode(obsmetrics.HTTPStatusCodeKey, "503", 7))
+		require.NoError(t, tt.CheckExporterTracesFailedByCode(obsmetrics.GRPCStatusCodeKey, "14", 13))
+		require.Error(t, tt.CheckExporterTracesFailedByCode(obsmetrics.HTTPStatusCodeKey, "200", 19))
+	})
+}
+
+func TestExportTraceDataOpToDestination(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		params := []struct {
+			items       int
+			destination string
+			err         error
+		}{
+			{items: 5, destination: DestinationPrimary, err: nil},
+			{items: 7, destination: DestinationPrimary, err: errFake},
+			{items: 11, destination: DestinationSecondary, err: nil},
+			{items: 13, destination: DestinationSecondary, err: nil},
+			{items: 17, destination: "unknown", err: nil},
+		}
+		for _, param := range params {
+			ctx := obsrep.StartTracesOp(context.Background())
+			obsrep.EndTracesOpToDestination(ctx, param.items, param.destination, param.err)
+		}
+
+		require.Equal(t, len(params), len(tt.SpanRecorder.Ended()))
+		require.NoError(t, tt.CheckExporterTraces(46, 7))
+		require.NoError(t, tt.CheckExporterTracesToDestination(DestinationPrimary, 5, 7))
+		require.NoError(t, tt.CheckExporterTracesToDestination(DestinationSecondary, 24, 0))
+		require.Error(t, tt.CheckExporterTracesToDestination("unknown", 17, 0))
+	})
+}
+
+func TestExportTraceDataOpPartialError(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		const batchSize = 15
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		partialErr := PartialError{Accepted: 10, Refused: 5, Err: errFake}
+		assert.Equal(t, batchSize, partialErr.Accepted+partialErr.Refused)
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, batchSize, partialErr)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, 1, len(spans))
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.SentSpansKey, Value: attribute.Int64Value(10)})
+		require.Contains(t, spans[0].Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(5)})
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, errFake.Error(), spans[0].Status().Description)
+
+		require.NoError(t, tt.CheckExporterTraces(10, 5))
+	})
+}
+
+func TestExportTraceDataOpStatusMapper(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+			StatusMapper: func(err error) (codes.Code, string) {
+				if errors.Is(err, context.Canceled) {
+					return codes.Unset, ""
+				}
+				return codes.Error, err.Error()
+			},
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 5, context.Canceled)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, 1, len(spans))
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+		require.NoError(t, tt.CheckExporterTraces(0, 5))
+	})
+}
+
+func TestExportOpSignalLevels(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		noneLevel := configtelemetry.LevelNone
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+			SignalLevels:           SignalLevels{Traces: &noneLevel},
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := obsrep.StartTracesOp(context.Background())
+		obsrep.EndTracesOp(ctx, 7, nil)
+		ctx = obsrep.StartLogsOp(context.Background())
+		obsrep.EndLogsOp(ctx, 11, errFake)
+
+		// The spans are still recorded for the signals without metrics.
+		require.Len(t, tt.SpanRecorder.Ended(), 2)
+		require.Error(t, tt.CheckExporterTraces(7, 0))
+		require.NoError(t, tt.CheckExporterLogs(0, 11))
+	})
+}
+
+func TestExportLogsOpPartial(t *testing.T) {
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
+
+		obsrep, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		params := []struct {
+			items    int
+			rejected int
+			err      error
+		}{
+			{items: 17, rejected: 5, err: nil},
+			{items: 23, rejected: 23, err: errFake},
+			{items: 11, rejected: 0, err: nil},
+		}
+		for i := range params {
+			ctx := obsrep.StartLogsOp(parentCtx)
+			assert.NotNil(t, ctx)
+
+			obsrep.EndLogsOpPartial(ctx, params[i].items, params[i].rejected, params[i].err)
+		}
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params), len(spans))
+
+		var sentLogRecords, failedToSendLogRecords int
+		for i, span := range spans {
+			assert.Equal(t, "exporter/"+exporterID.String()+"/logs", span.Name())
+			sent := params[i].items - params[i].rejected
+			sentLogRecords += sent
+			failedToSendLogRecords += params[i].rejected
+			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentLogRecordsKey, Value: attribute.Int64Value(int64(sent))})
+			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendLogRecordsKey, Value: attribute.Int64Value(int64(params[i].rejected))})
+			if sent == 0 {
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+			} else {
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			}
+		}
+
+		require.NoError(t, tt.CheckExporterLogs(int64(sentLogRecords), int64(failedToSendLogRecords)))
+	})
+}
+
+// fakeRecorder is a Recorder keeping the calls it receives.
+type fakeRecorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (fr *fakeRecorder) record(call string) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	fr.calls = append(fr.calls, call)
+}
+
+func (fr *fakeRecorder) RecordReceived(_ context.Context, dataType component.DataType, accepted, refused int64) {
+	fr.record(fmt.Sprintf("received %s %d %d", dataType, accepted, refused))
+}
+
+func (fr *fakeRecorder) RecordProcessed(_ context.Context, dataType component.DataType, accepted, refused, dropped int64) {
+	fr.record(fmt.Sprintf("processed %s %d %d %d", dataType, accepted, refused, dropped))
+}
+
+func (fr *fakeRecorder) RecordSent(_ context.Context, dataType component.DataType, sent, failed int64) {
+	fr.record(fmt.Sprintf("sent %s %d %d", dataType, sent, failed))
+}
+
+func (fr *fakeRecorder) RecordScraped(_ context.Context, scraped, errored int64) {
+	fr.record(fmt.Sprintf("scraped %d %d", scraped, errored))
+}
+
+// uncomparableRecorder is a Recorder which panics when compared with ==.
+type uncomparableRecorder struct {
+	*fakeRecorder
+	_ []string
+}
+
+func TestRecorder(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		recorder := &fakeRecorder{}
+
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			Recorder:               recorder,
+		}, useOtel)
+		require.NoError(t, err)
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 7, PartialError{Accepted: 5, Refused: 2, Err: errFake})
+
+		proc, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+			Recorder:                recorder,
+		}, useOtel)
+		require.NoError(t, err)
+		proc.MetricsDropped(context.Background(), 3)
+
+		exp, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+			Recorder:               recorder,
+		}, useOtel)
+		require.NoError(t, err)
+		ctx = exp.StartLogsOp(context.Background())
+		exp.EndLogsOp(ctx, 11, errFake)
+
+		scrp, err := newScraper(ScraperSettings{
+			ReceiverID:             receiverID,
+			Scraper:                scraperID,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			Recorder:               recorder,
+		}, useOtel)
+		require.NoError(t, err)
+		ctx = scrp.StartMetricsOp(context.Background())
+		scrp.EndMetricsOp(ctx, 13, nil)
+
+		conn, err := newConnector(ConnectorSettings{
+			ConnectorID:             connectorID,
+			ConnectorCreateSettings: tt.ToConnectorCreateSettings(),
+			Recorder:                recorder,
+		}, useOtel)
+		require.NoError(t, err)
+		ctx = conn.Receiver().StartMetricsOp(context.Background())
+		conn.Receiver().EndMetricsOp(ctx, "", 17, nil)
+		ctx = conn.Exporter().StartMetricsOp(context.Background())
+		conn.Exporter().EndMetricsOp(ctx, 17, nil)
+
+		assert.Equal(t, []string{
+			"received traces 5 2",
+			"processed metrics 0 0 3",
+			"sent logs 0 11",
+			"scraped 13 0",
+			"received metrics 17 0",
+			"sent metrics 17 0",
+		}, recorder.calls)
+
+		// The counters are only recorded by the recorder.
+		require.Error(t, tt.CheckReceiverTraces(transport, 5, 2))
+		require.Error(t, tt.CheckExporterLogs(0, 11))
+		require.Error(t, obsreporttest.CheckScraperMetrics(tt, receiverID, scraperID, 13, 0))
+	})
+}
+
+func TestRecorderAtLevelNone(t *testing.T) {
+	set := receivertest.NewNopCreateSettings()
+	set.MetricsLevel = configtelemetry.LevelNone
+	recorder := &fakeRecorder{}
+	rec, err := NewReceiver(ReceiverSettings{
+		ReceiverID:             receiverID,
+		Transport:              transport,
+		ReceiverCreateSettings: set,
+		Recorder:               recorder,
+	})
+	require.NoError(t, err)
+
+	ctx := rec.StartTracesOp(context.Background())
+	rec.EndTracesOp(ctx, format, 7, nil)
+	assert.Empty(t, recorder.calls)
+}
+
+func TestAddRecorder(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		first, second := &fakeRecorder{}, &fakeRecorder{}
+
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		firstID := rec.AddRecorder(first)
+		rec.AddRecorder(second)
+		// The recorders are identified by their ID, so they do not need to be comparable.
+		third := uncomparableRecorder{fakeRecorder: &fakeRecorder{}}
+		thirdID := rec.AddRecorder(third)
+		assert.NotEqual(t, firstID, thirdID)
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 7, nil)
+
+		rec.RemoveRecorder(firstID)
+		rec.RemoveRecorder(thirdID)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 5, errFake)
+
+		exp, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		exp.AddRecorder(first)
+		exp.AddRecorder(second)
+		ctx = exp.StartLogsOp(context.Background())
+		exp.EndLogsOp(ctx, 11, nil)
+
+		assert.Equal(t, []string{"received traces 7 0", "sent logs 11 0"}, first.calls)
+		assert.Equal(t, []string{"received traces 7 0", "received traces 0 5", "sent logs 11 0"}, second.calls)
+		assert.Equal(t, []string{"received traces 7 0"}, third.calls)
+
+		// The counters are still recorded with the default backend.
+		require.NoError(t, tt.CheckReceiverTraces(transport, 7, 5))
+	})
+}
+
+func TestAddRecorderToRecorderOfSettings(t *testing.T) {
+	set := processortest.NewNopCreateSettings()
+	set.MetricsLevel = configtelemetry.LevelNormal
+	primary, added := &fakeRecorder{}, &fakeRecorder{}
+	proc, err := NewProcessor(ProcessorSettings{
+		ProcessorID:             processorID,
+		ProcessorCreateSettings: set,
+		Recorder:                primary,
+	})
+	require.NoError(t, err)
+	proc.AddRecorder(added)
+	proc.TracesAccepted(context.Background(), 3)
+	// Removing a recorder which was not added is a no-op.
+	proc.RemoveRecorder(0)
+	proc.TracesDropped(context.Background(), 2)
+
+	assert.Equal(t, []string{"processed traces 3 0 0", "processed traces 0 0 2"}, primary.calls)
+	assert.Equal(t, primary.calls, added.calls)
+}
+
+func TestConnectorTraceData(t *testing.T) {
+	testTelemetry(t, connectorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
+
+		conn, err := newConnector(ConnectorSettings{
+			ConnectorID:             connectorID,
+			ConnectorCreateSettings: tt.ToConnectorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := conn.Exporter().StartTracesOp(parentCtx)
+		conn.Exporter().EndTracesOp(ctx, 17, nil)
+		ctx = conn.Exporter().StartTracesOp(parentCtx)
+		conn.Exporter().EndTracesOp(ctx, 5, errFake)
+
+		ctx = conn.Receiver().StartTracesOp(parentCtx)
+		conn.Receiver().EndTracesOp(ctx, "", 13, nil)
+		ctx = conn.Receiver().StartTracesOp(parentCtx)
+		conn.Receiver().EndTracesOp(ctx, "", 3, errFake)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, 4, len(spans))
+		assert.Equal(t, "connector/"+connectorID.String()+"/traces", spans[0].Name())
+		assert.Equal(t, "connector/"+connectorID.String()+"/traces", spans[1].Name())
+		assert.Equal(t, "connector/"+connectorID.String()+"/TraceDataReceived", spans[2].Name())
+		assert.Equal(t, "connector/"+connectorID.String()+"/TraceDataReceived", spans[3].Name())
+
+		require.NoError(t, tt.CheckConnectorTraces(13, 3, 17, 5))
+		require.Error(t, tt.CheckExporterTraces(17, 5))
+		require.Error(t, tt.CheckReceiverTraces("", 13, 3))
+	})
+}
+
+func TestConnectorTraceDataDetailedAndBySkew(t *testing.T) {
+	testTelemetry(t, connectorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		conn, err := newConnector(ConnectorSettings{
+			ConnectorID:             connectorID,
+			ConnectorCreateSettings: tt.ToConnectorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := conn.Receiver().StartTracesOp(context.Background())
+		conn.Receiver().EndTracesOpDetailed(ctx, "", 10, 20, 5, PartialError{Accepted: 6, Refused: 4, Err: errFake})
+		ctx = conn.Receiver().StartTracesOp(context.Background())
+		conn.Receiver().EndTracesOpWithSkew(ctx, "", map[string]int{ClockSkewOK: 7, ClockSkewStale: 2}, nil)
+
+		require.NoError(t, tt.CheckConnectorTracesDetailed(12, 8, 3, 2))
+		require.NoError(t, tt.CheckConnectorTracesBySkew(ClockSkewOK, 7))
+		require.NoError(t, tt.CheckConnectorTracesBySkew(ClockSkewStale, 2))
+		require.Error(t, tt.CheckReceiverTracesDetailed("", 12, 8, 3, 2))
+		require.Error(t, tt.CheckReceiverTracesBySkew("", ClockSkewOK, 7))
+	})
+}
+
+func TestConnectorLogRecords(t *testing.T) {
+	testTelemetry(t, connectorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		conn, err := newConnector(ConnectorSettings{
+			ConnectorID:             connectorID,
+			ConnectorCreateSettings: tt.ToConnectorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		ctx := conn.Exporter().StartLogsOp(context.Background())
+		conn.Exporter().EndLogsOp(ctx, 23, nil)
+		ctx = conn.Receiver().StartLogsOp(context.Background())
+		conn.Receiver().EndLogsOp(ctx, "", 23, nil)
+
+		require.NoError(t, tt.CheckConnectorLogs(23, 0, 23, 0))
+	})
+}
+
+func TestConnectorReroute(t *testing.T) {
+	testTelemetry(t, connectorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		conn, err := newConnector(ConnectorSettings{
+			ConnectorID:             connectorID,
+			ConnectorCreateSettings: tt.ToConnectorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		conn.RecordReroute(context.Background(), component.DataTypeTraces, 7, "traces/primary", "traces/backup")
+		conn.RecordReroute(context.Background(), component.DataTypeTraces, 3, "traces/primary", "traces/backup")
+		conn.RecordReroute(context.Background(), component.DataTypeTraces, 2, "traces/secondary", "traces/backup")
+		conn.RecordReroute(context.Background(), component.DataTypeMetrics, 11, "metrics/primary", "metrics/backup")
+		conn.RecordReroute(context.Background(), component.DataTypeLogs, 13, "logs/primary", "logs/backup")
+
+		require.NoError(t, tt.CheckConnectorTracesRerouted("traces/primary", "traces/backup", 10))
+		require.NoError(t, tt.CheckConnectorTracesRerouted("traces/secondary", "traces/backup", 2))
+		require.NoError(t, tt.CheckConnectorMetricsRerouted("metrics/primary", "metrics/backup", 11))
+		require.NoError(t, tt.CheckConnectorLogsRerouted("logs/primary", "logs/backup", 13))
+	})
+}
+
+func TestNoMetricsAtLevelNone(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		tt.MetricsLevel = configtelemetry.LevelNone
+
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:                receiverID,
+			Transport:                 transport,
+			ReceiverCreateSettings:    tt.ToReceiverCreateSettings(),
+			RecordTenants:             true,
+			EstimateDistinctResources: true,
+			EstimateDistinctTraces:    true,
+			RecordDeadlineRemaining:   true,
+			RecordPipelineStart:       true,
+			RecordItemsPerCore:        true,
+			VolumeAttributeKey:        "service.namespace",
+		}, useOtel)
+		require.NoError(t, err)
+		rec.RecordResource(context.Background(), pcommon.NewResource())
+		rec.ObserveTraceID(context.Background(), pcommon.TraceID{1})
+		deadlineCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		ctx := rec.StartTracesOp(deadlineCtx)
+		rec.EndTracesOp(ctx, format, 7, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpDetailed(ctx, format, 7, 3, 2, errFake)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithSkew(ctx, format, map[string]int{ClockSkewOK: 5, ClockSkewStale: 2}, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpConverted(ctx, "zipkin", format, 3, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpForTenant(ctx, format, 3, "tenant", nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithVolume(ctx, format, []ResourceItems{{Resource: pcommon.NewResource(), NumItems: 3}}, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithHTTPStatus(ctx, format, 3, http.StatusBadRequest, errFake)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithProtoVersion(ctx, format, "v0.19", 3, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithStructure(ctx, format, 1, 2, 3, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpWithAttrStats(ctx, format, 4, 10, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.RecordFirstByte(ctx)
+		rec.RecordSchemaMismatch(ctx)
+		rec.RecordAuthFailure(ctx)
+		rec.RecordReadError(ctx)
+		rec.RecordKeepalive(ctx)
+		rec.RecordValidationError(ctx, "trace_id")
+		rec.RecordStreamClose(ctx, StreamCloseEOF)
+		rec.RecordConnection(ctx, true)
+		rec.RecordConnectionClosed(ctx)
+		rec.RecordParseDuration(ctx, format, time.Millisecond)
+		rec.EndTracesOp(ctx, format, 1, nil)
+		ctx = rec.StartMetricsOp(context.Background())
+		rec.EndMetricsOp(ctx, format, 11, errFake)
+		ctx = rec.StartLogsOp(context.Background())
+		rec.EndLogsOp(ctx, format, 13, nil)
+		ctx = rec.StartLogsOp(context.Background())
+		rec.EndLogsOpWeighted(ctx, format, 13, 1024, nil)
+		ctx = rec.StartTracesOp(context.Background())
+		rec.EndTracesOpBySampled(ctx, format, 3, 4, nil)
+
+		scrp, err := newScraper(ScraperSettings{
+			ReceiverID:             receiverID,
+			Scraper:                scraperID,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		ctx = scrp.StartMetricsOp(context.Background())
+		scrp.EndMetricsOp(ctx, 17, partialErrFake)
+		scrp.RecordCache(context.Background(), true)
+		scrp.RecordMetricScrape(context.Background(), "system.cpu.time", nil)
+
+		proc, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+			TrackAllocs:             true,
+			DroppedByPipeline:       true,
+		}, useOtel)
+		require.NoError(t, err)
+		proc.TracesAccepted(context.Background(), 19)
+		proc.TracesAcceptedFrom(context.Background(), 19, receiverID)
+		proc.MetricsRefused(context.Background(), 23)
+		proc.LogsDropped(WithPipeline(context.Background(), "logs"), 29)
+		proc.TracesDeduplicated(context.Background(), 2)
+		proc.MetricsDeduplicated(context.Background(), 3)
+		proc.LogsDeduplicated(context.Background(), 5)
+		proc.RecordFlushReason(context.Background(), FlushReasonTimeout)
+		proc.RecordSamplingDecision(context.Background(), true, 7)
+		proc.TracesDroppedByRule(context.Background(), 3, "rule")
+		proc.TracesDroppedForResource(context.Background(), 3, "service")
+		proc.TracesPassed(context.Background(), 2)
+		proc.MetricsPassed(context.Background(), 3)
+		proc.LogsPassed(context.Background(), 5)
+		proc.RecordMemoryLimited(context.Background(), component.DataTypeLogs, 5)
+		proc.BytesDropped(context.Background(), component.DataTypeLogs, 512)
+		proc.RecordEffectiveSampleRatio(context.Background(), component.DataTypeTraces, 0.5)
+		proc.RecordThresholdBreach(context.Background(), component.DataTypeMetrics, 3, "cpu_high")
+		proc.RecordEnrichment(context.Background(), component.DataTypeLogs, EnrichmentHit, 3)
+		proc.RecordTransformDropped(context.Background(), component.DataTypeLogs, 3, "unknown_schema")
+		proc.RecordProcessingError(context.Background(), component.DataTypeTraces, 2)
+		proc.RecordOutOfOrder(context.Background(), component.DataTypeMetrics, 2)
+		proc.EndOp(proc.StartOp(context.Background()))
+		proc.RecordQueueLatency(context.Background(), time.Second)
+		proc.RecordProcessingDuration(context.Background(), component.DataTypeTraces, time.Second)
+		proc.RecordFanout(context.Background(), component.DataTypeTraces, 3)
+		proc.RecordBatchSplit(context.Background(), 1, 3)
+
+		exp, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+			Compression:            "gzip",
+			RecordPipelineLatency:  true,
+			RecordQueueWait:        true,
+		}, useOtel)
+		require.NoError(t, err)
+		ctx = exp.StartTracesOp(rec.StartTracesOp(context.Background()))
+		exp.EndTracesOp(ctx, 31, nil)
+		ctx = exp.StartTracesOpQueued(context.Background(), time.Now().Add(-time.Second))
+		exp.EndTracesOp(ctx, 31, nil)
+		ctx = exp.StartMetricsOp(context.Background())
+		exp.EndMetricsOp(ctx, 37, errFake)
+		ctx = exp.StartLogsOp(context.Background())
+		exp.EndLogsOpPartial(ctx, 41, 3, errFake)
+		ctx = exp.StartTracesOp(context.Background())
+		exp.EndTracesOpToDestination(ctx, 31, DestinationSecondary, nil)
+		exp.RecordConnectionState(context.Background(), ConnectionStateReady)
+		exp.RecordBackpressure(context.Background(), true)
+		exp.RecordBackpressure(context.Background(), false)
+		exp.RecordPersistentQueueSize(context.Background(), 10, 100)
+		exp.RecordRetriesExhausted(context.Background(), component.DataTypeLogs, 3)
+		exp.RecordPartialWarning(context.Background(), component.DataTypeLogs)
+		exp.RecordAckLatency(context.Background(), time.Second)
+		exp.RecordOldestQueuedAge(context.Background(), time.Second)
+		exp.RecordTLSError(context.Background(), TLSErrorVerify)
+
+		conn, err := newConnector(ConnectorSettings{
+			ConnectorID:             connectorID,
+			ConnectorCreateSettings: tt.ToConnectorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		ctx = conn.Receiver().StartLogsOp(context.Background())
+		conn.Receiver().EndLogsOp(ctx, "", 43, nil)
+		ctx = conn.Exporter().StartLogsOp(context.Background())
+		conn.Exporter().EndLogsOp(ctx, 43, nil)
+		conn.RecordReroute(context.Background(), component.DataTypeLogs, 43, "logs/primary", "logs/backup")
+
+		c, err := newComponent(component.KindExporter, exporterID, ComponentSettings{TelemetrySettings: tt.TelemetrySettings}, useOtel)
+		require.NoError(t, err)
+		c.RecordSaturation(context.Background(), 0.5)
+		c.RecordConcurrency(context.Background(), 4)
+
+		require.NoError(t, recordBuildInfo(tt.TelemetrySettings, "v0.75.0", "3a9f1c2", "", useOtel))
+
+		require.NoError(t, obsreporttest.CheckNoMetrics(tt))
+	})
+}
+
+func TestSetLevel(t *testing.T) {
+	testTelemetry(t, receiverID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		tt.MetricsLevel = configtelemetry.LevelDetailed
+		rec, err := newReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		scrp, err := newScraper(ScraperSettings{
+			ReceiverID:             receiverID,
+			Scraper:                scraperID,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		record := func(n int) {
+			ctx := rec.StartTracesOp(context.Background())
+			rec.EndTracesOp(ctx, format, n, nil)
+			ctx = scrp.StartMetricsOp(context.Background())
+			scrp.EndMetricsOp(ctx, n, nil)
+		}
+
+		record(3)
+		rec.SetLevel(configtelemetry.LevelNone)
+		scrp.SetLevel(configtelemetry.LevelNone)
+		record(5)
+		require.NoError(t, tt.CheckReceiverTraces(transport, 3, 0))
+		require.NoError(t, obsreporttest.CheckScraperMetrics(tt, receiverID, scraperID, 3, 0))
+
+		rec.SetLevel(configtelemetry.LevelDetailed)
+		scrp.SetLevel(configtelemetry.LevelDetailed)
+		record(7)
+		require.NoError(t, tt.CheckReceiverTraces(transport, 10, 0))
+		require.NoError(t, obsreporttest.CheckScraperMetrics(tt, receiverID, scraperID, 10, 0))
+	})
+
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		tt.MetricsLevel = configtelemetry.LevelDetailed
+		proc, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+
+		proc.TracesAccepted(context.Background(), 3)
+		proc.SetLevel(configtelemetry.LevelNone)
+		proc.TracesAccepted(context.Background(), 5)
+		require.NoError(t, tt.CheckProcessorTraces(3, 0, 0))
+
+		proc.SetLevel(configtelemetry.LevelDetailed)
+		proc.TracesAccepted(context.Background(), 7)
+		require.NoError(t, tt.CheckProcessorTraces(10, 0, 0))
+	})
+
+	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		tt.MetricsLevel = configtelemetry.LevelDetailed
+		exp, err := newExporter(ExporterSettings{
+			ExporterID:             exporterID,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		record := func(n int) {
+			ctx := exp.StartTracesOp(context.Background())
+			exp.EndTracesOp(ctx, n, nil)
+		}
+
+		record(3)
+		exp.SetLevel(configtelemetry.LevelNone)
+		record(5)
+		require.NoError(t, tt.CheckExporterTraces(3, 0))
+
+		exp.SetLevel(configtelemetry.LevelDetailed)
+		record(7)
+		require.NoError(t, tt.CheckExporterTraces(10, 0))
+	})
+}
+
+func TestReceiveWithLongLivedCtx(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(receiverID)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	longLivedCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+	defer parentSpan.End()
+
+	params := []testParams{
+		{items: 17, err: nil},
+		{items: 23, err: errFake},
+	}
+	for i := range params {
+		// Use a new context on each operation to simulate distinct operations
+		// under the same long lived context.
+		rec, rerr := NewReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			LongLivedCtx:           true,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		})
+		require.NoError(t, rerr)
+		ctx := rec.StartTracesOp(longLivedCtx)
+		assert.NotNil(t, ctx)
+		rec.EndTracesOp(ctx, format, params[i].items, params[i].err)
+	}
+
+	spans := tt.SpanRecorder.Ended()
+	require.Equal(t, len(params), len(spans))
+
+	for i, span := range spans {
+		assert.False(t, span.Parent().IsValid())
+		require.Equal(t, 1, len(span.Links()))
+		link := span.Links()[0]
+		assert.Equal(t, parentSpan.SpanContext().TraceID(), link.SpanContext.TraceID())
+		assert.Equal(t, parentSpan.SpanContext().SpanID(), link.SpanContext.SpanID())
+		assert.Equal(t, "receiver/"+receiverID.String()+"/TraceDataReceived", span.Name())
+		require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.TransportKey, Value: attribute.StringValue(transport)})
+		switch {
+		case params[i].err == nil:
+			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
+			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpansKey, Value: attribute.Int64Value(0)})
+			assert.Equal(t, codes.Unset, span.Status().Code)
+		case errors.Is(params[i].err, errFake):
+			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSpansKey, Value: attribute.Int64Value(0)})
+			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
+			assert.Equal(t, codes.Error, span.Status().Code)
+			assert.Equal(t, params[i].err.Error(), span.Status().Description)
+		default:
+			t.Fatalf("unexpected error: %v", params[i].err)
+		}
+	}
+}
+
+func TestReceiveOpAttachBaggageKeys(t *testing.T) {
+	tenant, err := baggage.NewMember("tenant", "acme")
+	require.NoError(t, err)
+	region, err := baggage.NewMember("region", "eu")
+	require.NoError(t, err)
+	bag, err := baggage.New(tenant, region)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantAttrs map[attribute.Key]attribute.Value
+	}{
+		{
+			name: "present",
+			ctx:  baggage.ContextWithBaggage(context.Background(), bag),
+			wantAttrs: map[attribute.Key]attribute.Value{
+				"tenant": attribute.StringValue("acme"),
+			},
+		},
+		{
+			name:      "absent",
+			ctx:       context.Background(),
+			wantAttrs: map[attribute.Key]attribute.Value{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt, err := obsreporttest.SetupTelemetry(receiverID)
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+			rec, err := NewReceiver(ReceiverSettings{
+				ReceiverID:             receiverID,
+				Transport:              transport,
+				ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+				AttachBaggageKeys:      []string{"tenant", "missing"},
+			})
+			require.NoError(t, err)
+			ctx := rec.StartTracesOp(tc.ctx)
+			rec.EndTracesOp(ctx, format, 7, nil)
+
+			spans := tt.SpanRecorder.Ended()
+			require.Equal(t, 1, len(spans))
+			attrs := map[attribute.Key]attribute.Value{}
+			for _, kv := range spans[0].Attributes() {
+				attrs[kv.Key] = kv.Value
+			}
+			for k, v := range tc.wantAttrs {
+				assert.Equal(t, v, attrs[k])
+			}
+			assert.NotContains(t, attrs, attribute.Key("missing"))
+			assert.NotContains(t, attrs, attribute.Key("region"))
+			if len(tc.wantAttrs) == 0 {
+				assert.NotContains(t, attrs, attribute.Key("tenant"))
+			}
+			require.NoError(t, tt.CheckReceiverTraces(transport, 7, 0))
+		})
+	}
+}
+
+func TestReceiveTraceDataOpWithRemoteParent(t *testing.T) {
+	remote := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+
+	t.Run("child", func(t *testing.T) {
+		tt, err := obsreporttest.SetupTelemetry(receiverID)
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+		rec, err := NewReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		})
+		require.NoError(t, err)
+		ctx := rec.StartTracesOpWithRemoteParent(context.Background(), remote)
+		rec.EndTracesOp(ctx, format, 7, nil)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, 1, len(spans))
+		assert.Equal(t, remote.TraceID(), spans[0].SpanContext().TraceID())
+		assert.Equal(t, remote.SpanID(), spans[0].Parent().SpanID())
+		assert.True(t, spans[0].Parent().IsRemote())
+		assert.Empty(t, spans[0].Links())
+		require.NoError(t, tt.CheckReceiverTraces(transport, 7, 0))
+	})
+
+	t.Run("link", func(t *testing.T) {
+		tt, err := obsreporttest.SetupTelemetry(receiverID)
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+		longLivedCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
+
+		rec, err := NewReceiver(ReceiverSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			LongLivedCtx:           true,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		})
+		require.NoError(t, err)
+		ctx := rec.StartTracesOpWithRemoteParent(longLivedCtx, remote)
+		rec.EndTracesOp(ctx, format, 7, nil)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, 1, len(spans))
+		assert.False(t, spans[0].Parent().IsValid())
+		assert.NotEqual(t, remote.TraceID(), spans[0].SpanContext().TraceID())
+		require.Equal(t, 1, len(spans[0].Links()))
+		assert.Equal(t, remote.TraceID(), spans[0].Links()[0].SpanContext.TraceID())
+		assert.Equal(t, remote.SpanID(), spans[0].Links()[0].SpanContext.SpanID())
+		require.NoError(t, tt.CheckReceiverTraces(transport, 7, 0))
+	})
+}
+
+func TestProcessorTraceData(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		const acceptedSpans = 27
+		const refusedSpans = 19
+		const droppedSpans = 13
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		obsrep.TracesAccepted(context.Background(), acceptedSpans)
+		obsrep.TracesRefused(context.Background(), refusedSpans)
+		obsrep.TracesDropped(context.Background(), droppedSpans)
+
+		require.NoError(t, tt.CheckProcessorTraces(acceptedSpans, refusedSpans, droppedSpans))
+	})
+}
+
+func TestProcessorTraceDataFrom(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		otherReceiverID := component.NewIDWithName("fakeReceiver", "other")
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		obsrep.TracesAcceptedFrom(context.Background(), 17, receiverID)
+		obsrep.TracesAcceptedFrom(context.Background(), 5, otherReceiverID)
+		obsrep.TracesAcceptedFrom(context.Background(), 3, receiverID)
+		obsrep.TracesAccepted(context.Background(), 7)
+
+		require.NoError(t, tt.CheckProcessorTraces(32, 0, 0))
+		require.NoError(t, tt.CheckProcessorTracesFrom(receiverID, 20))
+		require.NoError(t, tt.CheckProcessorTracesFrom(otherReceiverID, 5))
+	})
+}
+
+func TestProcessorSignalCounters(t *testing.T) {
+	type checkFunc func(tt *obsreporttest.TestTelemetry, numItems int64) error
+	tests := []struct {
+		name   string
+		record func(por *Processor, ctx context.Context, signal component.DataType, numItems int)
+		checks map[component.DataType]checkFunc
+	}{
+		{
+			name:   "deduplicated",
+			record: recordBySignal((*Processor).TracesDeduplicated, (*Processor).MetricsDeduplicated, (*Processor).LogsDeduplicated),
+			checks: map[component.DataType]checkFunc{
+				component.DataTypeTraces:  (*obsreporttest.TestTelemetry).CheckProcessorTracesDeduplicated,
+				component.DataTypeMetrics: (*obsreporttest.TestTelemetry).CheckProcessorMetricsDeduplicated,
+				component.DataTypeLogs:    (*obsreporttest.TestTelemetry).CheckProcessorLogsDeduplicated,
+			},
+		},
+		{
+			name:   "passed",
+			record: recordBySignal((*Processor).TracesPassed, (*Processor).MetricsPassed, (*Processor).LogsPassed),
+			checks: map[component.DataType]checkFunc{
+				component.DataTypeTraces:  (*obsreporttest.TestTelemetry).CheckProcessorTracesPassed,
+				component.DataTypeMetrics: (*obsreporttest.TestTelemetry).CheckProcessorMetricsPassed,
+				component.DataTypeLogs:    (*obsreporttest.TestTelemetry).CheckProcessorLogsPassed,
+			},
+		},
+		{
+			name:   "bytes_dropped",
+			record: (*Processor).BytesDropped,
+			checks: map[component.DataType]checkFunc{
+				component.DataTypeTraces:  (*obsreporttest.TestTelemetry).CheckProcessorTracesBytesDropped,
+				component.DataTypeMetrics: (*obsreporttest.TestTelemetry).CheckProcessorMetricsBytesDropped,
+				component.DataTypeLogs:    (*obsreporttest.TestTelemetry).CheckProcessorLogsBytesDropped,
+			},
+		},
+		{
+			name:   "memory_limited",
+			record: (*Processor).RecordMemoryLimited,
+			checks: map[component.DataType]checkFunc{
+				component.DataTypeTraces:  (*obsreporttest.TestTelemetry).CheckProcessorTracesMemoryLimited,
+				component.DataTypeMetrics: (*obsreporttest.TestTelemetry).CheckProcessorMetricsMemoryLimited,
+				component.DataTypeLogs:    (*obsreporttest.TestTelemetry).CheckProcessorLogsMemoryLimited,
+			},
+		},
+		{
+			name:   "processing_errors",
+			record: (*Processor).RecordProcessingError,
+			checks: map[component.DataType]checkFunc{
+				component.DataTypeTraces:  (*obsreporttest.TestTelemetry).CheckProcessorTracesProcessingErrors,
+				component.DataTypeMetrics: (*obsreporttest.TestTelemetry).CheckProcessorMetricsProcessingErrors,
+				component.DataTypeLogs:    (*obsreporttest.TestTelemetry).CheckProcessorLogsProcessingErrors,
+			},
+		},
+		{
+			name:   "out_of_order",
+			record: (*Processor).RecordOutOfOrder,
+			checks: map[component.DataType]checkFunc{
+				component.DataTypeTraces:  (*obsreporttest.TestTelemetry).CheckProcessorTracesOutOfOrder,
+				component.DataTypeMetrics: (*obsreporttest.TestTelemetry).CheckProcessorMetricsOutOfOrder,
+				component.DataTypeLogs:    (*obsreporttest.TestTelemetry).CheckProcessorLogsOutOfOrder,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+				obsrep, err := newProcessor(ProcessorSettings{
+					ProcessorID:             processorID,
+					ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+				}, useOtel)
+				require.NoError(t, err)
+				tc.record(obsrep, context.Background(), component.DataTypeTraces, 7)
+				tc.record(obsrep, context.Background(), component.DataTypeTraces, 3)
+				tc.record(obsrep, context.Background(), component.DataTypeMetrics, 11)
+				tc.record(obsrep, context.Background(), component.DataTypeLogs, 13)
+				tc.record(obsrep, context.Background(), component.DataType("profiles"), 5)
+
+				require.NoError(t, tc.checks[component.DataTypeTraces](&tt, 10))
+				require.NoError(t, tc.checks[component.DataTypeMetrics](&tt, 11))
+				require.NoError(t, tc.checks[component.DataTypeLogs](&tt, 13))
+				// The items are not recorded as accepted, refused or dropped.
+				require.Error(t, tt.CheckProcessorTraces(10, 0, 0))
+				require.Error(t, tt.CheckProcessorTraces(0, 10, 0))
+				require.Error(t, tt.CheckProcessorTraces(0, 0, 10))
+			})
+		})
+	}
+}
+
+// recordBySignal returns a function recording the items of a signal with the given
+// function of the signal, ignoring the other signals.
+func recordBySignal(traces, metrics, logs func(*Processor, context.Context, int)) func(*Processor, context.Context, component.DataType, int) {
+	return func(por *Processor, ctx context.Context, signal component.DataType, numItems int) {
+		switch signal {
+		case component.DataTypeTraces:
+			traces(por, ctx, numItems)
+		case component.DataTypeMetrics:
+			metrics(por, ctx, numItems)
+		case component.DataTypeLogs:
+			logs(por, ctx, numItems)
+		}
+	}
+}
+
+func TestProcessorFlushReason(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		for i := 0; i < 3; i++ {
+			obsrep.RecordFlushReason(context.Background(), FlushReasonSize)
+		}
+		obsrep.RecordFlushReason(context.Background(), FlushReasonTimeout)
+		obsrep.RecordFlushReason(context.Background(), FlushReasonTimeout)
+		obsrep.RecordFlushReason(context.Background(), FlushReasonForce)
+		obsrep.RecordFlushReason(context.Background(), "unknown")
+
+		require.NoError(t, tt.CheckProcessorFlushReason(FlushReasonSize, 3))
+		require.NoError(t, tt.CheckProcessorFlushReason(FlushReasonTimeout, 2))
+		require.NoError(t, tt.CheckProcessorFlushReason(FlushReasonForce, 1))
+		require.Error(t, tt.CheckProcessorFlushReason("unknown", 1))
+	})
+}
+
+func TestProcessorTimeoutFlushRatio(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		now := time.Unix(1700000000, 0)
+		obsrep.now = func() time.Time { return now }
+
+		obsrep.RecordFlushReason(context.Background(), FlushReasonTimeout)
+		obsrep.RecordFlushReason(context.Background(), FlushReasonSize)
+		now = now.Add(20 * time.Second)
+		obsrep.RecordFlushReason(context.Background(), FlushReasonSize)
+		obsrep.RecordFlushReason(context.Background(), FlushReasonTimeout)
+		// The forced flushes are not included.
+		obsrep.RecordFlushReason(context.Background(), FlushReasonForce)
+		require.NoError(t, tt.CheckProcessorTimeoutFlushRatio(0.5))
+
+		// The first flushes left the window.
+		now = now.Add(50 * time.Second)
+		obsrep.RecordFlushReason(context.Background(), FlushReasonTimeout)
+		obsrep.RecordFlushReason(context.Background(), FlushReasonTimeout)
+		require.NoError(t, tt.CheckProcessorTimeoutFlushRatio(0.75))
+
+		// All the flushes left the window.
+		now = now.Add(2 * time.Minute)
+		obsrep.RecordFlushReason(context.Background(), FlushReasonSize)
+		require.NoError(t, tt.CheckProcessorTimeoutFlushRatio(0))
+	})
+}
+
+func TestProcessorTracesDroppedByRule(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+			DropRuleIDs:             []string{"drop_health_checks", "drop_debug"},
+		}, useOtel)
+		require.NoError(t, err)
+
+		obsrep.TracesDroppedByRule(context.Background(), 5, "drop_health_checks")
+		obsrep.TracesDroppedByRule(context.Background(), 3, "drop_debug")
+		obsrep.TracesDroppedByRule(context.Background(), 2, "drop_health_checks")
+		obsrep.TracesDroppedByRule(context.Background(), 1, "not_configured")
+
+		require.NoError(t, tt.CheckProcessorTraces(0, 0, 11))
+		require.NoError(t, tt.CheckProcessorTracesDroppedByRule("drop_health_checks", 7))
+		require.NoError(t, tt.CheckProcessorTracesDroppedByRule("drop_debug", 3))
+		require.NoError(t, tt.CheckProcessorTracesDroppedByRule(DropRuleOther, 1))
+		require.Error(t, tt.CheckProcessorTracesDroppedByRule("not_configured", 1))
+	})
+}
+
+func TestProcessorDroppedByPipeline(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+			DroppedByPipeline:       true,
+		}, useOtel)
+		require.NoError(t, err)
+		assert.True(t, obsrep.DroppedByPipeline())
+
+		frontend := WithPipeline(context.Background(), "traces/frontend")
+		backend := WithPipeline(context.Background(), "traces/backend")
+		obsrep.TracesDropped(frontend, 5)
+		obsrep.TracesDropped(backend, 3)
+		obsrep.TracesDroppedByRule(frontend, 2, DropRuleOther)
+		obsrep.MetricsDropped(backend, 7)
+		obsrep.LogsDropped(frontend, 11)
+		// The drops without a pipeline in the context are not broken down.
+		obsrep.TracesDropped(context.Background(), 13)
+
+		require.NoError(t, tt.CheckProcessorTraces(0, 0, 23))
+		require.NoError(t, tt.CheckProcessorTracesDroppedByPipeline("traces/frontend", 7))
+		require.NoError(t, tt.CheckProcessorTracesDroppedByPipeline("traces/backend", 3))
+		require.NoError(t, tt.CheckProcessorMetricsDroppedByPipeline("traces/backend", 7))
+		require.Error(t, tt.CheckProcessorMetricsDroppedByPipeline("traces/frontend", 0))
+		require.NoError(t, tt.CheckProcessorLogsDroppedByPipeline("traces/frontend", 11))
+		require.Error(t, tt.CheckProcessorTracesDroppedByPipeline("", 13))
+	})
+}
+
+func TestProcessorDroppedByPipelineNotRequested(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		assert.False(t, obsrep.DroppedByPipeline())
+
+		obsrep.TracesDropped(WithPipeline(context.Background(), "traces/frontend"), 5)
+
+		require.NoError(t, tt.CheckProcessorTraces(0, 0, 5))
+		require.Error(t, tt.CheckProcessorTracesDroppedByPipeline("traces/frontend", 5))
+	})
+}
+
+func TestProcessorTracesDroppedForResource(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
 
-		require.NoError(t, obsreporttest.CheckScraperMetrics(tt, receiverID, scraperID, int64(scrapedMetricPoints), int64(erroredMetricPoints)))
+		obsrep.TracesDroppedForResource(context.Background(), 5, "checkout")
+		obsrep.TracesDroppedForResource(context.Background(), 3, "frontend")
+		obsrep.TracesDroppedForResource(context.Background(), 2, "checkout")
+		obsrep.TracesDroppedForResource(context.Background(), 1, "")
+
+		require.NoError(t, tt.CheckProcessorTraces(0, 0, 11))
+		require.NoError(t, tt.CheckProcessorTracesDroppedForResource("checkout", 7))
+		require.NoError(t, tt.CheckProcessorTracesDroppedForResource("frontend", 3))
+		require.Error(t, tt.CheckProcessorTracesDroppedForResource("", 1))
 	})
 }
 
-func TestExportTraceDataOp(t *testing.T) {
-	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
-		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
-		defer parentSpan.End()
-
-		obsrep, err := newExporter(ExporterSettings{
-			ExporterID:             exporterID,
-			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+func TestProcessorSamplingDecision(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
 		}, useOtel)
 		require.NoError(t, err)
 
-		params := []testParams{
-			{items: 22, err: nil},
-			{items: 14, err: errFake},
-		}
-		for i := range params {
-			ctx := obsrep.StartTracesOp(parentCtx)
-			assert.NotNil(t, ctx)
-			obsrep.EndTracesOp(ctx, params[i].items, params[i].err)
-		}
+		obsrep.RecordSamplingDecision(context.Background(), true, 7)
+		obsrep.RecordSamplingDecision(context.Background(), false, 5)
+		obsrep.RecordSamplingDecision(context.Background(), true, 3)
 
-		spans := tt.SpanRecorder.Ended()
-		require.Equal(t, len(params), len(spans))
+		require.NoError(t, tt.CheckProcessorSampledSpans(SamplingDecisionKept, 10))
+		require.NoError(t, tt.CheckProcessorSampledSpans(SamplingDecisionDropped, 5))
+	})
+}
 
-		var sentSpans, failedToSendSpans int
-		for i, span := range spans {
-			assert.Equal(t, "exporter/"+exporterID.String()+"/traces", span.Name())
-			switch {
-			case params[i].err == nil:
-				sentSpans += params[i].items
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(0)})
-				assert.Equal(t, codes.Unset, span.Status().Code)
-			case errors.Is(params[i].err, errFake):
-				failedToSendSpans += params[i].items
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentSpansKey, Value: attribute.Int64Value(0)})
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
-				assert.Equal(t, codes.Error, span.Status().Code)
-				assert.Equal(t, params[i].err.Error(), span.Status().Description)
-			default:
-				t.Fatalf("unexpected error: %v", params[i].err)
-			}
-		}
+func TestProcessorTransformDropped(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
+		obsrep.TracesDropped(context.Background(), 2)
+		obsrep.RecordTransformDropped(context.Background(), component.DataTypeTraces, 3, "unmappable_attribute")
+		obsrep.RecordTransformDropped(context.Background(), component.DataTypeTraces, 4, "unmappable_attribute")
+		obsrep.RecordTransformDropped(context.Background(), component.DataTypeTraces, 5, "unknown_schema")
+		obsrep.RecordTransformDropped(context.Background(), component.DataTypeMetrics, 6, "unknown_schema")
+		obsrep.RecordTransformDropped(context.Background(), component.DataTypeLogs, 7, "unmappable_attribute")
+		obsrep.RecordTransformDropped(context.Background(), component.DataType("profiles"), 1, "unknown_schema")
 
-		require.NoError(t, tt.CheckExporterTraces(int64(sentSpans), int64(failedToSendSpans)))
+		require.NoError(t, tt.CheckProcessorTracesTransformDropped("unmappable_attribute", 7))
+		require.NoError(t, tt.CheckProcessorTracesTransformDropped("unknown_schema", 5))
+		require.NoError(t, tt.CheckProcessorMetricsTransformDropped("unknown_schema", 6))
+		require.NoError(t, tt.CheckProcessorLogsTransformDropped("unmappable_attribute", 7))
+		// Only the filtered items are counted as dropped.
+		require.NoError(t, tt.CheckProcessorTraces(0, 0, 2))
 	})
 }
 
-func TestExportMetricsOp(t *testing.T) {
-	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
-		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
-		defer parentSpan.End()
+func TestProcessorEnrichment(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
 
-		obsrep, err := newExporter(ExporterSettings{
-			ExporterID:             exporterID,
-			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		obsrep.RecordEnrichment(context.Background(), component.DataTypeTraces, EnrichmentHit, 5)
+		obsrep.RecordEnrichment(context.Background(), component.DataTypeTraces, EnrichmentHit, 3)
+		obsrep.RecordEnrichment(context.Background(), component.DataTypeTraces, EnrichmentMiss, 2)
+		obsrep.RecordEnrichment(context.Background(), component.DataTypeTraces, EnrichmentError, 1)
+		obsrep.RecordEnrichment(context.Background(), component.DataTypeLogs, EnrichmentMiss, 4)
+		obsrep.RecordEnrichment(context.Background(), component.DataTypeLogs, "timeout", 6)
+		obsrep.RecordEnrichment(context.Background(), component.DataType("profiles"), EnrichmentHit, 7)
+
+		require.NoError(t, tt.CheckProcessorEnrichedItems(component.DataTypeTraces, EnrichmentHit, 8))
+		require.NoError(t, tt.CheckProcessorEnrichedItems(component.DataTypeTraces, EnrichmentMiss, 2))
+		require.NoError(t, tt.CheckProcessorEnrichedItems(component.DataTypeTraces, EnrichmentError, 1))
+		require.NoError(t, tt.CheckProcessorEnrichedItems(component.DataTypeLogs, EnrichmentMiss, 4))
+		require.Error(t, tt.CheckProcessorEnrichedItems(component.DataTypeLogs, "timeout", 6))
+		require.Error(t, tt.CheckProcessorEnrichedItems(component.DataType("profiles"), EnrichmentHit, 7))
+	})
+}
+
+func TestProcessorThresholdBreaches(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+			ThresholdNames:          []string{"cpu_high", "latency_spike"},
 		}, useOtel)
 		require.NoError(t, err)
 
-		params := []testParams{
-			{items: 17, err: nil},
-			{items: 23, err: errFake},
-		}
-		for i := range params {
-			ctx := obsrep.StartMetricsOp(parentCtx)
-			assert.NotNil(t, ctx)
+		obsrep.RecordThresholdBreach(context.Background(), component.DataTypeMetrics, 3, "cpu_high")
+		obsrep.RecordThresholdBreach(context.Background(), component.DataTypeMetrics, 2, "cpu_high")
+		obsrep.RecordThresholdBreach(context.Background(), component.DataTypeMetrics, 4, "latency_spike")
+		obsrep.RecordThresholdBreach(context.Background(), component.DataTypeTraces, 7, "latency_spike")
+		obsrep.RecordThresholdBreach(context.Background(), component.DataTypeTraces, 1, "not_configured")
 
-			obsrep.EndMetricsOp(ctx, params[i].items, params[i].err)
-		}
+		require.NoError(t, tt.CheckProcessorThresholdBreaches(component.DataTypeMetrics, "cpu_high", 5))
+		require.NoError(t, tt.CheckProcessorThresholdBreaches(component.DataTypeMetrics, "latency_spike", 4))
+		require.NoError(t, tt.CheckProcessorThresholdBreaches(component.DataTypeTraces, "latency_spike", 7))
+		require.NoError(t, tt.CheckProcessorThresholdBreaches(component.DataTypeTraces, ThresholdOther, 1))
+		require.Error(t, tt.CheckProcessorThresholdBreaches(component.DataTypeTraces, "not_configured", 1))
+	})
+}
 
-		spans := tt.SpanRecorder.Ended()
-		require.Equal(t, len(params), len(spans))
+func TestProcessorEffectiveSampleRatio(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
 
-		var sentMetricPoints, failedToSendMetricPoints int
-		for i, span := range spans {
-			assert.Equal(t, "exporter/"+exporterID.String()+"/metrics", span.Name())
-			switch {
-			case params[i].err == nil:
-				sentMetricPoints += params[i].items
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendMetricPointsKey, Value: attribute.Int64Value(0)})
-				assert.Equal(t, codes.Unset, span.Status().Code)
-			case errors.Is(params[i].err, errFake):
-				failedToSendMetricPoints += params[i].items
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentMetricPointsKey, Value: attribute.Int64Value(0)})
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
-				assert.Equal(t, codes.Error, span.Status().Code)
-				assert.Equal(t, params[i].err.Error(), span.Status().Description)
-			default:
-				t.Fatalf("unexpected error: %v", params[i].err)
-			}
-		}
+		obsrep.RecordEffectiveSampleRatio(context.Background(), component.DataTypeTraces, 0.5)
+		obsrep.RecordEffectiveSampleRatio(context.Background(), component.DataTypeTraces, 0.25)
+		obsrep.RecordEffectiveSampleRatio(context.Background(), component.DataTypeLogs, 1)
+		require.NoError(t, tt.CheckProcessorEffectiveSampleRatio(component.DataTypeTraces, 0.25))
+		require.NoError(t, tt.CheckProcessorEffectiveSampleRatio(component.DataTypeLogs, 1))
 
-		require.NoError(t, tt.CheckExporterMetrics(int64(sentMetricPoints), int64(failedToSendMetricPoints)))
+		// Invalid ratios are ignored.
+		obsrep.RecordEffectiveSampleRatio(context.Background(), component.DataTypeTraces, 1.5)
+		obsrep.RecordEffectiveSampleRatio(context.Background(), component.DataTypeTraces, -0.1)
+		obsrep.RecordEffectiveSampleRatio(context.Background(), component.DataTypeTraces, math.NaN())
+		obsrep.RecordEffectiveSampleRatio(context.Background(), component.DataTypeMetrics, 2)
+		require.NoError(t, tt.CheckProcessorEffectiveSampleRatio(component.DataTypeTraces, 0.25))
+		require.Error(t, tt.CheckProcessorEffectiveSampleRatio(component.DataTypeMetrics, 2))
 	})
 }
 
-func TestExportLogsOp(t *testing.T) {
-	testTelemetry(t, exporterID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
-		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
-		defer parentSpan.End()
+func TestProcessorQueueLatency(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
 
-		obsrep, err := newExporter(ExporterSettings{
-			ExporterID:             exporterID,
-			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		obsrep.RecordQueueLatency(context.Background(), 3*time.Millisecond)
+		obsrep.RecordQueueLatency(context.Background(), 250*time.Millisecond)
+		obsrep.RecordQueueLatency(context.Background(), 2*time.Second)
+
+		require.NoError(t, tt.CheckProcessorQueueLatency(3))
+	})
+}
+
+func TestProcessorProcessingDuration(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
 		}, useOtel)
 		require.NoError(t, err)
 
-		params := []testParams{
-			{items: 17, err: nil},
-			{items: 23, err: errFake},
-		}
-		for i := range params {
-			ctx := obsrep.StartLogsOp(parentCtx)
-			assert.NotNil(t, ctx)
+		// Not recorded below the detailed level.
+		obsrep.RecordProcessingDuration(context.Background(), component.DataTypeTraces, time.Second)
+		require.Error(t, tt.CheckProcessorProcessingDuration(component.DataTypeTraces, 1, 1000))
 
-			obsrep.EndLogsOp(ctx, params[i].items, params[i].err)
-		}
+		obsrep.SetLevel(configtelemetry.LevelDetailed)
+		obsrep.RecordProcessingDuration(context.Background(), component.DataTypeTraces, 3*time.Millisecond)
+		obsrep.RecordProcessingDuration(context.Background(), component.DataTypeTraces, 250*time.Millisecond)
+		obsrep.RecordProcessingDuration(context.Background(), component.DataTypeLogs, 1500*time.Microsecond)
+		obsrep.RecordProcessingDuration(context.Background(), component.DataType("profiles"), time.Second)
 
-		spans := tt.SpanRecorder.Ended()
-		require.Equal(t, len(params), len(spans))
+		require.NoError(t, tt.CheckProcessorProcessingDuration(component.DataTypeTraces, 2, 253))
+		require.NoError(t, tt.CheckProcessorProcessingDuration(component.DataTypeLogs, 1, 1.5))
+	})
+}
 
-		var sentLogRecords, failedToSendLogRecords int
-		for i, span := range spans {
-			assert.Equal(t, "exporter/"+exporterID.String()+"/logs", span.Name())
-			switch {
-			case params[i].err == nil:
-				sentLogRecords += params[i].items
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentLogRecordsKey, Value: attribute.Int64Value(int64(params[i].items))})
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendLogRecordsKey, Value: attribute.Int64Value(0)})
-				assert.Equal(t, codes.Unset, span.Status().Code)
-			case errors.Is(params[i].err, errFake):
-				failedToSendLogRecords += params[i].items
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentLogRecordsKey, Value: attribute.Int64Value(0)})
-				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendLogRecordsKey, Value: attribute.Int64Value(int64(params[i].items))})
-				assert.Equal(t, codes.Error, span.Status().Code)
-				assert.Equal(t, params[i].err.Error(), span.Status().Description)
-			default:
-				t.Fatalf("unexpected error: %v", params[i].err)
-			}
-		}
+func TestProcessorFanout(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
 
-		require.NoError(t, tt.CheckExporterLogs(int64(sentLogRecords), int64(failedToSendLogRecords)))
+		obsrep.RecordFanout(context.Background(), component.DataTypeTraces, 1)
+		obsrep.RecordFanout(context.Background(), component.DataTypeTraces, 2)
+		obsrep.RecordFanout(context.Background(), component.DataTypeTraces, 2)
+		obsrep.RecordFanout(context.Background(), component.DataTypeTraces, 5)
+		obsrep.RecordFanout(context.Background(), component.DataTypeMetrics, 3)
+		// Not recorded.
+		obsrep.RecordFanout(context.Background(), component.DataTypeLogs, 0)
+		obsrep.RecordFanout(context.Background(), component.DataType("profiles"), 2)
+
+		require.NoError(t, tt.CheckProcessorFanoutDegree(component.DataTypeTraces, 4, 10))
+		require.NoError(t, tt.CheckProcessorFanoutDegree(component.DataTypeMetrics, 1, 3))
+		require.Error(t, tt.CheckProcessorFanoutDegree(component.DataTypeLogs, 1, 0))
 	})
 }
 
-func TestReceiveWithLongLivedCtx(t *testing.T) {
-	tt, err := obsreporttest.SetupTelemetry(receiverID)
-	require.NoError(t, err)
-	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+func TestProcessorBatchSplit(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, useOtel)
+		require.NoError(t, err)
 
-	longLivedCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
-	defer parentSpan.End()
+		obsrep.RecordBatchSplit(context.Background(), 1, 3)
+		obsrep.RecordBatchSplit(context.Background(), 2, 5)
+		// No batch to split, or fewer resulting batches, nothing is recorded.
+		obsrep.RecordBatchSplit(context.Background(), 0, 4)
+		obsrep.RecordBatchSplit(context.Background(), 2, 1)
+		obsrep.RecordBatchSplit(context.Background(), 2, 0)
+		obsrep.RecordBatchSplit(context.Background(), 2, -3)
 
-	params := []testParams{
-		{items: 17, err: nil},
-		{items: 23, err: errFake},
-	}
-	for i := range params {
-		// Use a new context on each operation to simulate distinct operations
-		// under the same long lived context.
-		rec, rerr := NewReceiver(ReceiverSettings{
-			ReceiverID:             receiverID,
-			Transport:              transport,
-			LongLivedCtx:           true,
-			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
-		})
-		require.NoError(t, rerr)
-		ctx := rec.StartTracesOp(longLivedCtx)
-		assert.NotNil(t, ctx)
-		rec.EndTracesOp(ctx, format, params[i].items, params[i].err)
-	}
+		require.NoError(t, tt.CheckProcessorBatchSplitFactor(2, 5.5))
+	})
+}
 
-	spans := tt.SpanRecorder.Ended()
-	require.Equal(t, len(params), len(spans))
+func TestProcessorTrackAllocs(t *testing.T) {
+	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
+		tt.MetricsLevel = configtelemetry.LevelDetailed
+		obsrep, err := newProcessor(ProcessorSettings{
+			ProcessorID:             processorID,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+			TrackAllocs:             true,
+		}, useOtel)
+		require.NoError(t, err)
 
-	for i, span := range spans {
-		assert.False(t, span.Parent().IsValid())
-		require.Equal(t, 1, len(span.Links()))
-		link := span.Links()[0]
-		assert.Equal(t, parentSpan.SpanContext().TraceID(), link.SpanContext.TraceID())
-		assert.Equal(t, parentSpan.SpanContext().SpanID(), link.SpanContext.SpanID())
-		assert.Equal(t, "receiver/"+receiverID.String()+"/TraceDataReceived", span.Name())
-		require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.TransportKey, Value: attribute.StringValue(transport)})
-		switch {
-		case params[i].err == nil:
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpansKey, Value: attribute.Int64Value(0)})
-			assert.Equal(t, codes.Unset, span.Status().Code)
-		case errors.Is(params[i].err, errFake):
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.AcceptedSpansKey, Value: attribute.Int64Value(0)})
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.RefusedSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
-			assert.Equal(t, codes.Error, span.Status().Code)
-			assert.Equal(t, params[i].err.Error(), span.Status().Description)
-		default:
-			t.Fatalf("unexpected error: %v", params[i].err)
+		var data [][]byte
+		for i := 0; i < 3; i++ {
+			ctx := obsrep.StartOp(context.Background())
+			data = append(data, make([]byte, 1<<16))
+			obsrep.EndOp(ctx)
 		}
-	}
+		assert.Len(t, data, 3)
+
+		require.NoError(t, tt.CheckProcessorAllocatedBytes(3))
+	})
 }
 
-func TestProcessorTraceData(t *testing.T) {
+func TestProcessorTrackAllocsNotDetailed(t *testing.T) {
 	testTelemetry(t, processorID, func(t *testing.T, tt obsreporttest.TestTelemetry, useOtel bool) {
-		const acceptedSpans = 27
-		const refusedSpans = 19
-		const droppedSpans = 13
 		obsrep, err := newProcessor(ProcessorSettings{
 			ProcessorID:             processorID,
 			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+			TrackAllocs:             true,
 		}, useOtel)
 		require.NoError(t, err)
-		obsrep.TracesAccepted(context.Background(), acceptedSpans)
-		obsrep.TracesRefused(context.Background(), refusedSpans)
-		obsrep.TracesDropped(context.Background(), droppedSpans)
 
-		require.NoError(t, tt.CheckProcessorTraces(acceptedSpans, refusedSpans, droppedSpans))
+		ctx := obsrep.StartOp(context.Background())
+		obsrep.EndOp(ctx)
+
+		require.Error(t, tt.CheckProcessorAllocatedBytes(1))
 	})
 }
 
@@ -506,6 +4565,35 @@ func TestProcessorMetricsData(t *testing.T) {
 	})
 }
 
+func BenchmarkProcessorTracesAccepted(b *testing.B) {
+	for _, tc := range []struct {
+		name    string
+		useOtel bool
+	}{
+		{name: "WithOC", useOtel: false},
+		{name: "WithOTel", useOtel: true},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			tt, err := obsreporttest.SetupTelemetry(processorID)
+			require.NoError(b, err)
+			b.Cleanup(func() { require.NoError(b, tt.Shutdown(context.Background())) })
+
+			obsrep, err := newProcessor(ProcessorSettings{
+				ProcessorID:             processorID,
+				ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+			}, tc.useOtel)
+			require.NoError(b, err)
+
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				obsrep.TracesAccepted(ctx, 10)
+			}
+		})
+	}
+}
+
 func TestBuildProcessorCustomMetricName(t *testing.T) {
 	tests := []struct {
 		name string
@@ -524,8 +4612,11 @@ func TestBuildProcessorCustomMetricName(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			got := BuildProcessorCustomMetricName("test_type", tt.name)
 			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want, MetricNamingDefault.BuildProcessorCustomMetricName("test_type", tt.name))
 		})
 	}
+	assert.Equal(t, "processor_test_type_firstMeasure", MetricNamingOpenMetrics.BuildProcessorCustomMetricName("test_type", "firstMeasure"))
+	assert.Equal(t, "processor_", MetricNamingOpenMetrics.BuildProcessorCustomMetricName("", "firstMeasure"))
 }
 
 func TestProcessorLogRecords(t *testing.T) {
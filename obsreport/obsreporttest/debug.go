@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreporttest // import "go.opentelemetry.io/collector/obsreport/obsreporttest"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+)
+
+// EnableDebugPrinting writes, every interval, the current values of the obsreport
+// OpenCensus views registered for the given level to w. It is meant for local
+// development only. It returns an error if the OpenTelemetry internal metrics are
+// enabled, since those are not recorded on the OpenCensus views. The returned
+// function stops the printing.
+func EnableDebugPrinting(w io.Writer, level configtelemetry.Level, interval time.Duration) (func(), error) {
+	if obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled() {
+		return nil, errors.New("debug printing is not supported when the OpenTelemetry internal metrics are enabled")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid debug printing interval %v", interval)
+	}
+
+	views := obsreportconfig.AllViews(level)
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ticker.C:
+				printViews(w, views)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ticker.Stop()
+			close(done)
+			wg.Wait()
+		})
+	}, nil
+}
+
+// printViews writes one line per time series of the given views.
+func printViews(w io.Writer, views []*view.View) {
+	for _, v := range views {
+		rows, err := view.RetrieveData(v.Name)
+		if err != nil {
+			// The view is not registered.
+			continue
+		}
+		for _, row := range rows {
+			_, _ = fmt.Fprintf(w, "%s{%s} %s\n", v.Name, formatTags(row.Tags), formatData(row.Data))
+		}
+	}
+}
+
+func formatTags(tags []tag.Tag) string {
+	kvs := make([]string, 0, len(tags))
+	for _, t := range tags {
+		kvs = append(kvs, t.Key.Name()+"="+t.Value)
+	}
+	return strings.Join(kvs, ",")
+}
+
+func formatData(data view.AggregationData) string {
+	switch d := data.(type) {
+	case *view.SumData:
+		return fmt.Sprintf("%v", d.Value)
+	case *view.CountData:
+		return fmt.Sprintf("%v", d.Value)
+	case *view.LastValueData:
+		return fmt.Sprintf("%v", d.Value)
+	case *view.DistributionData:
+		return fmt.Sprintf("count=%v sum=%v", d.Count, d.Sum())
+	default:
+		return fmt.Sprintf("%v", d)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreporttest_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.buf.Write(p)
+}
+
+func (sb *syncBuffer) String() string {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.buf.String()
+}
+
+func TestEnableDebugPrinting(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(exporter)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	exp, err := obsreport.NewExporter(obsreport.ExporterSettings{
+		ExporterID:             exporter,
+		ExporterCreateSettings: tt.ToExporterCreateSettings(),
+	})
+	require.NoError(t, err)
+	ctx := exp.StartTracesOp(context.Background())
+	exp.EndTracesOp(ctx, 22, nil)
+
+	buf := &syncBuffer{}
+	stop, err := obsreporttest.EnableDebugPrinting(buf, configtelemetry.LevelNormal, time.Millisecond)
+	require.NoError(t, err)
+	defer stop()
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(buf.String(), "exporter/sent_spans{exporter="+exporter.String()+"} 22")
+	}, time.Second, time.Millisecond)
+}
+
+func TestEnableDebugPrintingInvalidInterval(t *testing.T) {
+	_, err := obsreporttest.EnableDebugPrinting(&syncBuffer{}, configtelemetry.LevelNormal, 0)
+	assert.Error(t, err)
+}
+
+func TestEnableDebugPrintingOtel(t *testing.T) {
+	gateID := obsreportconfig.UseOtelForInternalMetricsfeatureGate.ID()
+	wasEnabled := obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(gateID, true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(gateID, wasEnabled))
+	}()
+
+	_, err := obsreporttest.EnableDebugPrinting(&syncBuffer{}, configtelemetry.LevelNormal, time.Millisecond)
+	assert.Error(t, err)
+}
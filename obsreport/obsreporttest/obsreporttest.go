@@ -16,6 +16,8 @@ package obsreporttest // import "go.opentelemetry.io/collector/obsreport/obsrepo
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	ocprom "contrib.go.opencensus.io/exporter/prometheus"
 	"github.com/prometheus/client_golang/prometheus"
@@ -30,6 +32,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
@@ -46,19 +50,59 @@ const (
 	// Changes to metric names or labels can break alerting, dashboards, etc
 	// that are used to monitor the Collector in production deployments.
 	// DO NOT SWITCH THE VARIABLES BELOW TO SIMILAR ONES DEFINED ON THE PACKAGE.
-	receiverTag  = "receiver"
-	scraperTag   = "scraper"
-	transportTag = "transport"
-	exporterTag  = "exporter"
-	processorTag = "processor"
+	receiverTag    = "receiver"
+	scraperTag     = "scraper"
+	transportTag   = "transport"
+	exporterTag    = "exporter"
+	processorTag   = "processor"
+	connectorTag   = "connector"
+	formatTag      = "format"
+	clockSkewTag   = "clock_skew"
+	sourceTag      = "source_receiver"
+	reasonTag      = "reason"
+	decisionTag    = "decision"
+	stateTag       = "state"
+	ruleIDTag      = "rule_id"
+	pipelineTag    = "pipeline"
+	destinationTag = "destination"
+	fromFormatTag  = "from_format"
+	toFormatTag    = "to_format"
+	tenantTag      = "tenant"
+	signalTag      = "signal"
+	outcomeTag     = "outcome"
+	connectionTag  = "connection"
+	sampledTag     = "sampled"
+	thresholdTag   = "threshold"
+	compressionTag = "compression"
+	versionTag     = "version"
+	commitTag      = "commit"
+	metricNameTag  = "metric_name"
+	fromPipeTag    = "from_pipeline"
+	toPipeTag      = "to_pipeline"
+	statusCodeTag  = "http_status_code"
+	protoVerTag    = "proto_version"
+	fieldTag       = "field"
+	volumeTag      = "volume"
+	resourceTag    = "resource"
+	kindTag        = "kind"
+
+	// checkEventuallyInterval is the interval between the attempts of the Check*Eventually functions.
+	checkEventuallyInterval = 10 * time.Millisecond
 )
 
 type TestTelemetry struct {
 	component.TelemetrySettings
-	id           component.ID
-	SpanRecorder *tracetest.SpanRecorder
-	views        []*view.View
+	id             component.ID
+	SpanRecorder   *tracetest.SpanRecorder
+	tracerProvider *sdktrace.TracerProvider
+
+	// testMetrics is shared by all the copies of the TestTelemetry, so that
+	// Reset and Shutdown always operate on the current metrics setup.
+	*testMetrics
+}
 
+type testMetrics struct {
+	views                 []*view.View
 	otelPrometheusChecker *prometheusChecker
 	meterProvider         *sdkmetric.MeterProvider
 	ocExporter            *ocprom.Exporter
@@ -72,6 +116,14 @@ func (tts *TestTelemetry) ToExporterCreateSettings() exporter.CreateSettings {
 	return set
 }
 
+// ToConnectorCreateSettings returns a connector.CreateSettings with configured TelemetrySettings.
+func (tts *TestTelemetry) ToConnectorCreateSettings() connector.CreateSettings {
+	set := connectortest.NewNopCreateSettings()
+	set.TelemetrySettings = tts.TelemetrySettings
+	set.ID = tts.id
+	return set
+}
+
 // ToProcessorCreateSettings returns a processor.CreateSettings with configured TelemetrySettings.
 func (tts *TestTelemetry) ToProcessorCreateSettings() processor.CreateSettings {
 	set := processortest.NewNopCreateSettings()
@@ -94,6 +146,163 @@ func (tts *TestTelemetry) CheckExporterTraces(sentSpans, sendFailedSpans int64)
 	return tts.otelPrometheusChecker.checkExporterTraces(tts.id, sentSpans, sendFailedSpans)
 }
 
+// CheckExporterTracesFailedByCode checks that for the current exported value for the spans that the
+// exporter failed to send with the given status code match given value. The statusCodeKey must be
+// either "http.status_code" or "rpc.grpc.status_code".
+func (tts *TestTelemetry) CheckExporterTracesFailedByCode(statusCodeKey, code string, sendFailedSpans int64) error {
+	return tts.otelPrometheusChecker.checkExporterTracesFailedByCode(tts.id, statusCodeKey, code, sendFailedSpans)
+}
+
+// CheckExporterTracesToDestination checks that for the current exported values for the spans
+// that the exporter sent, or failed to send, to the given destination match given values.
+func (tts *TestTelemetry) CheckExporterTracesToDestination(destination string, sentSpans, sendFailedSpans int64) error {
+	return tts.otelPrometheusChecker.checkExporterTracesToDestination(tts.id, destination, sentSpans, sendFailedSpans)
+}
+
+// CheckExporterConnectionState checks that for the current exported value of the connection state
+// gauge of the exporter for the given state, 1 if it is the current state or 0 otherwise, and of
+// the number of transitions to the given state match given values.
+func (tts *TestTelemetry) CheckExporterConnectionState(state string, value, transitions int64) error {
+	return tts.otelPrometheusChecker.checkExporterConnectionState(tts.id, state, value, transitions)
+}
+
+// CheckExporterBackpressure checks that for the current exported value of the backpressure gauge of
+// the exporter, 1 if it is under backpressure or 0 otherwise, and of the total time spent under
+// backpressure, in milliseconds, match given values.
+func (tts *TestTelemetry) CheckExporterBackpressure(value, durationMillis int64) error {
+	return tts.otelPrometheusChecker.checkExporterBackpressure(tts.id, value, durationMillis)
+}
+
+// CheckExporterSends checks that for the current exported value for the number of export operations
+// of the exporter with the given compression codec match given value.
+func (tts *TestTelemetry) CheckExporterSends(compression string, sends int64) error {
+	return tts.otelPrometheusChecker.checkExporterSends(tts.id, compression, sends)
+}
+
+// CheckExporterTracesRetriesExhausted checks that for the current exported value for the spans dropped
+// by the exporter after exhausting the retries match given value.
+func (tts *TestTelemetry) CheckExporterTracesRetriesExhausted(retriesExhaustedSpans int64) error {
+	return tts.otelPrometheusChecker.checkExporterRetriesExhausted(tts.id, "spans", retriesExhaustedSpans)
+}
+
+// CheckExporterMetricsRetriesExhausted checks that for the current exported value for the metric points
+// dropped by the exporter after exhausting the retries match given value.
+func (tts *TestTelemetry) CheckExporterMetricsRetriesExhausted(retriesExhaustedMetricPoints int64) error {
+	return tts.otelPrometheusChecker.checkExporterRetriesExhausted(tts.id, "metric_points", retriesExhaustedMetricPoints)
+}
+
+// CheckExporterLogsRetriesExhausted checks that for the current exported value for the log records
+// dropped by the exporter after exhausting the retries match given value.
+func (tts *TestTelemetry) CheckExporterLogsRetriesExhausted(retriesExhaustedLogRecords int64) error {
+	return tts.otelPrometheusChecker.checkExporterRetriesExhausted(tts.id, "log_records", retriesExhaustedLogRecords)
+}
+
+// CheckExporterTracesPartialWarnings checks that for the current exported value for the traces export
+// responses with a partial success warning match the given value.
+func (tts *TestTelemetry) CheckExporterTracesPartialWarnings(partialWarnings int64) error {
+	return tts.otelPrometheusChecker.checkExporterPartialWarnings(tts.id, "traces", partialWarnings)
+}
+
+// CheckExporterMetricsPartialWarnings checks that for the current exported value for the metrics export
+// responses with a partial success warning match the given value.
+func (tts *TestTelemetry) CheckExporterMetricsPartialWarnings(partialWarnings int64) error {
+	return tts.otelPrometheusChecker.checkExporterPartialWarnings(tts.id, "metrics", partialWarnings)
+}
+
+// CheckExporterLogsPartialWarnings checks that for the current exported value for the logs export
+// responses with a partial success warning match the given value.
+func (tts *TestTelemetry) CheckExporterLogsPartialWarnings(partialWarnings int64) error {
+	return tts.otelPrometheusChecker.checkExporterPartialWarnings(tts.id, "logs", partialWarnings)
+}
+
+// CheckExporterPersistentQueueSize checks that for the current exported value of the number of items
+// held in the persistent queue of the exporter and of its size in bytes match given values.
+func (tts *TestTelemetry) CheckExporterPersistentQueueSize(items, bytes int64) error {
+	return tts.otelPrometheusChecker.checkExporterPersistentQueueSize(tts.id, items, bytes)
+}
+
+// CheckCollectorInfo checks that the collector info metric is exported with the given
+// version and commit labels.
+func (tts *TestTelemetry) CheckCollectorInfo(version, commit string) error {
+	return tts.otelPrometheusChecker.checkCollectorInfo(version, commit)
+}
+
+// CheckPendingInternalTelemetry checks that the current exported value of the data points of
+// the internal metrics waiting to be exported match given value.
+func (tts *TestTelemetry) CheckPendingInternalTelemetry(dataPoints int64) error {
+	return tts.otelPrometheusChecker.checkPendingInternalTelemetry(dataPoints)
+}
+
+// CheckDroppedInternalTelemetry checks that the current exported value of the data points of
+// the internal metrics dropped before being exported match given value.
+func (tts *TestTelemetry) CheckDroppedInternalTelemetry(dataPoints int64) error {
+	return tts.otelPrometheusChecker.checkDroppedInternalTelemetry(dataPoints)
+}
+
+// CheckSaturation checks that for the current exported value of the saturation of the component
+// of the given kind, recorded with obsreport.Component.RecordSaturation, match given value.
+func (tts *TestTelemetry) CheckSaturation(kind component.Kind, saturation float64) error {
+	return tts.otelPrometheusChecker.checkSaturation(tts.id, kind, saturation)
+}
+
+// CheckConcurrency checks that for the current exported value of the concurrency of the component
+// of the given kind, recorded with obsreport.Component.RecordConcurrency, match given value.
+func (tts *TestTelemetry) CheckConcurrency(kind component.Kind, concurrency int64) error {
+	return tts.otelPrometheusChecker.checkConcurrency(tts.id, kind, concurrency)
+}
+
+// CheckExporterOldestQueuedAge checks that for the current exported value of the age of the oldest
+// item in the queue of the exporter, in milliseconds, matches given value.
+func (tts *TestTelemetry) CheckExporterOldestQueuedAge(age float64) error {
+	return tts.otelPrometheusChecker.checkExporterOldestQueuedAge(tts.id, age)
+}
+
+// CheckExporterAckLatency checks that for the current exported value of the distribution of the
+// acknowledgment latency of the exporter, the number of acknowledgments and their total latency,
+// in milliseconds, match given values.
+func (tts *TestTelemetry) CheckExporterAckLatency(acks uint64, sum float64) error {
+	return tts.otelPrometheusChecker.checkExporterAckLatency(tts.id, acks, sum)
+}
+
+// CheckExporterQueueWait checks that for the current exported value of the distribution of the time
+// the data waited in the queue of the exporter, the number of operations and the sum of the times
+// in milliseconds match given values.
+func (tts *TestTelemetry) CheckExporterQueueWait(operations uint64, sum float64) error {
+	return tts.otelPrometheusChecker.checkExporterQueueWait(tts.id, operations, sum)
+}
+
+// CheckPipelineLatency checks that for the current exported value of the distribution of the
+// pipeline latency recorded by the exporter, the number of export operations and their total
+// latency, in milliseconds, match given values.
+func (tts *TestTelemetry) CheckPipelineLatency(operations uint64, sum float64) error {
+	return tts.otelPrometheusChecker.checkPipelineLatency(tts.id, operations, sum)
+}
+
+// CheckExporterTLSErrors checks that for the current exported value of the number of TLS errors
+// of the exporter of the given kind match given value.
+func (tts *TestTelemetry) CheckExporterTLSErrors(kind string, tlsErrors int64) error {
+	return tts.otelPrometheusChecker.checkExporterTLSErrors(tts.id, kind, tlsErrors)
+}
+
+// CheckExporterRequests checks that for the current exported value of the number of export
+// requests with the given outcome, regardless of the number of items they held, match given value.
+func (tts *TestTelemetry) CheckExporterRequests(outcome string, requests int64) error {
+	return tts.otelPrometheusChecker.checkExporterRequests(tts.id, outcome, requests)
+}
+
+// CheckExporterSendDuration checks that for the current exported value of the distribution of the
+// duration of the export operations with the given outcome, the number of operations match given value.
+func (tts *TestTelemetry) CheckExporterSendDuration(outcome string, operations uint64) error {
+	return tts.otelPrometheusChecker.checkExporterSendDuration(tts.id, outcome, operations)
+}
+
+// CheckExporterBatchSizes checks that for the current exported value of the distribution of the number
+// of items in the batches of the given signal sent by the exporter, the number of batches and the total
+// number of items match given values.
+func (tts *TestTelemetry) CheckExporterBatchSizes(signal component.DataType, batches uint64, items int64) error {
+	return tts.otelPrometheusChecker.checkExporterBatchSizes(tts.id, signal, batches, items)
+}
+
 // CheckExporterMetrics checks that for the current exported values for metrics exporter metrics match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func (tts *TestTelemetry) CheckExporterMetrics(sentMetricsPoints, sendFailedMetricsPoints int64) error {
@@ -112,6 +321,12 @@ func (tts *TestTelemetry) CheckProcessorTraces(acceptedSpans, refusedSpans, drop
 	return tts.otelPrometheusChecker.checkProcessorTraces(tts.id, acceptedSpans, refusedSpans, droppedSpans)
 }
 
+// CheckProcessorTracesFrom checks that for the current exported value for the spans accepted by the processor
+// from the given source receiver match given value.
+func (tts *TestTelemetry) CheckProcessorTracesFrom(source component.ID, acceptedSpans int64) error {
+	return tts.otelPrometheusChecker.checkProcessorTracesFrom(tts.id, source, acceptedSpans)
+}
+
 // CheckProcessorMetrics checks that for the current exported values for metrics exporter metrics match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func (tts *TestTelemetry) CheckProcessorMetrics(acceptedMetricPoints, refusedMetricPoints, droppedMetricPoints int64) error {
@@ -124,12 +339,396 @@ func (tts *TestTelemetry) CheckProcessorLogs(acceptedLogRecords, refusedLogRecor
 	return tts.otelPrometheusChecker.checkProcessorLogs(tts.id, acceptedLogRecords, refusedLogRecords, droppedLogRecords)
 }
 
+// CheckProcessorTracesDeduplicated checks that for the current exported value for the spans deduplicated
+// by the processor match given value.
+func (tts *TestTelemetry) CheckProcessorTracesDeduplicated(deduplicatedSpans int64) error {
+	return tts.otelPrometheusChecker.checkProcessorDeduplicated(tts.id, "spans", deduplicatedSpans)
+}
+
+// CheckProcessorMetricsDeduplicated checks that for the current exported value for the metric points deduplicated
+// by the processor match given value.
+func (tts *TestTelemetry) CheckProcessorMetricsDeduplicated(deduplicatedMetricPoints int64) error {
+	return tts.otelPrometheusChecker.checkProcessorDeduplicated(tts.id, "metric_points", deduplicatedMetricPoints)
+}
+
+// CheckProcessorLogsDeduplicated checks that for the current exported value for the log records deduplicated
+// by the processor match given value.
+func (tts *TestTelemetry) CheckProcessorLogsDeduplicated(deduplicatedLogRecords int64) error {
+	return tts.otelPrometheusChecker.checkProcessorDeduplicated(tts.id, "log_records", deduplicatedLogRecords)
+}
+
+// CheckProcessorTracesPassed checks that for the current exported value for the spans passed through
+// by the processor match given value.
+func (tts *TestTelemetry) CheckProcessorTracesPassed(passedSpans int64) error {
+	return tts.otelPrometheusChecker.checkProcessorPassed(tts.id, "spans", passedSpans)
+}
+
+// CheckProcessorMetricsPassed checks that for the current exported value for the metric points passed through
+// by the processor match given value.
+func (tts *TestTelemetry) CheckProcessorMetricsPassed(passedMetricPoints int64) error {
+	return tts.otelPrometheusChecker.checkProcessorPassed(tts.id, "metric_points", passedMetricPoints)
+}
+
+// CheckProcessorLogsPassed checks that for the current exported value for the log records passed through
+// by the processor match given value.
+func (tts *TestTelemetry) CheckProcessorLogsPassed(passedLogRecords int64) error {
+	return tts.otelPrometheusChecker.checkProcessorPassed(tts.id, "log_records", passedLogRecords)
+}
+
+// CheckProcessorTracesBytesDropped checks that for the current exported value for the size in bytes
+// of the spans dropped by the processor match given value.
+func (tts *TestTelemetry) CheckProcessorTracesBytesDropped(droppedBytes int64) error {
+	return tts.otelPrometheusChecker.checkProcessorBytesDropped(tts.id, "spans", droppedBytes)
+}
+
+// CheckProcessorMetricsBytesDropped checks that for the current exported value for the size in bytes
+// of the metric points dropped by the processor match given value.
+func (tts *TestTelemetry) CheckProcessorMetricsBytesDropped(droppedBytes int64) error {
+	return tts.otelPrometheusChecker.checkProcessorBytesDropped(tts.id, "metric_points", droppedBytes)
+}
+
+// CheckProcessorLogsBytesDropped checks that for the current exported value for the size in bytes
+// of the log records dropped by the processor match given value.
+func (tts *TestTelemetry) CheckProcessorLogsBytesDropped(droppedBytes int64) error {
+	return tts.otelPrometheusChecker.checkProcessorBytesDropped(tts.id, "log_records", droppedBytes)
+}
+
+// CheckProcessorTracesMemoryLimited checks that for the current exported value for the spans refused
+// by the processor because the memory usage was above the limit match given value.
+func (tts *TestTelemetry) CheckProcessorTracesMemoryLimited(limitedSpans int64) error {
+	return tts.otelPrometheusChecker.checkProcessorMemoryLimited(tts.id, "spans", limitedSpans)
+}
+
+// CheckProcessorMetricsMemoryLimited checks that for the current exported value for the metric points refused
+// by the processor because the memory usage was above the limit match given value.
+func (tts *TestTelemetry) CheckProcessorMetricsMemoryLimited(limitedMetricPoints int64) error {
+	return tts.otelPrometheusChecker.checkProcessorMemoryLimited(tts.id, "metric_points", limitedMetricPoints)
+}
+
+// CheckProcessorLogsMemoryLimited checks that for the current exported value for the log records refused
+// by the processor because the memory usage was above the limit match given value.
+func (tts *TestTelemetry) CheckProcessorLogsMemoryLimited(limitedLogRecords int64) error {
+	return tts.otelPrometheusChecker.checkProcessorMemoryLimited(tts.id, "log_records", limitedLogRecords)
+}
+
+// CheckProcessorTracesProcessingErrors checks that for the current exported value for the spans the
+// processor failed to fully process but passed on match given value.
+func (tts *TestTelemetry) CheckProcessorTracesProcessingErrors(spans int64) error {
+	return tts.otelPrometheusChecker.checkProcessorProcessingErrors(tts.id, "spans", spans)
+}
+
+// CheckProcessorMetricsProcessingErrors checks that for the current exported value for the metric points
+// the processor failed to fully process but passed on match given value.
+func (tts *TestTelemetry) CheckProcessorMetricsProcessingErrors(metricPoints int64) error {
+	return tts.otelPrometheusChecker.checkProcessorProcessingErrors(tts.id, "metric_points", metricPoints)
+}
+
+// CheckProcessorLogsProcessingErrors checks that for the current exported value for the log records
+// the processor failed to fully process but passed on match given value.
+func (tts *TestTelemetry) CheckProcessorLogsProcessingErrors(logRecords int64) error {
+	return tts.otelPrometheusChecker.checkProcessorProcessingErrors(tts.id, "log_records", logRecords)
+}
+
+// CheckProcessorTracesOutOfOrder checks that for the current exported value for the spans the
+// processor received out of order match given value.
+func (tts *TestTelemetry) CheckProcessorTracesOutOfOrder(spans int64) error {
+	return tts.otelPrometheusChecker.checkProcessorOutOfOrder(tts.id, "spans", spans)
+}
+
+// CheckProcessorMetricsOutOfOrder checks that for the current exported value for the metric points
+// the processor received out of order match given value.
+func (tts *TestTelemetry) CheckProcessorMetricsOutOfOrder(metricPoints int64) error {
+	return tts.otelPrometheusChecker.checkProcessorOutOfOrder(tts.id, "metric_points", metricPoints)
+}
+
+// CheckProcessorLogsOutOfOrder checks that for the current exported value for the log records
+// the processor received out of order match given value.
+func (tts *TestTelemetry) CheckProcessorLogsOutOfOrder(logRecords int64) error {
+	return tts.otelPrometheusChecker.checkProcessorOutOfOrder(tts.id, "log_records", logRecords)
+}
+
+// CheckProcessorFlushReason checks that for the current exported value for the number of flushes of the
+// processor for the given reason match given value.
+func (tts *TestTelemetry) CheckProcessorFlushReason(reason string, flushes int64) error {
+	return tts.otelPrometheusChecker.checkProcessorFlushReason(tts.id, reason, flushes)
+}
+
+// CheckProcessorTimeoutFlushRatio checks that for the current exported value of the ratio of the flushes
+// of the processor triggered by the timeout over the last minute match given value.
+func (tts *TestTelemetry) CheckProcessorTimeoutFlushRatio(ratio float64) error {
+	return tts.otelPrometheusChecker.checkProcessorTimeoutFlushRatio(tts.id, ratio)
+}
+
+// CheckProcessorTracesDroppedForResource checks that for the current exported value for the number of
+// spans the processor dropped for the given resource key match given value.
+func (tts *TestTelemetry) CheckProcessorTracesDroppedForResource(resourceKey string, droppedSpans int64) error {
+	return tts.otelPrometheusChecker.checkProcessorTracesDroppedForResource(tts.id, resourceKey, droppedSpans)
+}
+
+// CheckProcessorTracesDroppedByRule checks that for the current exported value for the number of spans
+// the processor dropped by the given rule match given value.
+func (tts *TestTelemetry) CheckProcessorTracesDroppedByRule(ruleID string, droppedSpans int64) error {
+	return tts.otelPrometheusChecker.checkProcessorTracesDroppedByRule(tts.id, ruleID, droppedSpans)
+}
+
+// CheckProcessorTracesDroppedByPipeline checks that for the current exported value for the number of
+// spans the processor dropped in the given pipeline match given value.
+func (tts *TestTelemetry) CheckProcessorTracesDroppedByPipeline(pipeline string, droppedSpans int64) error {
+	return tts.otelPrometheusChecker.checkProcessorDroppedByPipeline(tts.id, "spans", pipeline, droppedSpans)
+}
+
+// CheckProcessorMetricsDroppedByPipeline checks that for the current exported value for the number of
+// metric points the processor dropped in the given pipeline match given value.
+func (tts *TestTelemetry) CheckProcessorMetricsDroppedByPipeline(pipeline string, droppedMetricPoints int64) error {
+	return tts.otelPrometheusChecker.checkProcessorDroppedByPipeline(tts.id, "metric_points", pipeline, droppedMetricPoints)
+}
+
+// CheckProcessorLogsDroppedByPipeline checks that for the current exported value for the number of
+// log records the processor dropped in the given pipeline match given value.
+func (tts *TestTelemetry) CheckProcessorLogsDroppedByPipeline(pipeline string, droppedLogRecords int64) error {
+	return tts.otelPrometheusChecker.checkProcessorDroppedByPipeline(tts.id, "log_records", pipeline, droppedLogRecords)
+}
+
+// CheckProcessorSampledSpans checks that for the current exported value for the number of spans the
+// processor took the given sampling decision on match given value.
+func (tts *TestTelemetry) CheckProcessorSampledSpans(decision string, sampledSpans int64) error {
+	return tts.otelPrometheusChecker.checkProcessorSampledSpans(tts.id, decision, sampledSpans)
+}
+
+// CheckProcessorTracesTransformDropped checks that for the current exported value for the spans the
+// processor dropped because it could not transform them for the given reason match given value.
+func (tts *TestTelemetry) CheckProcessorTracesTransformDropped(reason string, spans int64) error {
+	return tts.otelPrometheusChecker.checkProcessorTransformDropped(tts.id, "spans", reason, spans)
+}
+
+// CheckProcessorMetricsTransformDropped checks that for the current exported value for the metric points
+// the processor dropped because it could not transform them for the given reason match given value.
+func (tts *TestTelemetry) CheckProcessorMetricsTransformDropped(reason string, metricPoints int64) error {
+	return tts.otelPrometheusChecker.checkProcessorTransformDropped(tts.id, "metric_points", reason, metricPoints)
+}
+
+// CheckProcessorLogsTransformDropped checks that for the current exported value for the log records
+// the processor dropped because it could not transform them for the given reason match given value.
+func (tts *TestTelemetry) CheckProcessorLogsTransformDropped(reason string, logRecords int64) error {
+	return tts.otelPrometheusChecker.checkProcessorTransformDropped(tts.id, "log_records", reason, logRecords)
+}
+
+// CheckProcessorEnrichedItems checks that for the current exported value for the number of items
+// of the given signal the processor looked up to enrich them with the given outcome match given value.
+func (tts *TestTelemetry) CheckProcessorEnrichedItems(signal component.DataType, outcome string, items int64) error {
+	return tts.otelPrometheusChecker.checkProcessorEnrichedItems(tts.id, signal, outcome, items)
+}
+
+// CheckProcessorThresholdBreaches checks that for the current exported value for the number of items
+// of the given signal that breached the given threshold match given value.
+func (tts *TestTelemetry) CheckProcessorThresholdBreaches(signal component.DataType, threshold string, breaches int64) error {
+	return tts.otelPrometheusChecker.checkProcessorThresholdBreaches(tts.id, signal, threshold, breaches)
+}
+
+// CheckProcessorEffectiveSampleRatio checks that for the current exported value of the ratio of the
+// data of the given signal kept by the processor when sampling match given value.
+func (tts *TestTelemetry) CheckProcessorEffectiveSampleRatio(signal component.DataType, ratio float64) error {
+	return tts.otelPrometheusChecker.checkProcessorEffectiveSampleRatio(tts.id, signal, ratio)
+}
+
+// CheckProcessorQueueLatency checks that the current exported queue latency histogram for the
+// processor has the given number of measurements.
+func (tts *TestTelemetry) CheckProcessorQueueLatency(count uint64) error {
+	return tts.otelPrometheusChecker.checkProcessorQueueLatency(tts.id, count)
+}
+
+// CheckProcessorProcessingDuration checks that the current exported processing duration histogram
+// for the processor and the given signal has the given number of measurements and sum, in milliseconds.
+func (tts *TestTelemetry) CheckProcessorProcessingDuration(signal component.DataType, count uint64, sum float64) error {
+	return tts.otelPrometheusChecker.checkProcessorProcessingDuration(tts.id, signal, count, sum)
+}
+
+// CheckProcessorFanoutDegree checks that the current exported fan-out degree histogram for the
+// processor and the given signal has the given number of measurements and sum.
+func (tts *TestTelemetry) CheckProcessorFanoutDegree(signal component.DataType, count uint64, sum float64) error {
+	return tts.otelPrometheusChecker.checkProcessorFanoutDegree(tts.id, signal, count, sum)
+}
+
+// CheckProcessorBatchSplitFactor checks that the current exported batch split factor histogram for the
+// processor has the given number of measurements and sum.
+func (tts *TestTelemetry) CheckProcessorBatchSplitFactor(count uint64, sum float64) error {
+	return tts.otelPrometheusChecker.checkProcessorBatchSplitFactor(tts.id, count, sum)
+}
+
+// CheckProcessorAllocatedBytes checks that the current exported allocated bytes histogram for the
+// processor has the given number of measurements and that the allocated bytes are not negative.
+func (tts *TestTelemetry) CheckProcessorAllocatedBytes(count uint64) error {
+	return tts.otelPrometheusChecker.checkProcessorAllocatedBytes(tts.id, count)
+}
+
 // CheckReceiverTraces checks that for the current exported values for trace receiver metrics match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func (tts *TestTelemetry) CheckReceiverTraces(protocol string, acceptedSpans, droppedSpans int64) error {
 	return tts.otelPrometheusChecker.checkReceiverTraces(tts.id, protocol, acceptedSpans, droppedSpans)
 }
 
+// CheckReceiverTracesDetailed checks that for the current exported values for the span events and span links
+// receiver metrics match given values.
+func (tts *TestTelemetry) CheckReceiverTracesDetailed(protocol string, acceptedSpanEvents, refusedSpanEvents, acceptedSpanLinks, refusedSpanLinks int64) error {
+	return tts.otelPrometheusChecker.checkReceiverTracesDetailed(tts.id, protocol, acceptedSpanEvents, refusedSpanEvents, acceptedSpanLinks, refusedSpanLinks)
+}
+
+// CheckReceiverTracesStructure checks that for the current exported values for the resource and scope
+// groupings accepted by the receiver match given values.
+func (tts *TestTelemetry) CheckReceiverTracesStructure(protocol string, acceptedResources, acceptedScopes int64) error {
+	return tts.otelPrometheusChecker.checkReceiverTracesStructure(tts.id, protocol, acceptedResources, acceptedScopes)
+}
+
+// CheckReceiverLogsWeight checks that for the current exported value for the size in bytes of the log
+// records accepted by the receiver match given value.
+func (tts *TestTelemetry) CheckReceiverLogsWeight(protocol string, acceptedLogRecordBytes int64) error {
+	return tts.otelPrometheusChecker.checkReceiverLogsWeight(tts.id, protocol, acceptedLogRecordBytes)
+}
+
+// CheckReceiverSchemaMismatches checks that for the current exported value for the number of times the
+// receiver got data with an unexpected or missing schema URL match given value.
+func (tts *TestTelemetry) CheckReceiverSchemaMismatches(protocol string, schemaMismatches int64) error {
+	return tts.otelPrometheusChecker.checkReceiverSchemaMismatches(tts.id, protocol, schemaMismatches)
+}
+
+// CheckReceiverValidationErrors checks that for the current exported value for the number of times
+// the receiver rejected data because the given field failed validation match given value.
+func (tts *TestTelemetry) CheckReceiverValidationErrors(protocol, field string, validationErrors int64) error {
+	return tts.otelPrometheusChecker.checkReceiverValidationErrors(tts.id, protocol, field, validationErrors)
+}
+
+// CheckReceiverStreamCloses checks that for the current exported value for the number of client
+// streams closed by the receiver for the given reason match given value.
+func (tts *TestTelemetry) CheckReceiverStreamCloses(protocol, reason string, streamCloses int64) error {
+	return tts.otelPrometheusChecker.checkReceiverStreamCloses(tts.id, protocol, reason, streamCloses)
+}
+
+// CheckReceiverKeepalives checks that for the current exported value for the number of keepalive
+// pings exchanged by the receiver match given value.
+func (tts *TestTelemetry) CheckReceiverKeepalives(protocol string, keepalives int64) error {
+	return tts.otelPrometheusChecker.checkReceiverKeepalives(tts.id, protocol, keepalives)
+}
+
+// CheckReceiverAuthFailures checks that for the current exported value for the number of requests
+// rejected by the receiver because they failed authentication match given value.
+func (tts *TestTelemetry) CheckReceiverAuthFailures(protocol string, authFailures int64) error {
+	return tts.otelPrometheusChecker.checkReceiverAuthFailures(tts.id, protocol, authFailures)
+}
+
+// CheckReceiverReadErrors checks that for the current exported value for the number of requests
+// whose body the receiver failed to read match given value.
+func (tts *TestTelemetry) CheckReceiverReadErrors(protocol string, readErrors int64) error {
+	return tts.otelPrometheusChecker.checkReceiverReadErrors(tts.id, protocol, readErrors)
+}
+
+// CheckReceiverConnections checks that for the current exported values for the number of new and
+// reused connections accepted by the receiver, and of the connections currently open, match given values.
+func (tts *TestTelemetry) CheckReceiverConnections(protocol string, newConnections, reusedConnections, activeConnections int64) error {
+	return tts.otelPrometheusChecker.checkReceiverConnections(tts.id, protocol, newConnections, reusedConnections, activeConnections)
+}
+
+// CheckReceiverDistinctResources checks that for the current exported value of the estimated number
+// of distinct resources received by the receiver match given value.
+func (tts *TestTelemetry) CheckReceiverDistinctResources(protocol string, estimate int64) error {
+	return tts.otelPrometheusChecker.checkReceiverDistinctResources(tts.id, protocol, estimate)
+}
+
+// CheckReceiverItemsPerCore checks that for the current exported value of the items of the signal
+// accepted by the receiver divided by the number of cores match given value.
+func (tts *TestTelemetry) CheckReceiverItemsPerCore(protocol string, signal component.DataType, itemsPerCore float64) error {
+	return tts.otelPrometheusChecker.checkReceiverItemsPerCore(tts.id, protocol, signal, itemsPerCore)
+}
+
+// CheckReceiverEmptyBatches checks that for the current exported value for the number of receive
+// operations that accepted no items match given value.
+func (tts *TestTelemetry) CheckReceiverEmptyBatches(protocol string, emptyBatches int64) error {
+	return tts.otelPrometheusChecker.checkReceiverEmptyBatches(tts.id, protocol, emptyBatches)
+}
+
+// CheckReceiverAttributesPerSpan checks that the current exported histogram of the average number of
+// attributes per span for the receiver has the given number of measurements with the given sum.
+func (tts *TestTelemetry) CheckReceiverAttributesPerSpan(protocol string, count uint64, sum float64) error {
+	return tts.otelPrometheusChecker.checkReceiverAttributesPerSpan(tts.id, protocol, count, sum)
+}
+
+// CheckReceiverDeadlineRemaining checks that the current exported deadline remaining histogram for the
+// receiver has the given number of measurements and sum, in milliseconds.
+func (tts *TestTelemetry) CheckReceiverDeadlineRemaining(protocol string, count uint64, sum float64) error {
+	return tts.otelPrometheusChecker.checkReceiverDeadlineRemaining(tts.id, protocol, count, sum)
+}
+
+// CheckReceiverDistinctTraces checks that for the current exported value of the estimated number
+// of distinct traces received by the receiver match given value.
+func (tts *TestTelemetry) CheckReceiverDistinctTraces(protocol string, estimate int64) error {
+	return tts.otelPrometheusChecker.checkReceiverDistinctTraces(tts.id, protocol, estimate)
+}
+
+// CheckReceiverFirstByteLatency checks that the current exported first byte latency histogram for the
+// receiver has the given number of measurements.
+func (tts *TestTelemetry) CheckReceiverFirstByteLatency(protocol string, count uint64) error {
+	return tts.otelPrometheusChecker.checkReceiverFirstByteLatency(tts.id, protocol, count)
+}
+
+// CheckReceiverParseDuration checks that the current exported parse duration histogram for the
+// receiver and the given format has the given number of measurements with the given sum, in milliseconds.
+func (tts *TestTelemetry) CheckReceiverParseDuration(protocol, format string, count uint64, sum float64) error {
+	return tts.otelPrometheusChecker.checkReceiverParseDuration(tts.id, protocol, format, count, sum)
+}
+
+// CheckReceiverTracesBySampled checks that for the current exported values for the spans accepted by the
+// receiver with and without the sampled flag match given values.
+func (tts *TestTelemetry) CheckReceiverTracesBySampled(protocol string, sampledSpans, unsampledSpans int64) error {
+	return tts.otelPrometheusChecker.checkReceiverTracesBySampled(tts.id, protocol, sampledSpans, unsampledSpans)
+}
+
+// CheckReceiverTracesBySkew checks that for the current exported value for the spans accepted by the receiver
+// within the given clock skew range match given value.
+func (tts *TestTelemetry) CheckReceiverTracesBySkew(protocol, skew string, acceptedSpans int64) error {
+	return tts.otelPrometheusChecker.checkReceiverTracesBySkew(tts.id, protocol, skew, acceptedSpans)
+}
+
+// CheckReceiverTracesConverted checks that for the current exported value for the spans accepted by the
+// receiver after being converted from fromFormat to toFormat match given value.
+func (tts *TestTelemetry) CheckReceiverTracesConverted(protocol, fromFormat, toFormat string, convertedSpans int64) error {
+	return tts.otelPrometheusChecker.checkReceiverTracesConverted(tts.id, protocol, fromFormat, toFormat, convertedSpans)
+}
+
+// CheckReceiverTracesByProtoVersion checks that for the current exported value for the spans
+// accepted by the receiver for the given protocol schema version match given value.
+func (tts *TestTelemetry) CheckReceiverTracesByProtoVersion(protocol, version string, acceptedSpans int64) error {
+	return tts.otelPrometheusChecker.checkReceiverTracesByProtoVersion(tts.id, protocol, version, acceptedSpans)
+}
+
+// CheckReceiverTracesRefusedByHTTPStatus checks that for the current exported value for the spans
+// refused by the receiver with the given HTTP status code match given value.
+func (tts *TestTelemetry) CheckReceiverTracesRefusedByHTTPStatus(protocol string, httpStatus int, refusedSpans int64) error {
+	return tts.otelPrometheusChecker.checkReceiverTracesRefusedByHTTPStatus(tts.id, protocol, httpStatus, refusedSpans)
+}
+
+// CheckReceiverTracesForTenant checks that for the current exported values for the spans accepted and
+// refused by the receiver for the given tenant match given values.
+func (tts *TestTelemetry) CheckReceiverTracesForTenant(protocol, tenant string, acceptedSpans, droppedSpans int64) error {
+	return tts.otelPrometheusChecker.checkReceiverTracesForTenant(tts.id, protocol, tenant, acceptedSpans, droppedSpans)
+}
+
+// CheckReceiverTracesByVolume checks that for the current exported value for the spans accepted by
+// the receiver for the given value of the volume resource attribute match given value.
+func (tts *TestTelemetry) CheckReceiverTracesByVolume(protocol, volume string, acceptedSpans int64) error {
+	return tts.otelPrometheusChecker.checkReceiverByVolume(tts.id, protocol, "spans", volume, acceptedSpans)
+}
+
+// CheckReceiverMetricsByVolume checks that for the current exported value for the metric points accepted
+// by the receiver for the given value of the volume resource attribute match given value.
+func (tts *TestTelemetry) CheckReceiverMetricsByVolume(protocol, volume string, acceptedMetricPoints int64) error {
+	return tts.otelPrometheusChecker.checkReceiverByVolume(tts.id, protocol, "metric_points", volume, acceptedMetricPoints)
+}
+
+// CheckReceiverLogsByVolume checks that for the current exported value for the log records accepted by
+// the receiver for the given value of the volume resource attribute match given value.
+func (tts *TestTelemetry) CheckReceiverLogsByVolume(protocol, volume string, acceptedLogRecords int64) error {
+	return tts.otelPrometheusChecker.checkReceiverByVolume(tts.id, protocol, "log_records", volume, acceptedLogRecords)
+}
+
 // CheckReceiverLogs checks that for the current exported values for logs receiver metrics match given values.
 // When this function is called it is required to also call SetupTelemetry as first thing.
 func (tts *TestTelemetry) CheckReceiverLogs(protocol string, acceptedLogRecords, droppedLogRecords int64) error {
@@ -142,60 +741,148 @@ func (tts *TestTelemetry) CheckReceiverMetrics(protocol string, acceptedMetricPo
 	return tts.otelPrometheusChecker.checkReceiverMetrics(tts.id, protocol, acceptedMetricPoints, droppedMetricPoints)
 }
 
-// Shutdown unregisters any views and shuts down the SpanRecorder
-func (tts *TestTelemetry) Shutdown(ctx context.Context) error {
-	view.Unregister(tts.views...)
-	view.UnregisterExporter(tts.ocExporter)
-	var errs error
-	errs = multierr.Append(errs, tts.SpanRecorder.Shutdown(ctx))
-	if tts.meterProvider != nil {
-		errs = multierr.Append(errs, tts.meterProvider.Shutdown(ctx))
-	}
-	return errs
+// CheckConnectorTraces checks that for the current exported values for trace connector metrics match given values.
+func (tts *TestTelemetry) CheckConnectorTraces(acceptedSpans, refusedSpans, sentSpans, sendFailedSpans int64) error {
+	return tts.otelPrometheusChecker.checkConnectorTraces(tts.id, acceptedSpans, refusedSpans, sentSpans, sendFailedSpans)
 }
 
-// SetupTelemetry does setup the testing environment to check the metrics recorded by receivers, producers or exporters.
-// The caller must pass the ID of the component that intends to test, so the CreateSettings and Check methods will use.
-// The caller should defer a call to Shutdown the returned TestTelemetry.
-func SetupTelemetry(id component.ID) (TestTelemetry, error) {
+// CheckConnectorTracesDetailed checks that for the current exported values for the span events and span links
+// emitted by the connector match given values.
+func (tts *TestTelemetry) CheckConnectorTracesDetailed(acceptedSpanEvents, refusedSpanEvents, acceptedSpanLinks, refusedSpanLinks int64) error {
+	return tts.otelPrometheusChecker.checkConnectorTracesDetailed(tts.id, acceptedSpanEvents, refusedSpanEvents, acceptedSpanLinks, refusedSpanLinks)
+}
+
+// CheckConnectorTracesBySkew checks that for the current exported value for the spans emitted by the connector
+// within the given clock skew range match given value.
+func (tts *TestTelemetry) CheckConnectorTracesBySkew(skew string, acceptedSpans int64) error {
+	return tts.otelPrometheusChecker.checkConnectorTracesBySkew(tts.id, skew, acceptedSpans)
+}
+
+// CheckConnectorTracesRerouted checks that for the current exported value for the spans rerouted
+// by the connector from the given pipeline to the given fallback pipeline match given value.
+func (tts *TestTelemetry) CheckConnectorTracesRerouted(fromPipeline, toPipeline string, reroutedSpans int64) error {
+	return tts.otelPrometheusChecker.checkConnectorRerouted(tts.id, "spans", fromPipeline, toPipeline, reroutedSpans)
+}
+
+// CheckConnectorMetricsRerouted checks that for the current exported value for the metric points
+// rerouted by the connector from the given pipeline to the given fallback pipeline match given value.
+func (tts *TestTelemetry) CheckConnectorMetricsRerouted(fromPipeline, toPipeline string, reroutedMetricPoints int64) error {
+	return tts.otelPrometheusChecker.checkConnectorRerouted(tts.id, "metric_points", fromPipeline, toPipeline, reroutedMetricPoints)
+}
+
+// CheckConnectorLogsRerouted checks that for the current exported value for the log records
+// rerouted by the connector from the given pipeline to the given fallback pipeline match given value.
+func (tts *TestTelemetry) CheckConnectorLogsRerouted(fromPipeline, toPipeline string, reroutedLogRecords int64) error {
+	return tts.otelPrometheusChecker.checkConnectorRerouted(tts.id, "log_records", fromPipeline, toPipeline, reroutedLogRecords)
+}
+
+// CheckConnectorMetrics checks that for the current exported values for metrics connector metrics match given values.
+func (tts *TestTelemetry) CheckConnectorMetrics(acceptedMetricPoints, refusedMetricPoints, sentMetricPoints, sendFailedMetricPoints int64) error {
+	return tts.otelPrometheusChecker.checkConnectorMetrics(tts.id, acceptedMetricPoints, refusedMetricPoints, sentMetricPoints, sendFailedMetricPoints)
+}
+
+// CheckConnectorLogs checks that for the current exported values for logs connector metrics match given values.
+func (tts *TestTelemetry) CheckConnectorLogs(acceptedLogRecords, refusedLogRecords, sentLogRecords, sendFailedLogRecords int64) error {
+	return tts.otelPrometheusChecker.checkConnectorLogs(tts.id, acceptedLogRecords, refusedLogRecords, sentLogRecords, sendFailedLogRecords)
+}
+
+// Reset clears the recorded spans and metrics, so that each case of a table-driven test can
+// check its own values instead of the values accumulated by all the previous cases.
+// The components must be created again after calling Reset, from the settings returned by
+// the same TestTelemetry: the metrics recorded using OpenTelemetry by the components created
+// before are not reported anymore. Likewise, the copies of the TestTelemetry made before
+// calling Reset keep the previous SpanRecorder and MeterProvider, only their Check methods
+// see the new metrics.
+func (tts *TestTelemetry) Reset(ctx context.Context) error {
 	sr := new(tracetest.SpanRecorder)
-	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	tts.tracerProvider.RegisterSpanProcessor(sr)
+	tts.tracerProvider.UnregisterSpanProcessor(tts.SpanRecorder)
+	tts.SpanRecorder = sr
 
-	settings := TestTelemetry{
-		TelemetrySettings: componenttest.NewNopTelemetrySettings(),
-		id:                id,
-		SpanRecorder:      sr,
+	if err := tts.shutdownMetrics(ctx); err != nil {
+		return err
 	}
-	settings.TelemetrySettings.TracerProvider = tp
-	settings.TelemetrySettings.MetricsLevel = configtelemetry.LevelNormal
-	settings.views = obsreportconfig.AllViews(configtelemetry.LevelNormal)
-	err := view.Register(settings.views...)
+	return tts.setupMetrics()
+}
+
+// Shutdown unregisters any views and shuts down the MeterProvider and the SpanRecorder,
+// including the ones created by Reset on any copy of the TestTelemetry. It can be called
+// more than once.
+func (tts *TestTelemetry) Shutdown(ctx context.Context) error {
+	var errs error
+	errs = multierr.Append(errs, tts.shutdownMetrics(ctx))
+	errs = multierr.Append(errs, tts.tracerProvider.Shutdown(ctx))
+	return errs
+}
+
+// setupMetrics registers the views and creates a MeterProvider, both reporting to a new Prometheus registry.
+func (tts *TestTelemetry) setupMetrics() error {
+	tts.views = obsreportconfig.AllViews(configtelemetry.LevelNormal)
+	err := view.Register(tts.views...)
 	if err != nil {
-		return settings, err
+		return err
 	}
 
 	promReg := prometheus.NewRegistry()
 
-	settings.ocExporter, err = ocprom.NewExporter(ocprom.Options{Registry: promReg})
+	tts.ocExporter, err = ocprom.NewExporter(ocprom.Options{Registry: promReg})
 	if err != nil {
-		return settings, err
+		return err
 	}
-	view.RegisterExporter(settings.ocExporter)
+	view.RegisterExporter(tts.ocExporter)
 
 	exp, err := otelprom.New(otelprom.WithRegisterer(promReg), otelprom.WithoutUnits(), otelprom.WithoutScopeInfo())
 	if err != nil {
-		return settings, err
+		return err
 	}
 
-	settings.meterProvider = sdkmetric.NewMeterProvider(
+	tts.meterProvider = sdkmetric.NewMeterProvider(
 		sdkmetric.WithResource(resource.Empty()),
 		sdkmetric.WithReader(exp),
 	)
-	settings.TelemetrySettings.MeterProvider = settings.meterProvider
+	tts.TelemetrySettings.MeterProvider = tts.meterProvider
 
-	settings.otelPrometheusChecker = &prometheusChecker{promHandler: settings.ocExporter}
+	tts.otelPrometheusChecker = &prometheusChecker{promHandler: tts.ocExporter}
 
-	return settings, nil
+	return nil
+}
+
+// shutdownMetrics unregisters the views and shuts down the MeterProvider created by setupMetrics,
+// if they were not already.
+func (tts *TestTelemetry) shutdownMetrics(ctx context.Context) error {
+	view.Unregister(tts.views...)
+	tts.views = nil
+	if tts.ocExporter != nil {
+		view.UnregisterExporter(tts.ocExporter)
+		tts.ocExporter = nil
+	}
+	if tts.meterProvider != nil {
+		mp := tts.meterProvider
+		tts.meterProvider = nil
+		return mp.Shutdown(ctx)
+	}
+	return nil
+}
+
+// SetupTelemetry does setup the testing environment to check the metrics recorded by receivers, producers or exporters.
+// The caller must pass the ID of the component that intends to test, so the CreateSettings and Check methods will use.
+// The caller should defer a call to Shutdown the returned TestTelemetry.
+func SetupTelemetry(id component.ID) (TestTelemetry, error) {
+	sr := new(tracetest.SpanRecorder)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	settings := TestTelemetry{
+		TelemetrySettings: componenttest.NewNopTelemetrySettings(),
+		id:                id,
+		SpanRecorder:      sr,
+		tracerProvider:    tp,
+		testMetrics:       &testMetrics{},
+	}
+	settings.TelemetrySettings.TracerProvider = tp
+	settings.TelemetrySettings.MetricsLevel = configtelemetry.LevelNormal
+
+	err := settings.setupMetrics()
+	return settings, err
 }
 
 // CheckScraperMetrics checks that for the current exported values for metrics scraper metrics match given values.
@@ -203,3 +890,38 @@ func SetupTelemetry(id component.ID) (TestTelemetry, error) {
 func CheckScraperMetrics(tts TestTelemetry, receiver component.ID, scraper component.ID, scrapedMetricPoints, erroredMetricPoints int64) error {
 	return tts.otelPrometheusChecker.checkScraperMetrics(receiver, scraper, scrapedMetricPoints, erroredMetricPoints)
 }
+
+// CheckScraperCache checks that for the current exported values for the cache hits and misses of the scraper
+// match given values.
+func CheckScraperCache(tts TestTelemetry, receiver component.ID, scraper component.ID, cacheHits, cacheMisses int64) error {
+	return tts.otelPrometheusChecker.checkScraperCache(receiver, scraper, cacheHits, cacheMisses)
+}
+
+// CheckScraperMetricScrapes checks that for the current exported values for the successful and failed
+// scrapes of the given metric name of the scraper match given values.
+func CheckScraperMetricScrapes(tts TestTelemetry, receiver component.ID, scraper component.ID, metricName string, scrapes, scrapeErrors int64) error {
+	return tts.otelPrometheusChecker.checkScraperMetricScrapes(receiver, scraper, metricName, scrapes, scrapeErrors)
+}
+
+// CheckReceiverTracesEventually is like CheckReceiverTraces for the given receiver, but retries the check
+// until the exported values match the given values or the timeout expires. It is meant for tests where
+// the metrics are recorded asynchronously, the returned error contains the result of the last attempt.
+func CheckReceiverTracesEventually(tts TestTelemetry, receiver component.ID, protocol string, acceptedSpans, droppedSpans int64, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := tts.otelPrometheusChecker.checkReceiverTraces(receiver, protocol, acceptedSpans, droppedSpans)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("receiver trace metrics did not match after %v: %w", timeout, err)
+		}
+		time.Sleep(checkEventuallyInterval)
+	}
+}
+
+// CheckNoMetrics checks that no obsreport metrics were recorded, for example when the MetricsLevel
+// of the TestTelemetry is set to configtelemetry.LevelNone.
+func CheckNoMetrics(tts TestTelemetry) error {
+	return tts.otelPrometheusChecker.checkNoMetrics()
+}
@@ -17,11 +17,15 @@ package obsreporttest_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 )
@@ -80,6 +84,34 @@ func TestCheckReceiverTracesViews(t *testing.T) {
 	assert.Error(t, tt.CheckReceiverTraces(transport, 0, 7))
 }
 
+func TestCheckReceiverTracesEventually(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(receiver)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	rec, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             receiver,
+		Transport:              transport,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		time.Sleep(50 * time.Millisecond)
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 7, nil)
+	}()
+
+	assert.NoError(t, obsreporttest.CheckReceiverTracesEventually(tt, receiver, transport, 7, 0, 5*time.Second))
+	<-done
+
+	err = obsreporttest.CheckReceiverTracesEventually(tt, receiver, transport, 7, 7, 50*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did not match after 50ms")
+}
+
 func TestCheckReceiverMetricsViews(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetry(receiver)
 	require.NoError(t, err)
@@ -256,3 +288,88 @@ func TestCheckExporterLogsViews(t *testing.T) {
 	assert.Error(t, tt.CheckExporterLogs(0, 0))
 	assert.Error(t, tt.CheckExporterLogs(0, 7))
 }
+
+func TestCheckNoMetrics(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(receiver)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	assert.NoError(t, obsreporttest.CheckNoMetrics(tt))
+
+	set := tt.ToReceiverCreateSettings()
+	set.MetricsLevel = configtelemetry.LevelNone
+	rec, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             receiver,
+		Transport:              transport,
+		ReceiverCreateSettings: set,
+	})
+	require.NoError(t, err)
+	ctx := rec.StartTracesOp(context.Background())
+	rec.EndTracesOp(ctx, format, 7, nil)
+
+	assert.NoError(t, obsreporttest.CheckNoMetrics(tt))
+
+	rec, err = obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             receiver,
+		Transport:              transport,
+		ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+	})
+	require.NoError(t, err)
+	ctx = rec.StartTracesOp(context.Background())
+	rec.EndTracesOp(ctx, format, 7, nil)
+
+	assert.Error(t, obsreporttest.CheckNoMetrics(tt))
+}
+
+func TestCheckNoMetricsCollectorInfo(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(receiver)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	assert.NoError(t, obsreporttest.CheckNoMetrics(tt))
+
+	// The metrics that are not about a component are also checked.
+	require.NoError(t, obsreport.RecordBuildInfo(tt.TelemetrySettings, "v0.75.0", "3a9f1c2", ""))
+	assert.Error(t, obsreporttest.CheckNoMetrics(tt))
+}
+
+func TestReset(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(receiver)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	for _, numSpans := range []int{7, 3} {
+		require.NoError(t, tt.Reset(context.Background()))
+		assert.Empty(t, tt.SpanRecorder.Ended())
+
+		rec, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             receiver,
+			Transport:              transport,
+			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+		})
+		require.NoError(t, err)
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, numSpans, nil)
+
+		assert.Len(t, tt.SpanRecorder.Ended(), 1)
+		assert.NoError(t, tt.CheckReceiverTraces(transport, int64(numSpans), 0))
+	}
+}
+
+func TestShutdownAfterResetOnCopy(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(receiver)
+	require.NoError(t, err)
+
+	cp := tt
+	require.NoError(t, cp.Reset(context.Background()))
+	require.NoError(t, tt.Shutdown(context.Background()))
+	// Shutting down again, from any copy, is a no-op.
+	require.NoError(t, cp.Shutdown(context.Background()))
+	require.NoError(t, tt.Shutdown(context.Background()))
+
+	// The SpanRecorder and the views set up by the copy were shut down too.
+	_, span := cp.TracerProvider.Tracer("test").Start(context.Background(), "op")
+	span.End()
+	assert.Empty(t, cp.SpanRecorder.Ended())
+	assert.Nil(t, view.Find(obsmetrics.ReceiverAcceptedSpans.Name()))
+}
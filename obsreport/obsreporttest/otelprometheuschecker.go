@@ -19,6 +19,8 @@ import (
 	"math"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 
 	io_prometheus_client "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
@@ -27,6 +29,7 @@ import (
 	"go.uber.org/multierr"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
 // prometheusChecker is used to assert exported metrics from a prometheus handler.
@@ -41,6 +44,20 @@ func (pc *prometheusChecker) checkScraperMetrics(receiver component.ID, scraper
 		pc.checkCounter("scraper_errored_metric_points", erroredMetricPoints, scraperAttrs))
 }
 
+func (pc *prometheusChecker) checkScraperCache(receiver component.ID, scraper component.ID, cacheHits, cacheMisses int64) error {
+	scraperAttrs := attributesForScraperMetrics(receiver, scraper)
+	return multierr.Combine(
+		pc.checkCounter("scraper_cache_hits", cacheHits, scraperAttrs),
+		pc.checkCounter("scraper_cache_misses", cacheMisses, scraperAttrs))
+}
+
+func (pc *prometheusChecker) checkScraperMetricScrapes(receiver component.ID, scraper component.ID, metricName string, scrapes, scrapeErrors int64) error {
+	scraperAttrs := append(attributesForScraperMetrics(receiver, scraper), attribute.String(metricNameTag, metricName))
+	return multierr.Combine(
+		pc.checkCounter("scraper_metric_scrapes", scrapes, scraperAttrs),
+		pc.checkCounter("scraper_metric_scrape_errors", scrapeErrors, scraperAttrs))
+}
+
 func (pc *prometheusChecker) checkReceiverTraces(receiver component.ID, protocol string, acceptedSpans, droppedSpans int64) error {
 	receiverAttrs := attributesForReceiverMetrics(receiver, protocol)
 	return multierr.Combine(
@@ -48,6 +65,135 @@ func (pc *prometheusChecker) checkReceiverTraces(receiver component.ID, protocol
 		pc.checkCounter("receiver_refused_spans", droppedSpans, receiverAttrs))
 }
 
+func (pc *prometheusChecker) checkReceiverTracesDetailed(receiver component.ID, protocol string, acceptedSpanEvents, refusedSpanEvents, acceptedSpanLinks, refusedSpanLinks int64) error {
+	receiverAttrs := attributesForReceiverMetrics(receiver, protocol)
+	return multierr.Combine(
+		pc.checkCounter("receiver_accepted_span_events", acceptedSpanEvents, receiverAttrs),
+		pc.checkCounter("receiver_refused_span_events", refusedSpanEvents, receiverAttrs),
+		pc.checkCounter("receiver_accepted_span_links", acceptedSpanLinks, receiverAttrs),
+		pc.checkCounter("receiver_refused_span_links", refusedSpanLinks, receiverAttrs))
+}
+
+func (pc *prometheusChecker) checkReceiverTracesStructure(receiver component.ID, protocol string, acceptedResources, acceptedScopes int64) error {
+	receiverAttrs := attributesForReceiverMetrics(receiver, protocol)
+	return multierr.Combine(
+		pc.checkCounter("receiver_accepted_resources", acceptedResources, receiverAttrs),
+		pc.checkCounter("receiver_accepted_scopes", acceptedScopes, receiverAttrs))
+}
+
+func (pc *prometheusChecker) checkReceiverLogsWeight(receiver component.ID, protocol string, acceptedLogRecordBytes int64) error {
+	return pc.checkCounter("receiver_accepted_log_record_bytes", acceptedLogRecordBytes, attributesForReceiverMetrics(receiver, protocol))
+}
+
+func (pc *prometheusChecker) checkReceiverSchemaMismatches(receiver component.ID, protocol string, schemaMismatches int64) error {
+	return pc.checkCounter("receiver_schema_mismatches", schemaMismatches, attributesForReceiverMetrics(receiver, protocol))
+}
+
+func (pc *prometheusChecker) checkReceiverValidationErrors(receiver component.ID, protocol, field string, validationErrors int64) error {
+	receiverAttrs := append(attributesForReceiverMetrics(receiver, protocol), attribute.String(fieldTag, field))
+	return pc.checkCounter("receiver_validation_errors", validationErrors, receiverAttrs)
+}
+
+func (pc *prometheusChecker) checkReceiverStreamCloses(receiver component.ID, protocol, reason string, streamCloses int64) error {
+	receiverAttrs := append(attributesForReceiverMetrics(receiver, protocol), attribute.String(reasonTag, reason))
+	return pc.checkCounter("receiver_stream_closes", streamCloses, receiverAttrs)
+}
+
+func (pc *prometheusChecker) checkReceiverKeepalives(receiver component.ID, protocol string, keepalives int64) error {
+	return pc.checkCounter("receiver_keepalives", keepalives, attributesForReceiverMetrics(receiver, protocol))
+}
+
+func (pc *prometheusChecker) checkReceiverAuthFailures(receiver component.ID, protocol string, authFailures int64) error {
+	return pc.checkCounter("receiver_auth_failures", authFailures, attributesForReceiverMetrics(receiver, protocol))
+}
+
+func (pc *prometheusChecker) checkReceiverReadErrors(receiver component.ID, protocol string, readErrors int64) error {
+	return pc.checkCounter("receiver_read_errors", readErrors, attributesForReceiverMetrics(receiver, protocol))
+}
+
+func (pc *prometheusChecker) checkReceiverConnections(receiver component.ID, protocol string, newConnections, reusedConnections, activeConnections int64) error {
+	receiverAttrs := attributesForReceiverMetrics(receiver, protocol)
+	return multierr.Combine(
+		pc.checkCounter("receiver_connections", newConnections, append(receiverAttrs, attribute.String(connectionTag, "new"))),
+		pc.checkCounter("receiver_connections", reusedConnections, append(receiverAttrs, attribute.String(connectionTag, "reused"))),
+		pc.checkGauge("receiver_active_connections", activeConnections, receiverAttrs))
+}
+
+func (pc *prometheusChecker) checkReceiverDistinctResources(receiver component.ID, protocol string, estimate int64) error {
+	return pc.checkGauge("receiver_distinct_resources_estimate", estimate, attributesForReceiverMetrics(receiver, protocol))
+}
+
+func (pc *prometheusChecker) checkReceiverDistinctTraces(receiver component.ID, protocol string, estimate int64) error {
+	return pc.checkGauge("receiver_distinct_traces_estimate", estimate, attributesForReceiverMetrics(receiver, protocol))
+}
+
+func (pc *prometheusChecker) checkReceiverItemsPerCore(receiver component.ID, protocol string, signal component.DataType, itemsPerCore float64) error {
+	receiverAttrs := attributesForReceiverMetrics(receiver, protocol)
+	return pc.checkFloatGauge("receiver_items_per_core", itemsPerCore, append(receiverAttrs, attribute.String(signalTag, string(signal))))
+}
+
+func (pc *prometheusChecker) checkReceiverEmptyBatches(receiver component.ID, protocol string, emptyBatches int64) error {
+	return pc.checkCounter("receiver_empty_batches", emptyBatches, attributesForReceiverMetrics(receiver, protocol))
+}
+
+func (pc *prometheusChecker) checkReceiverAttributesPerSpan(receiver component.ID, protocol string, count uint64, sum float64) error {
+	return pc.checkHistogram("receiver_attributes_per_span", count, sum, attributesForReceiverMetrics(receiver, protocol))
+}
+
+func (pc *prometheusChecker) checkReceiverFirstByteLatency(receiver component.ID, protocol string, count uint64) error {
+	return pc.checkHistogramCount("receiver_first_byte_latency", count, attributesForReceiverMetrics(receiver, protocol))
+}
+
+func (pc *prometheusChecker) checkReceiverDeadlineRemaining(receiver component.ID, protocol string, count uint64, sum float64) error {
+	return pc.checkHistogram("receiver_deadline_remaining", count, sum, attributesForReceiverMetrics(receiver, protocol))
+}
+
+func (pc *prometheusChecker) checkReceiverParseDuration(receiver component.ID, protocol, format string, count uint64, sum float64) error {
+	receiverAttrs := append(attributesForReceiverMetrics(receiver, protocol), attribute.String(formatTag, format))
+	return pc.checkHistogram("receiver_parse_duration", count, sum, receiverAttrs)
+}
+
+func (pc *prometheusChecker) checkReceiverTracesBySampled(receiver component.ID, protocol string, sampledSpans, unsampledSpans int64) error {
+	receiverAttrs := attributesForReceiverMetrics(receiver, protocol)
+	return multierr.Combine(
+		pc.checkCounter("receiver_accepted_spans_by_sampled", sampledSpans, append(receiverAttrs, attribute.String(sampledTag, "true"))),
+		pc.checkCounter("receiver_accepted_spans_by_sampled", unsampledSpans, append(receiverAttrs, attribute.String(sampledTag, "false"))))
+}
+
+func (pc *prometheusChecker) checkReceiverTracesBySkew(receiver component.ID, protocol, skew string, acceptedSpans int64) error {
+	receiverAttrs := append(attributesForReceiverMetrics(receiver, protocol), attribute.String(clockSkewTag, skew))
+	return pc.checkCounter("receiver_accepted_spans_by_clock_skew", acceptedSpans, receiverAttrs)
+}
+
+func (pc *prometheusChecker) checkReceiverTracesConverted(receiver component.ID, protocol, fromFormat, toFormat string, convertedSpans int64) error {
+	receiverAttrs := append(attributesForReceiverMetrics(receiver, protocol),
+		attribute.String(fromFormatTag, fromFormat),
+		attribute.String(toFormatTag, toFormat))
+	return pc.checkCounter("receiver_converted_spans", convertedSpans, receiverAttrs)
+}
+
+func (pc *prometheusChecker) checkReceiverTracesForTenant(receiver component.ID, protocol, tenant string, acceptedSpans, droppedSpans int64) error {
+	receiverAttrs := append(attributesForReceiverMetrics(receiver, protocol), attribute.String(tenantTag, tenant))
+	return multierr.Combine(
+		pc.checkCounter("receiver_accepted_spans_by_tenant", acceptedSpans, receiverAttrs),
+		pc.checkCounter("receiver_refused_spans_by_tenant", droppedSpans, receiverAttrs))
+}
+
+func (pc *prometheusChecker) checkReceiverByVolume(receiver component.ID, protocol, itemType, volume string, accepted int64) error {
+	receiverAttrs := append(attributesForReceiverMetrics(receiver, protocol), attribute.String(volumeTag, volume))
+	return pc.checkCounter("receiver_accepted_"+itemType+"_by_volume", accepted, receiverAttrs)
+}
+
+func (pc *prometheusChecker) checkReceiverTracesByProtoVersion(receiver component.ID, protocol, version string, acceptedSpans int64) error {
+	receiverAttrs := append(attributesForReceiverMetrics(receiver, protocol), attribute.String(protoVerTag, version))
+	return pc.checkCounter("receiver_accepted_spans_by_proto_version", acceptedSpans, receiverAttrs)
+}
+
+func (pc *prometheusChecker) checkReceiverTracesRefusedByHTTPStatus(receiver component.ID, protocol string, httpStatus int, refusedSpans int64) error {
+	receiverAttrs := append(attributesForReceiverMetrics(receiver, protocol), attribute.String(statusCodeTag, strconv.Itoa(httpStatus)))
+	return pc.checkCounter("receiver_refused_spans_by_status_code", refusedSpans, receiverAttrs)
+}
+
 func (pc *prometheusChecker) checkReceiverLogs(receiver component.ID, protocol string, acceptedLogRecords, droppedLogRecords int64) error {
 	receiverAttrs := attributesForReceiverMetrics(receiver, protocol)
 	return multierr.Combine(
@@ -70,6 +216,123 @@ func (pc *prometheusChecker) checkProcessorTraces(processor component.ID, accept
 		pc.checkCounter("processor_dropped_spans", droppedSpans, processorAttrs))
 }
 
+func (pc *prometheusChecker) checkProcessorTracesFrom(processor component.ID, source component.ID, acceptedSpans int64) error {
+	processorAttrs := append(attributesForProcessorMetrics(processor), attribute.String(sourceTag, source.String()))
+	return pc.checkCounter("processor_accepted_spans_by_source", acceptedSpans, processorAttrs)
+}
+
+func (pc *prometheusChecker) checkProcessorDeduplicated(processor component.ID, itemType string, deduplicated int64) error {
+	return pc.checkCounter("processor_deduplicated_"+itemType, deduplicated, attributesForProcessorMetrics(processor))
+}
+
+func (pc *prometheusChecker) checkProcessorPassed(processor component.ID, itemType string, passed int64) error {
+	return pc.checkCounter("processor_passthrough_"+itemType, passed, attributesForProcessorMetrics(processor))
+}
+
+func (pc *prometheusChecker) checkProcessorMemoryLimited(processor component.ID, itemType string, limited int64) error {
+	return pc.checkCounter("processor_memory_limited_"+itemType, limited, attributesForProcessorMetrics(processor))
+}
+
+func (pc *prometheusChecker) checkProcessorBytesDropped(processor component.ID, itemType string, droppedBytes int64) error {
+	return pc.checkCounter("processor_dropped_bytes_"+itemType, droppedBytes, attributesForProcessorMetrics(processor))
+}
+
+func (pc *prometheusChecker) checkProcessorProcessingErrors(processor component.ID, itemType string, items int64) error {
+	return pc.checkCounter("processor_processing_errors_"+itemType, items, attributesForProcessorMetrics(processor))
+}
+
+func (pc *prometheusChecker) checkProcessorOutOfOrder(processor component.ID, itemType string, items int64) error {
+	return pc.checkCounter("processor_out_of_order_"+itemType, items, attributesForProcessorMetrics(processor))
+}
+
+func (pc *prometheusChecker) checkProcessorTransformDropped(processor component.ID, itemType, reason string, items int64) error {
+	processorAttrs := append(attributesForProcessorMetrics(processor), attribute.String(reasonTag, reason))
+	return pc.checkCounter("processor_transform_dropped_"+itemType, items, processorAttrs)
+}
+
+func (pc *prometheusChecker) checkProcessorFlushReason(processor component.ID, reason string, flushes int64) error {
+	processorAttrs := append(attributesForProcessorMetrics(processor), attribute.String(reasonTag, reason))
+	return pc.checkCounter("processor_flush_by_reason", flushes, processorAttrs)
+}
+
+func (pc *prometheusChecker) checkProcessorTracesDroppedForResource(processor component.ID, resourceKey string, droppedSpans int64) error {
+	processorAttrs := append(attributesForProcessorMetrics(processor), attribute.String(resourceTag, resourceKey))
+	return pc.checkCounter("processor_dropped_spans_by_resource", droppedSpans, processorAttrs)
+}
+
+func (pc *prometheusChecker) checkProcessorTracesDroppedByRule(processor component.ID, ruleID string, droppedSpans int64) error {
+	processorAttrs := append(attributesForProcessorMetrics(processor), attribute.String(ruleIDTag, ruleID))
+	return pc.checkCounter("processor_dropped_spans_by_rule", droppedSpans, processorAttrs)
+}
+
+func (pc *prometheusChecker) checkProcessorDroppedByPipeline(processor component.ID, itemType, pipeline string, dropped int64) error {
+	processorAttrs := append(attributesForProcessorMetrics(processor), attribute.String(pipelineTag, pipeline))
+	return pc.checkCounter("processor_dropped_"+itemType+"_by_pipeline", dropped, processorAttrs)
+}
+
+func (pc *prometheusChecker) checkProcessorSampledSpans(processor component.ID, decision string, sampledSpans int64) error {
+	processorAttrs := append(attributesForProcessorMetrics(processor), attribute.String(decisionTag, decision))
+	return pc.checkCounter("processor_sampled_spans", sampledSpans, processorAttrs)
+}
+
+func (pc *prometheusChecker) checkProcessorEnrichedItems(processor component.ID, signal component.DataType, outcome string, items int64) error {
+	processorAttrs := append(attributesForProcessorMetrics(processor),
+		attribute.String(signalTag, string(signal)),
+		attribute.String(outcomeTag, outcome))
+	return pc.checkCounter("processor_enriched_items", items, processorAttrs)
+}
+
+func (pc *prometheusChecker) checkProcessorThresholdBreaches(processor component.ID, signal component.DataType, threshold string, breaches int64) error {
+	processorAttrs := append(attributesForProcessorMetrics(processor),
+		attribute.String(signalTag, string(signal)),
+		attribute.String(thresholdTag, threshold))
+	return pc.checkCounter("processor_threshold_breaches", breaches, processorAttrs)
+}
+
+func (pc *prometheusChecker) checkProcessorTimeoutFlushRatio(processor component.ID, ratio float64) error {
+	return pc.checkFloatGauge("processor_timeout_flush_ratio", ratio, attributesForProcessorMetrics(processor))
+}
+
+func (pc *prometheusChecker) checkProcessorEffectiveSampleRatio(processor component.ID, signal component.DataType, ratio float64) error {
+	processorAttrs := append(attributesForProcessorMetrics(processor), attribute.String(signalTag, string(signal)))
+	return pc.checkFloatGauge("processor_effective_sample_ratio", ratio, processorAttrs)
+}
+
+func (pc *prometheusChecker) checkProcessorQueueLatency(processor component.ID, count uint64) error {
+	return pc.checkHistogramCount("processor_queue_latency", count, attributesForProcessorMetrics(processor))
+}
+
+func (pc *prometheusChecker) checkProcessorProcessingDuration(processor component.ID, signal component.DataType, count uint64, sum float64) error {
+	processorAttrs := append(attributesForProcessorMetrics(processor), attribute.String(signalTag, string(signal)))
+	return pc.checkHistogram("processor_processing_duration", count, sum, processorAttrs)
+}
+
+func (pc *prometheusChecker) checkProcessorFanoutDegree(processor component.ID, signal component.DataType, count uint64, sum float64) error {
+	processorAttrs := append(attributesForProcessorMetrics(processor), attribute.String(signalTag, string(signal)))
+	return pc.checkHistogram("processor_fanout_degree", count, sum, processorAttrs)
+}
+
+func (pc *prometheusChecker) checkProcessorBatchSplitFactor(processor component.ID, count uint64, sum float64) error {
+	return pc.checkHistogram("processor_batch_split_factor", count, sum, attributesForProcessorMetrics(processor))
+}
+
+func (pc *prometheusChecker) checkProcessorAllocatedBytes(processor component.ID, count uint64) error {
+	processorAttrs := attributesForProcessorMetrics(processor)
+	if err := pc.checkHistogramCount("processor_allocated_bytes", count, processorAttrs); err != nil {
+		return err
+	}
+
+	ts, err := pc.getMetric("processor_allocated_bytes", io_prometheus_client.MetricType_HISTOGRAM, processorAttrs)
+	if err != nil {
+		return err
+	}
+	if ts.GetHistogram().GetSampleSum() < 0 {
+		return fmt.Errorf("sample sum for metric 'processor_allocated_bytes' is negative, got '%f'", ts.GetHistogram().GetSampleSum())
+	}
+
+	return nil
+}
+
 func (pc *prometheusChecker) checkProcessorMetrics(processor component.ID, acceptedMetricPoints, refusedMetricPoints, droppedMetricPoints int64) error {
 	processorAttrs := attributesForProcessorMetrics(processor)
 	return multierr.Combine(
@@ -86,6 +349,135 @@ func (pc *prometheusChecker) checkProcessorLogs(processor component.ID, accepted
 		pc.checkCounter("processor_dropped_log_records", droppedLogRecords, processorAttrs))
 }
 
+func (pc *prometheusChecker) checkExporterTracesFailedByCode(exporter component.ID, statusCodeKey, code string, sendFailedSpans int64) error {
+	// Prometheus label names cannot contain dots.
+	exporterAttrs := append(attributesForExporterMetrics(exporter), attribute.String(strings.ReplaceAll(statusCodeKey, ".", "_"), code))
+	return pc.checkCounter("exporter_send_failed_spans_by_code", sendFailedSpans, exporterAttrs)
+}
+
+func (pc *prometheusChecker) checkExporterTracesToDestination(exporter component.ID, destination string, sentSpans, sendFailedSpans int64) error {
+	exporterAttrs := append(attributesForExporterMetrics(exporter), attribute.String(destinationTag, destination))
+	return multierr.Combine(
+		pc.checkCounter("exporter_sent_spans_by_destination", sentSpans, exporterAttrs),
+		pc.checkCounter("exporter_send_failed_spans_by_destination", sendFailedSpans, exporterAttrs))
+}
+
+func (pc *prometheusChecker) checkExporterConnectionState(exporter component.ID, state string, value, transitions int64) error {
+	exporterAttrs := append(attributesForExporterMetrics(exporter), attribute.String(stateTag, state))
+	return multierr.Combine(
+		pc.checkGauge("exporter_connection_state", value, exporterAttrs),
+		pc.checkCounter("exporter_connection_state_transitions", transitions, exporterAttrs))
+}
+
+func (pc *prometheusChecker) checkExporterBackpressure(exporter component.ID, value, durationMillis int64) error {
+	exporterAttrs := attributesForExporterMetrics(exporter)
+	return multierr.Combine(
+		pc.checkGauge("exporter_backpressure", value, exporterAttrs),
+		pc.checkCounter("exporter_backpressure_duration", durationMillis, exporterAttrs))
+}
+
+func (pc *prometheusChecker) checkExporterSends(exporter component.ID, compression string, sends int64) error {
+	exporterAttrs := append(attributesForExporterMetrics(exporter), attribute.String(compressionTag, compression))
+	return pc.checkCounter("exporter_sends", sends, exporterAttrs)
+}
+
+func (pc *prometheusChecker) checkExporterRetriesExhausted(exporter component.ID, itemType string, retriesExhausted int64) error {
+	return pc.checkCounter("exporter_retries_exhausted_"+itemType, retriesExhausted, attributesForExporterMetrics(exporter))
+}
+
+func (pc *prometheusChecker) checkExporterPartialWarnings(exporter component.ID, signal string, partialWarnings int64) error {
+	return pc.checkCounter("exporter_partial_warnings_"+signal, partialWarnings, attributesForExporterMetrics(exporter))
+}
+
+func (pc *prometheusChecker) checkExporterPersistentQueueSize(exporter component.ID, items, bytes int64) error {
+	exporterAttrs := attributesForExporterMetrics(exporter)
+	return multierr.Combine(
+		pc.checkGauge("exporter_persistent_queue_items", items, exporterAttrs),
+		pc.checkGauge("exporter_persistent_queue_bytes", bytes, exporterAttrs))
+}
+
+func (pc *prometheusChecker) checkExporterOldestQueuedAge(exporter component.ID, age float64) error {
+	return pc.checkFloatGauge("exporter_oldest_queued_age", age, attributesForExporterMetrics(exporter))
+}
+
+func (pc *prometheusChecker) checkSaturation(id component.ID, kind component.Kind, saturation float64) error {
+	kindTag, err := componentKindTag(kind)
+	if err != nil {
+		return err
+	}
+	return pc.checkFloatGauge(kindTag+"_saturation", saturation, []attribute.KeyValue{attribute.String(kindTag, id.String())})
+}
+
+func (pc *prometheusChecker) checkConcurrency(id component.ID, kind component.Kind, concurrency int64) error {
+	kindTag, err := componentKindTag(kind)
+	if err != nil {
+		return err
+	}
+	return pc.checkGauge(kindTag+"_concurrency", concurrency, []attribute.KeyValue{attribute.String(kindTag, id.String())})
+}
+
+// componentKindTag returns the tag identifying the components of the given kind.
+func componentKindTag(kind component.Kind) (string, error) {
+	switch kind {
+	case component.KindReceiver:
+		return receiverTag, nil
+	case component.KindProcessor:
+		return processorTag, nil
+	case component.KindExporter:
+		return exporterTag, nil
+	case component.KindConnector:
+		return connectorTag, nil
+	default:
+		return "", fmt.Errorf("obsreport does not support components of kind %d", kind)
+	}
+}
+
+func (pc *prometheusChecker) checkCollectorInfo(version, commit string) error {
+	return pc.checkGauge("collector_info", 1, []attribute.KeyValue{
+		attribute.String(versionTag, version),
+		attribute.String(commitTag, commit)})
+}
+
+func (pc *prometheusChecker) checkPendingInternalTelemetry(dataPoints int64) error {
+	return pc.checkGauge("obsreport_pending_internal_telemetry", dataPoints, nil)
+}
+
+func (pc *prometheusChecker) checkDroppedInternalTelemetry(dataPoints int64) error {
+	return pc.checkCounter("obsreport_dropped_internal_telemetry", dataPoints, nil)
+}
+
+func (pc *prometheusChecker) checkExporterSendDuration(exporter component.ID, outcome string, operations uint64) error {
+	exporterAttrs := append(attributesForExporterMetrics(exporter), attribute.String(outcomeTag, outcome))
+	return pc.checkHistogramCount("exporter_send_duration", operations, exporterAttrs)
+}
+
+func (pc *prometheusChecker) checkExporterTLSErrors(exporter component.ID, kind string, tlsErrors int64) error {
+	exporterAttrs := append(attributesForExporterMetrics(exporter), attribute.String(kindTag, kind))
+	return pc.checkCounter("exporter_tls_errors", tlsErrors, exporterAttrs)
+}
+
+func (pc *prometheusChecker) checkExporterRequests(exporter component.ID, outcome string, requests int64) error {
+	exporterAttrs := append(attributesForExporterMetrics(exporter), attribute.String(outcomeTag, outcome))
+	return pc.checkCounter("exporter_requests", requests, exporterAttrs)
+}
+
+func (pc *prometheusChecker) checkExporterAckLatency(exporter component.ID, acks uint64, sum float64) error {
+	return pc.checkHistogram("exporter_ack_latency", acks, sum, attributesForExporterMetrics(exporter))
+}
+
+func (pc *prometheusChecker) checkExporterQueueWait(exporter component.ID, operations uint64, sum float64) error {
+	return pc.checkHistogram("exporter_queue_wait", operations, sum, attributesForExporterMetrics(exporter))
+}
+
+func (pc *prometheusChecker) checkPipelineLatency(exporter component.ID, operations uint64, sum float64) error {
+	return pc.checkHistogram("pipeline_latency", operations, sum, attributesForExporterMetrics(exporter))
+}
+
+func (pc *prometheusChecker) checkExporterBatchSizes(exporter component.ID, signal component.DataType, batches uint64, items int64) error {
+	exporterAttrs := append(attributesForExporterMetrics(exporter), attribute.String(signalTag, string(signal)))
+	return pc.checkHistogram("exporter_sent_batch_size", batches, float64(items), exporterAttrs)
+}
+
 func (pc *prometheusChecker) checkExporterTraces(exporter component.ID, sentSpans, sendFailedSpans int64) error {
 	exporterAttrs := attributesForExporterMetrics(exporter)
 	if sendFailedSpans > 0 {
@@ -119,6 +511,72 @@ func (pc *prometheusChecker) checkExporterMetrics(exporter component.ID, sentMet
 		pc.checkCounter("exporter_sent_metric_points", sentMetricPoints, exporterAttrs))
 }
 
+func (pc *prometheusChecker) checkConnectorTraces(connector component.ID, acceptedSpans, refusedSpans, sentSpans, sendFailedSpans int64) error {
+	return pc.checkConnector(connector, "spans", acceptedSpans, refusedSpans, sentSpans, sendFailedSpans)
+}
+
+func (pc *prometheusChecker) checkConnectorMetrics(connector component.ID, acceptedMetricPoints, refusedMetricPoints, sentMetricPoints, sendFailedMetricPoints int64) error {
+	return pc.checkConnector(connector, "metric_points", acceptedMetricPoints, refusedMetricPoints, sentMetricPoints, sendFailedMetricPoints)
+}
+
+func (pc *prometheusChecker) checkConnectorLogs(connector component.ID, acceptedLogRecords, refusedLogRecords, sentLogRecords, sendFailedLogRecords int64) error {
+	return pc.checkConnector(connector, "log_records", acceptedLogRecords, refusedLogRecords, sentLogRecords, sendFailedLogRecords)
+}
+
+func (pc *prometheusChecker) checkConnectorTracesDetailed(connector component.ID, acceptedSpanEvents, refusedSpanEvents, acceptedSpanLinks, refusedSpanLinks int64) error {
+	connectorAttrs := attributesForConnectorMetrics(connector)
+	return multierr.Combine(
+		pc.checkCounter("connector_accepted_span_events", acceptedSpanEvents, connectorAttrs),
+		pc.checkCounter("connector_refused_span_events", refusedSpanEvents, connectorAttrs),
+		pc.checkCounter("connector_accepted_span_links", acceptedSpanLinks, connectorAttrs),
+		pc.checkCounter("connector_refused_span_links", refusedSpanLinks, connectorAttrs))
+}
+
+func (pc *prometheusChecker) checkConnectorTracesBySkew(connector component.ID, skew string, acceptedSpans int64) error {
+	connectorAttrs := append(attributesForConnectorMetrics(connector), attribute.String(clockSkewTag, skew))
+	return pc.checkCounter("connector_accepted_spans_by_clock_skew", acceptedSpans, connectorAttrs)
+}
+
+func (pc *prometheusChecker) checkConnectorRerouted(connector component.ID, itemType, fromPipeline, toPipeline string, rerouted int64) error {
+	connectorAttrs := append(attributesForConnectorMetrics(connector),
+		attribute.String(fromPipeTag, fromPipeline),
+		attribute.String(toPipeTag, toPipeline))
+	return pc.checkCounter("connector_rerouted_"+itemType, rerouted, connectorAttrs)
+}
+
+func (pc *prometheusChecker) checkConnector(connector component.ID, itemType string, accepted, refused, sent, sendFailed int64) error {
+	connectorAttrs := attributesForConnectorMetrics(connector)
+	errs := multierr.Combine(
+		pc.checkCounter("connector_accepted_"+itemType, accepted, connectorAttrs),
+		pc.checkCounter("connector_refused_"+itemType, refused, connectorAttrs),
+		pc.checkCounter("connector_sent_"+itemType, sent, connectorAttrs))
+	if sendFailed > 0 {
+		errs = multierr.Append(errs, pc.checkCounter("connector_send_failed_"+itemType, sendFailed, connectorAttrs))
+	}
+	return errs
+}
+
+// checkNoMetrics returns an error listing all the component metrics that have at least one timeseries.
+func (pc *prometheusChecker) checkNoMetrics() error {
+	// Forces a flush for the opencensus view data, any view name works as the
+	// request is processed after all the previously recorded measurements.
+	_, _ = view.RetrieveData(receiverTag)
+
+	parsed, err := fetchPrometheusMetrics(pc.promHandler)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for name, metricFamily := range parsed {
+		if !isComponentMetric(name) || len(metricFamily.Metric) == 0 {
+			continue
+		}
+		errs = multierr.Append(errs, fmt.Errorf("metric '%s' has %d timeseries, expected none", name, len(metricFamily.Metric)))
+	}
+	return errs
+}
+
 func (pc *prometheusChecker) checkCounter(expectedMetric string, value int64, attrs []attribute.KeyValue) error {
 	// Forces a flush for the opencensus view data.
 	_, _ = view.RetrieveData(expectedMetric)
@@ -136,6 +594,59 @@ func (pc *prometheusChecker) checkCounter(expectedMetric string, value int64, at
 	return nil
 }
 
+func (pc *prometheusChecker) checkGauge(expectedMetric string, value int64, attrs []attribute.KeyValue) error {
+	return pc.checkFloatGauge(expectedMetric, float64(value), attrs)
+}
+
+func (pc *prometheusChecker) checkFloatGauge(expectedMetric string, expected float64, attrs []attribute.KeyValue) error {
+	// Forces a flush for the opencensus view data.
+	_, _ = view.RetrieveData(expectedMetric)
+
+	ts, err := pc.getMetric(expectedMetric, io_prometheus_client.MetricType_GAUGE, attrs)
+	if err != nil {
+		return err
+	}
+
+	if math.Abs(expected-ts.GetGauge().GetValue()) > 0.0001 {
+		return fmt.Errorf("values for metric '%s' did no match, expected '%f' got '%f'", expectedMetric, expected, ts.GetGauge().GetValue())
+	}
+
+	return nil
+}
+
+func (pc *prometheusChecker) checkHistogramCount(expectedMetric string, count uint64, attrs []attribute.KeyValue) error {
+	// Forces a flush for the opencensus view data.
+	_, _ = view.RetrieveData(expectedMetric)
+
+	ts, err := pc.getMetric(expectedMetric, io_prometheus_client.MetricType_HISTOGRAM, attrs)
+	if err != nil {
+		return err
+	}
+
+	if ts.GetHistogram().GetSampleCount() != count {
+		return fmt.Errorf("sample count for metric '%s' did no match, expected '%d' got '%d'", expectedMetric, count, ts.GetHistogram().GetSampleCount())
+	}
+
+	return nil
+}
+
+func (pc *prometheusChecker) checkHistogram(expectedMetric string, count uint64, sum float64, attrs []attribute.KeyValue) error {
+	if err := pc.checkHistogramCount(expectedMetric, count, attrs); err != nil {
+		return err
+	}
+
+	ts, err := pc.getMetric(expectedMetric, io_prometheus_client.MetricType_HISTOGRAM, attrs)
+	if err != nil {
+		return err
+	}
+
+	if math.Abs(sum-ts.GetHistogram().GetSampleSum()) > 0.0001 {
+		return fmt.Errorf("sample sum for metric '%s' did no match, expected '%f' got '%f'", expectedMetric, sum, ts.GetHistogram().GetSampleSum())
+	}
+
+	return nil
+}
+
 // getMetric returns the metric time series that matches the given name, type and set of attributes
 // it fetches data from the prometheus endpoint and parse them, ideally OTel Go should provide a MeterRecorder of some kind.
 func (pc *prometheusChecker) getMetric(expectedName string, expectedType io_prometheus_client.MetricType, expectedAttrs []attribute.KeyValue) (*io_prometheus_client.Metric, error) {
@@ -157,7 +668,7 @@ func (pc *prometheusChecker) getMetric(expectedName string, expectedType io_prom
 		return nil, fmt.Errorf("metric '%v' has type '%s' instead of '%s'", expectedName, metricFamily.Type.String(), expectedType.String())
 	}
 
-	expectedSet := attribute.NewSet(expectedAttrs...)
+	expectedSet := attribute.NewSet(withoutEmptyValues(expectedAttrs)...)
 
 	for _, metric := range metricFamily.Metric {
 		var attrs []attribute.KeyValue
@@ -165,7 +676,7 @@ func (pc *prometheusChecker) getMetric(expectedName string, expectedType io_prom
 		for _, label := range metric.Label {
 			attrs = append(attrs, attribute.String(label.GetName(), label.GetValue()))
 		}
-		set := attribute.NewSet(attrs...)
+		set := attribute.NewSet(withoutEmptyValues(attrs)...)
 
 		if expectedSet.Equals(&set) {
 			return metric, nil
@@ -175,6 +686,19 @@ func (pc *prometheusChecker) getMetric(expectedName string, expectedType io_prom
 	return nil, fmt.Errorf("metric '%s' doesn't have a timeseries with the given attributes: %s", expectedName, expectedSet.Encoded(attribute.DefaultEncoder()))
 }
 
+// withoutEmptyValues returns the attributes that have a non-empty value. An empty label value is
+// equivalent to the label not being set, e.g. OpenCensus exports the tags of a view that were not
+// set when recording with an empty value.
+func withoutEmptyValues(attrs []attribute.KeyValue) []attribute.KeyValue {
+	res := make([]attribute.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		if attr.Value.AsString() != "" {
+			res = append(res, attr)
+		}
+	}
+	return res
+}
+
 func fetchPrometheusMetrics(handler http.Handler) (map[string]*io_prometheus_client.MetricFamily, error) {
 	req, err := http.NewRequest(http.MethodGet, "/metrics", nil)
 	if err != nil {
@@ -188,6 +712,17 @@ func fetchPrometheusMetrics(handler http.Handler) (map[string]*io_prometheus_cli
 	return parser.TextToMetricFamilies(rr.Body)
 }
 
+// isComponentMetric returns true if the metric name has one of the prefixes used by the obsreport metrics.
+func isComponentMetric(name string) bool {
+	for _, prefix := range obsmetrics.Prefixes {
+		// The Prometheus exporters replace the separators of the names with "_".
+		if strings.HasPrefix(name, strings.ReplaceAll(prefix, obsmetrics.NameSep, "_")) {
+			return true
+		}
+	}
+	return false
+}
+
 func attributesForScraperMetrics(receiver component.ID, scraper component.ID) []attribute.KeyValue {
 	return []attribute.KeyValue{
 		attribute.String(receiverTag, receiver.String()),
@@ -207,6 +742,10 @@ func attributesForProcessorMetrics(processor component.ID) []attribute.KeyValue
 	return []attribute.KeyValue{attribute.String(processorTag, processor.String())}
 }
 
+func attributesForConnectorMetrics(connector component.ID) []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.String(connectorTag, connector.String())}
+}
+
 // attributesForReceiverMetrics returns the attributes that are needed for the receiver metrics.
 func attributesForExporterMetrics(exporter component.ID) []attribute.KeyValue {
 	return []attribute.KeyValue{attribute.String(exporterTag, exporter.String())}
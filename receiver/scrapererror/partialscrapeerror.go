@@ -14,7 +14,11 @@
 
 package scrapererror // import "go.opentelemetry.io/collector/receiver/scrapererror"
 
-import "errors"
+import (
+	"errors"
+
+	"go.uber.org/multierr"
+)
 
 // PartialScrapeError is an error to represent
 // that a subset of metrics were failed to be scraped.
@@ -32,12 +36,62 @@ func NewPartialScrapeError(err error, failed int) PartialScrapeError {
 	}
 }
 
-// IsPartialScrapeError checks if an error was wrapped with PartialScrapeError.
+// MultiPartialScrapeError is an error to represent that a subset
+// of metrics were failed to be scraped from multiple targets.
+type MultiPartialScrapeError struct {
+	error
+	// failedByTarget holds the number of failed metrics for each target. It is held
+	// by pointer so that the error is comparable, like PartialScrapeError.
+	failedByTarget *map[string]int
+}
+
+// NewMultiPartialScrapeError creates MultiPartialScrapeError for failed metrics
+// of scrapers that scrape multiple targets. The errors are combined into one.
+// Use this error type only when a subset of data was failed to be scraped.
+func NewMultiPartialScrapeError(errs []error, failedByTarget map[string]int) MultiPartialScrapeError {
+	return MultiPartialScrapeError{
+		error:          multierr.Combine(errs...),
+		failedByTarget: &failedByTarget,
+	}
+}
+
+// FailedByTarget returns the number of failed metrics for each target.
+func (e MultiPartialScrapeError) FailedByTarget() map[string]int {
+	failedByTarget := map[string]int{}
+	if e.failedByTarget == nil {
+		return failedByTarget
+	}
+	for target, failed := range *e.failedByTarget {
+		failedByTarget[target] = failed
+	}
+	return failedByTarget
+}
+
+// Failed returns the number of failed metrics across all targets.
+func (e MultiPartialScrapeError) Failed() int {
+	failed := 0
+	if e.failedByTarget == nil {
+		return failed
+	}
+	for _, f := range *e.failedByTarget {
+		failed += f
+	}
+	return failed
+}
+
+// Unwrap returns the combined errors, so that errors.Is and errors.As
+// can match any of the individual errors.
+func (e MultiPartialScrapeError) Unwrap() error {
+	return e.error
+}
+
+// IsPartialScrapeError checks if an error was wrapped with PartialScrapeError or MultiPartialScrapeError.
 func IsPartialScrapeError(err error) bool {
 	if err == nil {
 		return false
 	}
 
 	var partialScrapeErr PartialScrapeError
-	return errors.As(err, &partialScrapeErr)
+	var multiPartialScrapeErr MultiPartialScrapeError
+	return errors.As(err, &partialScrapeErr) || errors.As(err, &multiPartialScrapeErr)
 }
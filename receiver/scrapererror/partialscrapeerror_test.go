@@ -36,4 +36,22 @@ func TestIsPartialScrapeError(t *testing.T) {
 
 	err = NewPartialScrapeError(err, 2)
 	require.True(t, IsPartialScrapeError(err))
+
+	err = NewMultiPartialScrapeError([]error{errors.New("testError")}, map[string]int{"target": 2})
+	require.True(t, IsPartialScrapeError(err))
+}
+
+func TestMultiPartialScrapeError(t *testing.T) {
+	err1 := errors.New("err1")
+	err2 := errors.New("err2")
+	partialErr := NewMultiPartialScrapeError([]error{err1, err2}, map[string]int{"target1": 2, "target2": 5})
+	assert.ErrorIs(t, partialErr, err1)
+	assert.ErrorIs(t, partialErr, err2)
+	assert.Equal(t, 7, partialErr.Failed())
+	assert.Equal(t, map[string]int{"target1": 2, "target2": 5}, partialErr.FailedByTarget())
+
+	// The error is comparable, so comparing it through an interface does not panic.
+	var err error = partialErr
+	assert.True(t, err == partialErr)
+	assert.False(t, err == error(NewMultiPartialScrapeError([]error{err1}, map[string]int{"target1": 2})))
 }
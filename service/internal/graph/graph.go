@@ -29,6 +29,10 @@ import (
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
@@ -199,23 +203,44 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
 		case *capabilitiesNode:
 			capability := consumer.Capabilities{MutatesData: false}
+			// The pipeline is added to the context for the processors shared by multiple
+			// pipelines to attribute the data they drop to the right one. It costs an
+			// allocation per batch, so it is only done if one of them asked for it.
+			withPipeline := false
 			for _, proc := range g.pipelines[n.pipelineID].processors {
 				capability.MutatesData = capability.MutatesData || proc.getConsumer().Capabilities().MutatesData
+				withPipeline = withPipeline || proc.droppedByPipeline
 			}
 			next := g.nextConsumers(n.ID())[0]
+			pipeline := n.pipelineID.String()
 			switch n.pipelineID.Type() {
 			case component.DataTypeTraces:
 				cc := capabilityconsumer.NewTraces(next.(consumer.Traces), capability)
 				n.baseConsumer = cc
 				n.ConsumeTracesFunc = cc.ConsumeTraces
+				if withPipeline {
+					n.ConsumeTracesFunc = func(ctx context.Context, td ptrace.Traces) error {
+						return cc.ConsumeTraces(obsreport.WithPipeline(ctx, pipeline), td)
+					}
+				}
 			case component.DataTypeMetrics:
 				cc := capabilityconsumer.NewMetrics(next.(consumer.Metrics), capability)
 				n.baseConsumer = cc
 				n.ConsumeMetricsFunc = cc.ConsumeMetrics
+				if withPipeline {
+					n.ConsumeMetricsFunc = func(ctx context.Context, md pmetric.Metrics) error {
+						return cc.ConsumeMetrics(obsreport.WithPipeline(ctx, pipeline), md)
+					}
+				}
 			case component.DataTypeLogs:
 				cc := capabilityconsumer.NewLogs(next.(consumer.Logs), capability)
 				n.baseConsumer = cc
 				n.ConsumeLogsFunc = cc.ConsumeLogs
+				if withPipeline {
+					n.ConsumeLogsFunc = func(ctx context.Context, ld plog.Logs) error {
+						return cc.ConsumeLogs(obsreport.WithPipeline(ctx, pipeline), ld)
+					}
+				}
 			}
 		case *fanOutNode:
 			nexts := g.nextConsumers(n.ID())
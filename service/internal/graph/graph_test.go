@@ -34,6 +34,8 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/collector/receiver"
@@ -994,6 +996,76 @@ func TestConnectorRouter(t *testing.T) {
 
 }
 
+func TestGraphPipelineInContext(t *testing.T) {
+	rcvrID := component.NewID("examplereceiver")
+	breakdownProcID := component.NewIDWithName("pipelinerecorder", "breakdown")
+	plainProcID := component.NewIDWithName("pipelinerecorder", "plain")
+	expID := component.NewID("exampleexporter")
+
+	frontendID := component.NewIDWithName("traces", "frontend")
+	backendID := component.NewIDWithName("traces", "backend")
+	plainID := component.NewIDWithName("traces", "plain")
+
+	var seen []string
+	ctx := context.Background()
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: receiver.NewBuilder(
+			map[component.ID]component.Config{
+				rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: processor.NewBuilder(
+			map[component.ID]component.Config{
+				breakdownProcID: &pipelineRecorderConfig{DroppedByPipeline: true},
+				plainProcID:     &pipelineRecorderConfig{},
+			},
+			map[component.Type]processor.Factory{
+				"pipelinerecorder": newPipelineRecorderFactory(&seen),
+			},
+		),
+		ExporterBuilder: exporter.NewBuilder(
+			map[component.ID]component.Config{
+				expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: connector.NewBuilder(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: map[component.ID]*PipelineConfig{
+			frontendID: {
+				Receivers:  []component.ID{rcvrID},
+				Processors: []component.ID{breakdownProcID},
+				Exporters:  []component.ID{expID},
+			},
+			backendID: {
+				Receivers:  []component.ID{rcvrID},
+				Processors: []component.ID{breakdownProcID},
+				Exporters:  []component.ID{expID},
+			},
+			plainID: {
+				Receivers:  []component.ID{rcvrID},
+				Processors: []component.ID{plainProcID},
+				Exporters:  []component.ID{expID},
+			},
+		},
+	}
+
+	pg, err := Build(ctx, set)
+	require.NoError(t, err)
+
+	tracesReceiver := pg.getReceivers()[component.DataTypeTraces][rcvrID].(*testcomponents.ExampleReceiver)
+	require.NoError(t, tracesReceiver.ConsumeTraces(ctx, testdata.GenerateTraces(1)))
+
+	// The pipeline is only set for the pipelines with a processor that asked for it.
+	assert.ElementsMatch(t, []string{frontendID.String(), backendID.String(), ""}, seen)
+}
+
 func TestGraphBuildErrors(t *testing.T) {
 	nopReceiverFactory := receivertest.NewNopFactory()
 	nopProcessorFactory := processortest.NewNopFactory()
@@ -2114,6 +2186,51 @@ func newErrConnectorFactory() connector.Factory {
 	)
 }
 
+type pipelineRecorderConfig struct {
+	DroppedByPipeline bool
+}
+
+// newPipelineRecorderFactory returns a factory of trace processors appending the pipeline
+// held by the context of each batch to seen, or an empty string if there is none.
+func newPipelineRecorderFactory(seen *[]string) processor.Factory {
+	return processor.NewFactory("pipelinerecorder",
+		func() component.Config { return &pipelineRecorderConfig{} },
+		processor.WithTraces(func(_ context.Context, set processor.CreateSettings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+			obsrep, err := obsreport.NewProcessor(obsreport.ProcessorSettings{
+				ProcessorID:             set.ID,
+				ProcessorCreateSettings: set,
+				DroppedByPipeline:       cfg.(*pipelineRecorderConfig).DroppedByPipeline,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &pipelineRecorder{obsrep: obsrep, next: next, seen: seen}, nil
+		}, component.StabilityLevelUndefined),
+	)
+}
+
+type pipelineRecorder struct {
+	component.StartFunc
+	component.ShutdownFunc
+	obsrep *obsreport.Processor
+	next   consumer.Traces
+	seen   *[]string
+}
+
+func (p *pipelineRecorder) DroppedByPipeline() bool {
+	return p.obsrep.DroppedByPipeline()
+}
+
+func (p *pipelineRecorder) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (p *pipelineRecorder) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	pipeline, _ := obsreport.PipelineFromContext(ctx)
+	*p.seen = append(*p.seen, pipeline)
+	return p.next.ConsumeTraces(ctx, td)
+}
+
 type errComponent struct {
 	consumertest.Consumer
 }
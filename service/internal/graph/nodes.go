@@ -123,6 +123,15 @@ type processorNode struct {
 	componentID component.ID
 	pipelineID  component.ID
 	component.Component
+	// droppedByPipeline is set if the processor breaks down the data it drops by pipeline,
+	// which is then set in the context of the data, see obsreport.WithPipeline.
+	droppedByPipeline bool
+}
+
+// droppedByPipelineProcessor is implemented by the processors breaking down the data
+// they drop by pipeline, see obsreport.ProcessorSettings.DroppedByPipeline.
+type droppedByPipelineProcessor interface {
+	DroppedByPipeline() bool
 }
 
 func newProcessorNode(pipelineID, procID component.ID) *processorNode {
@@ -159,6 +168,9 @@ func (n *processorNode) buildComponent(ctx context.Context,
 	if err != nil {
 		return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID, err)
 	}
+	if p, ok := n.Component.(droppedByPipelineProcessor); ok {
+		n.droppedByPipeline = p.DroppedByPipeline()
+	}
 	return nil
 }
 
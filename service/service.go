@@ -20,6 +20,7 @@ import (
 	"runtime"
 
 	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
@@ -29,8 +30,10 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
+	semconv "go.opentelemetry.io/collector/semconv/v1.18.0"
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/internal/graph"
 	"go.opentelemetry.io/collector/service/internal/proctelemetry"
@@ -63,6 +66,16 @@ type Settings struct {
 	// LoggingOptions provides a way to change behavior of zap logging.
 	LoggingOptions []zap.Option
 
+	// MetricReaders are additional readers of the internal metrics, e.g. a periodic reader
+	// wrapping an OTLP exporter to push them to a backend in addition to exposing them for
+	// Prometheus scraping. They are only used when the telemetry.useOtelForInternalMetrics
+	// feature gate is enabled, and are shut down with the Service.
+	MetricReaders []sdkmetric.Reader
+
+	// InternalTelemetry, if set, reports the data points pending export and dropped by
+	// the exporters of the MetricReaders wrapped with its WrapExporter method.
+	InternalTelemetry *obsreport.InternalTelemetry
+
 	// For testing purpose only.
 	useOtel *bool
 }
@@ -93,13 +106,14 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 			buildInfo:         set.BuildInfo,
 			asyncErrorChannel: set.AsyncErrorChannel,
 		},
-		telemetryInitializer: newColTelemetry(useOtel, disableHighCard),
+		telemetryInitializer: newColTelemetry(useOtel, disableHighCard, set.MetricReaders),
 	}
 	var err error
 	srv.telemetry, err = telemetry.New(ctx, telemetry.Settings{ZapOptions: set.LoggingOptions}, cfg.Telemetry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get logger: %w", err)
 	}
+	telAttrs := buildTelAttrs(set.BuildInfo, cfg.Telemetry)
 	srv.telemetrySettings = component.TelemetrySettings{
 		Logger:         srv.telemetry.Logger(),
 		TracerProvider: srv.telemetry.TracerProvider(),
@@ -107,11 +121,26 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 		MetricsLevel:   cfg.Telemetry.Metrics.Level,
 	}
 
-	if err = srv.telemetryInitializer.init(set.BuildInfo, srv.telemetrySettings.Logger, cfg.Telemetry, set.AsyncErrorChannel); err != nil {
+	if err = srv.telemetryInitializer.init(telAttrs, srv.telemetrySettings.Logger, cfg.Telemetry, set.AsyncErrorChannel); err != nil {
 		return nil, fmt.Errorf("failed to initialize telemetry: %w", err)
 	}
 	srv.telemetrySettings.MeterProvider = srv.telemetryInitializer.mp
 
+	err = obsreport.RecordBuildInfo(srv.telemetrySettings, set.BuildInfo.Version, buildCommit(), telAttrs[semconv.AttributeServiceInstanceID])
+	if err != nil {
+		err = fmt.Errorf("failed to record build info: %w", err)
+	} else if set.InternalTelemetry != nil {
+		if err = set.InternalTelemetry.Register(srv.telemetrySettings); err != nil {
+			err = fmt.Errorf("failed to register internal telemetry metrics: %w", err)
+		}
+	}
+	if err != nil {
+		if shutdownErr := srv.telemetryInitializer.shutdown(); shutdownErr != nil {
+			err = multierr.Append(err, fmt.Errorf("failed to shutdown collector telemetry: %w", shutdownErr))
+		}
+		return nil, err
+	}
+
 	// process the configuration and initialize the pipeline
 	if err = srv.initExtensionsAndPipeline(ctx, set, cfg); err != nil {
 		// If pipeline initialization fails then shut down the telemetry server
@@ -169,6 +198,10 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 
 	srv.telemetrySettings.Logger.Info("Shutdown complete.")
 
+	if err := srv.telemetryInitializer.flush(ctx); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to flush collector telemetry: %w", err))
+	}
+
 	if err := srv.telemetry.Shutdown(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown telemetry: %w", err))
 	}
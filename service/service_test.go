@@ -17,6 +17,7 @@ package service
 import (
 	"bufio"
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 	"sync"
@@ -26,6 +27,10 @@ import (
 	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
@@ -37,8 +42,12 @@ import (
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/extension/zpagesextension"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
@@ -317,6 +326,150 @@ func TestServiceTelemetryRestart(t *testing.T) {
 	require.NoError(t, srvTwo.Shutdown(context.Background()))
 }
 
+// TestServiceTelemetryWithMetricReaders tests that the obsreport metrics are exported
+// with the metric readers of the settings, e.g. to push them to a backend with OTLP.
+func TestServiceTelemetryWithMetricReaders(t *testing.T) {
+	gateID := obsreportconfig.UseOtelForInternalMetricsfeatureGate.ID()
+	wasEnabled := obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(gateID, true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(gateID, wasEnabled))
+	}()
+
+	exp := &capturingExporter{}
+	set := newNopSettings()
+	set.MetricReaders = []sdkmetric.Reader{sdkmetric.NewPeriodicReader(exp, sdkmetric.WithInterval(time.Hour))}
+	cfg := newNopConfig()
+	cfg.Telemetry.Metrics.Address = testutil.GetAvailableLocalAddress(t)
+
+	srv, err := New(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+
+	receiverID := component.NewID("fake")
+	rec, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID: receiverID,
+		Transport:  "grpc",
+		ReceiverCreateSettings: receiver.CreateSettings{
+			ID:                receiverID,
+			TelemetrySettings: srv.telemetrySettings,
+			BuildInfo:         set.BuildInfo,
+		},
+	})
+	require.NoError(t, err)
+	ctx := rec.StartTracesOp(context.Background())
+	rec.EndTracesOp(ctx, "otlp", 7, nil)
+
+	// The internal metrics are flushed to the readers on shutdown.
+	require.NoError(t, srv.Shutdown(context.Background()))
+
+	accepted, ok := exp.sum("receiver/accepted_spans", attribute.String("receiver", receiverID.String()))
+	require.True(t, ok, "receiver/accepted_spans was not exported")
+	assert.Equal(t, int64(7), accepted)
+}
+
+// TestServiceTelemetryDroppedByMetricReaders tests that the data points the exporters of
+// the metric readers of the settings fail to export are counted in the internal metrics.
+func TestServiceTelemetryDroppedByMetricReaders(t *testing.T) {
+	gateID := obsreportconfig.UseOtelForInternalMetricsfeatureGate.ID()
+	wasEnabled := obsreportconfig.UseOtelForInternalMetricsfeatureGate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(gateID, true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(gateID, wasEnabled))
+	}()
+
+	failing := &capturingExporter{err: errors.New("backend unavailable")}
+	exp := &capturingExporter{}
+	set := newNopSettings()
+	set.InternalTelemetry = obsreport.NewInternalTelemetry()
+	set.MetricReaders = []sdkmetric.Reader{
+		sdkmetric.NewPeriodicReader(set.InternalTelemetry.WrapExporter(failing, 1), sdkmetric.WithInterval(time.Hour)),
+		sdkmetric.NewPeriodicReader(set.InternalTelemetry.WrapExporter(exp, 1), sdkmetric.WithInterval(time.Hour)),
+	}
+	cfg := newNopConfig()
+	cfg.Telemetry.Metrics.Address = testutil.GetAvailableLocalAddress(t)
+
+	srv, err := New(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	// The internal metrics are flushed to the readers in order on shutdown, the failures
+	// of the first one are exported by the second one.
+	require.NoError(t, srv.Shutdown(context.Background()))
+
+	dropped, ok := exp.sum("obsreport/dropped_internal_telemetry")
+	require.True(t, ok, "obsreport/dropped_internal_telemetry was not exported")
+	assert.Positive(t, dropped)
+}
+
+// capturingExporter is a sdkmetric.Exporter, like the OTLP ones, keeping the exported
+// metrics in memory, or failing to export them with err if set.
+type capturingExporter struct {
+	mu       sync.Mutex
+	err      error
+	exported []metricdata.ResourceMetrics
+}
+
+func (ce *capturingExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	return sdkmetric.DefaultTemporalitySelector(kind)
+}
+
+func (ce *capturingExporter) Aggregation(kind sdkmetric.InstrumentKind) aggregation.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+func (ce *capturingExporter) Export(_ context.Context, rm metricdata.ResourceMetrics) error {
+	if ce.err != nil {
+		return ce.err
+	}
+	ce.mu.Lock()
+	defer ce.mu.Unlock()
+	ce.exported = append(ce.exported, rm)
+	return nil
+}
+
+func (ce *capturingExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (ce *capturingExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// sum returns the value of the last exported data point of the int64 sum with the given
+// name having all the given attributes.
+func (ce *capturingExporter) sum(name string, attrs ...attribute.KeyValue) (int64, bool) {
+	ce.mu.Lock()
+	defer ce.mu.Unlock()
+	var value int64
+	found := false
+	for _, rm := range ce.exported {
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				sum, ok := m.Data.(metricdata.Sum[int64])
+				if !ok || m.Name != name {
+					continue
+				}
+				for _, dp := range sum.DataPoints {
+					if hasAttributes(dp.Attributes, attrs) {
+						value, found = dp.Value, true
+					}
+				}
+			}
+		}
+	}
+	return value, found
+}
+
+// hasAttributes returns whether the set holds all the given attributes.
+func hasAttributes(set attribute.Set, attrs []attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if v, ok := set.Value(attr.Key); !ok || v != attr.Value {
+			return false
+		}
+	}
+	return true
+}
+
 func assertMetrics(t *testing.T, metricsAddr string, expectedLabels map[string]labelValue) {
 	client := &http.Client{}
 	resp, err := client.Get("http://" + metricsAddr + "/metrics")
@@ -360,6 +513,20 @@ func assertMetrics(t *testing.T, metricsAddr string, expectedLabels map[string]l
 			}
 		}
 	}
+
+	// The service records the build info of the collector.
+	info, ok := parsed["otelcol_collector_info"]
+	require.True(t, ok, "otelcol_collector_info not found")
+	require.Len(t, info.Metric, 1)
+	assert.Equal(t, 1.0, info.Metric[0].GetGauge().GetValue())
+	versionFound := false
+	for _, labelPair := range info.Metric[0].Label {
+		if labelPair.GetName() == "version" {
+			versionFound = true
+			assert.Equal(t, metricsVersion, labelPair.GetValue())
+		}
+	}
+	assert.True(t, versionFound, "label version not present")
 }
 
 func assertZPages(t *testing.T, zpagesAddr string) {
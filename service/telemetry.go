@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"runtime/debug"
 	"strings"
 	"unicode"
 
@@ -88,20 +89,23 @@ type telemetryInitializer struct {
 	ocRegistry *ocmetric.Registry
 	mp         metric.MeterProvider
 	servers    []*http.Server
+	// readers are the additional readers of the OpenTelemetry meter provider.
+	readers []sdkmetric.Reader
 
 	useOtel                bool
 	disableHighCardinality bool
 }
 
-func newColTelemetry(useOtel bool, disableHighCardinality bool) *telemetryInitializer {
+func newColTelemetry(useOtel bool, disableHighCardinality bool, readers []sdkmetric.Reader) *telemetryInitializer {
 	return &telemetryInitializer{
 		mp:                     metric.NewNoopMeterProvider(),
+		readers:                readers,
 		useOtel:                useOtel,
 		disableHighCardinality: disableHighCardinality,
 	}
 }
 
-func (tel *telemetryInitializer) init(buildInfo component.BuildInfo, logger *zap.Logger, cfg telemetry.Config, asyncErrorChannel chan error) error {
+func (tel *telemetryInitializer) init(telAttrs map[string]string, logger *zap.Logger, cfg telemetry.Config, asyncErrorChannel chan error) error {
 	if cfg.Metrics.Level == configtelemetry.LevelNone || cfg.Metrics.Address == "" {
 		logger.Info(
 			"Skipping telemetry setup.",
@@ -113,18 +117,16 @@ func (tel *telemetryInitializer) init(buildInfo component.BuildInfo, logger *zap
 
 	logger.Info("Setting up own telemetry...")
 
-	// Construct telemetry attributes from build info and config's resource attributes.
-	telAttrs := buildTelAttrs(buildInfo, cfg)
-
 	if tp, err := textMapPropagatorFromConfig(cfg.Traces.Propagators); err == nil {
 		otel.SetTextMapPropagator(tp)
 	} else {
 		return err
 	}
 
-	return tel.initPrometheus(logger, cfg.Metrics.Address, cfg.Metrics.Level, telAttrs, asyncErrorChannel)
+	return tel.initPrometheus(logger, cfg.Metrics.Address, cfg.Metrics.Level, cfg.Metrics.IncludeInstanceID, telAttrs, asyncErrorChannel)
 }
 
+// buildTelAttrs constructs the telemetry attributes from build info and config's resource attributes.
 func buildTelAttrs(buildInfo component.BuildInfo, cfg telemetry.Config) map[string]string {
 	telAttrs := map[string]string{}
 
@@ -156,14 +158,29 @@ func buildTelAttrs(buildInfo component.BuildInfo, cfg telemetry.Config) map[stri
 	return telAttrs
 }
 
-func (tel *telemetryInitializer) initPrometheus(logger *zap.Logger, address string, level configtelemetry.Level, telAttrs map[string]string, asyncErrorChannel chan error) error {
+// buildCommit returns the revision the collector was built from, if it was built from a
+// version control checkout, or an empty string otherwise.
+func buildCommit() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+	return ""
+}
+
+func (tel *telemetryInitializer) initPrometheus(logger *zap.Logger, address string, level configtelemetry.Level, includeInstanceID bool, telAttrs map[string]string, asyncErrorChannel chan error) error {
 	promRegistry := prometheus.NewRegistry()
 	if tel.useOtel {
 		if err := tel.initOpenTelemetry(telAttrs, promRegistry); err != nil {
 			return err
 		}
 	} else {
-		if err := tel.initOpenCensus(level, telAttrs, promRegistry); err != nil {
+		if err := tel.initOpenCensus(level, includeInstanceID, telAttrs, promRegistry); err != nil {
 			return err
 		}
 	}
@@ -189,11 +206,14 @@ func (tel *telemetryInitializer) initPrometheus(logger *zap.Logger, address stri
 	return nil
 }
 
-func (tel *telemetryInitializer) initOpenCensus(level configtelemetry.Level, telAttrs map[string]string, promRegistry *prometheus.Registry) error {
+func (tel *telemetryInitializer) initOpenCensus(level configtelemetry.Level, includeInstanceID bool, telAttrs map[string]string, promRegistry *prometheus.Registry) error {
 	tel.ocRegistry = ocmetric.NewRegistry()
 	metricproducer.GlobalManager().AddProducer(tel.ocRegistry)
 
 	tel.views = obsreportconfig.AllViews(level)
+	if includeInstanceID {
+		tel.views = obsreportconfig.WithInstanceIDTag(tel.views)
+	}
 	if err := view.Register(tel.views...); err != nil {
 		return err
 	}
@@ -264,15 +284,26 @@ func (tel *telemetryInitializer) initOpenTelemetry(attrs map[string]string, prom
 			AttributeFilter: cardinalityFilter(httpUnacceptableKeyValues...),
 		}))
 	}
-	tel.mp = sdkmetric.NewMeterProvider(
+	opts := []sdkmetric.Option{
 		sdkmetric.WithResource(res),
 		sdkmetric.WithReader(exporter),
 		sdkmetric.WithView(views...),
-	)
+	}
+	for _, reader := range tel.readers {
+		// Like the Prometheus exporter, also read the metrics still recorded with OpenCensus.
+		reader.RegisterProducer(opencensus.NewMetricProducer())
+		opts = append(opts, sdkmetric.WithReader(reader))
+	}
+	tel.mp = sdkmetric.NewMeterProvider(opts...)
 
 	return nil
 }
 
+// flush flushes the internal metrics recorded so far, so they are not lost on shutdown.
+func (tel *telemetryInitializer) flush(ctx context.Context) error {
+	return obsreport.Flush(ctx, tel.mp)
+}
+
 func (tel *telemetryInitializer) shutdown() error {
 	metricproducer.GlobalManager().DeleteProducer(tel.ocRegistry)
 	view.Unregister(tel.views...)
@@ -283,6 +314,12 @@ func (tel *telemetryInitializer) shutdown() error {
 			errs = multierr.Append(errs, server.Close())
 		}
 	}
+	if _, ok := tel.mp.(*sdkmetric.MeterProvider); ok {
+		// The readers only started reading if the meter provider was created.
+		for _, reader := range tel.readers {
+			errs = multierr.Append(errs, reader.Shutdown(context.Background()))
+		}
+	}
 	return errs
 }
 
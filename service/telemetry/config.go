@@ -116,6 +116,12 @@ type MetricsConfig struct {
 
 	// Address is the [address]:port that metrics exposition should be bound to.
 	Address string `mapstructure:"address"`
+
+	// IncludeInstanceID registers the OpenCensus views of the internal metrics with the
+	// collector.instance.id tag, for the components setting the InstanceID of their obsreport
+	// settings. It is disabled by default, so the other series are not exported with an
+	// empty collector.instance.id label.
+	IncludeInstanceID bool `mapstructure:"include_instance_id"`
 }
 
 // TracesConfig exposes the common Telemetry configuration for collector's internal spans.
@@ -32,6 +32,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/internal/testutil"
 	semconv "go.opentelemetry.io/collector/semconv/v1.18.0"
 	"go.opentelemetry.io/collector/service/telemetry"
@@ -186,7 +187,7 @@ func TestTelemetryInit(t *testing.T) {
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			tel := newColTelemetry(tc.useOtel, tc.disableHighCard)
+			tel := newColTelemetry(tc.useOtel, tc.disableHighCard, nil)
 			buildInfo := component.NewDefaultBuildInfo()
 			cfg := telemetry.Config{
 				Resource: map[string]*string{
@@ -198,7 +199,7 @@ func TestTelemetryInit(t *testing.T) {
 				},
 			}
 
-			err := tel.init(buildInfo, zap.NewNop(), cfg, make(chan error))
+			err := tel.init(buildTelAttrs(buildInfo, cfg), zap.NewNop(), cfg, make(chan error))
 			require.NoError(t, err)
 			defer func() {
 				require.NoError(t, tel.shutdown())
@@ -230,6 +231,29 @@ func TestTelemetryInit(t *testing.T) {
 	}
 }
 
+func TestTelemetryInitIncludeInstanceID(t *testing.T) {
+	for _, includeInstanceID := range []bool{false, true} {
+		tel := newColTelemetry(false, false, nil)
+		cfg := telemetry.Config{
+			Metrics: telemetry.MetricsConfig{
+				Level:             configtelemetry.LevelNormal,
+				Address:           testutil.GetAvailableLocalAddress(t),
+				IncludeInstanceID: includeInstanceID,
+			},
+		}
+		require.NoError(t, tel.init(buildTelAttrs(component.NewDefaultBuildInfo(), cfg), zap.NewNop(), cfg, make(chan error)))
+
+		registered := view.Find(obsmetrics.ReceiverAcceptedSpans.Name())
+		require.NotNil(t, registered)
+		if includeInstanceID {
+			assert.Contains(t, registered.TagKeys, obsmetrics.TagKeyCollectorInstanceID)
+		} else {
+			assert.NotContains(t, registered.TagKeys, obsmetrics.TagKeyCollectorInstanceID)
+		}
+		require.NoError(t, tel.shutdown())
+	}
+}
+
 func createTestMetrics(t *testing.T, mp metric.MeterProvider) *view.View {
 	// Creates a OTel Go counter
 	counter, err := mp.Meter("collector_test").Int64Counter(otelPrefix+counterName, instrument.WithUnit("ms"))